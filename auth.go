@@ -1,6 +1,7 @@
 package epo_ops
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -17,8 +18,8 @@ const (
 	// defaultAuthURL is the default EPO OPS OAuth2 token endpoint
 	defaultAuthURL = "https://ops.epo.org/3.2/auth/accesstoken"
 
-	// tokenRefreshBuffer is the time before expiry when we should refresh the token
-	tokenRefreshBuffer = 5 * time.Minute
+	// defaultTokenRefreshBuffer is the default time before expiry when we should refresh the token
+	defaultTokenRefreshBuffer = 60 * time.Second
 )
 
 // Authenticator handles OAuth2 authentication for the EPO OPS API.
@@ -28,6 +29,7 @@ type Authenticator struct {
 	consumerSecret string
 	token          string
 	tokenExpiry    time.Time
+	refreshBuffer  time.Duration
 	httpClient     *http.Client
 	mu             sync.RWMutex
 }
@@ -51,15 +53,19 @@ func NewAuthenticator(consumerKey, consumerSecret string, httpClient *http.Clien
 		authURL:        defaultAuthURL,
 		consumerKey:    consumerKey,
 		consumerSecret: consumerSecret,
+		refreshBuffer:  defaultTokenRefreshBuffer,
 		httpClient:     httpClient,
 	}
 }
 
 // GetToken returns a valid access token, refreshing it if necessary.
+//
+// The cached token is refreshed proactively once it is within the refresh buffer
+// of its expiry, so requests near the token boundary do not fail with 401.
 func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	// Check if we have a valid cached token
 	a.mu.RLock()
-	if a.token != "" && time.Now().Add(tokenRefreshBuffer).Before(a.tokenExpiry) {
+	if a.token != "" && time.Now().Add(a.refreshBuffer).Before(a.tokenExpiry) {
 		token := a.token
 		a.mu.RUnlock()
 		return token, nil
@@ -71,7 +77,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	defer a.mu.Unlock()
 
 	// Double-check after acquiring write lock (another goroutine might have refreshed)
-	if a.token != "" && time.Now().Add(tokenRefreshBuffer).Before(a.tokenExpiry) {
+	if a.token != "" && time.Now().Add(a.refreshBuffer).Before(a.tokenExpiry) {
 		return a.token, nil
 	}
 
@@ -114,10 +120,28 @@ func (a *Authenticator) requestToken(ctx context.Context) (string, error) {
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return "", &AuthError{
+		message := fmt.Sprintf("token request failed with status %d: %s", resp.StatusCode, string(body))
+
+		// Throttled or blocked token requests are a quota problem, not bad credentials
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			quotaErr := &QuotaExceededError{
+				Message: message,
+				Status:  resp.Header.Get("X-Throttling-Control"),
+			}
+			if opsErr, err := parseErrorXML(body, resp.StatusCode); err == nil {
+				quotaErr.Cause = opsErr
+			}
+			return "", quotaErr
+		}
+
+		authErr := &AuthError{
 			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("token request failed with status %d: %s", resp.StatusCode, string(body)),
+			Message:    message,
 		}
+		if isRejectedCredentials(resp.StatusCode, body) {
+			authErr.Kind = KindInvalidCredentials
+		}
+		return "", authErr
 	}
 
 	// Parse JSON response
@@ -146,6 +170,27 @@ func (a *Authenticator) requestToken(ctx context.Context) (string, error) {
 	return a.token, nil
 }
 
+// isRejectedCredentials reports whether a token endpoint error rejects the consumer key
+// or secret: the endpoint answers those with 400 or 401 and an invalid_client or
+// invalid_grant error. Other client and server errors say nothing about the credentials.
+func isRejectedCredentials(statusCode int, body []byte) bool {
+	if statusCode != http.StatusBadRequest && statusCode != http.StatusUnauthorized {
+		return false
+	}
+	return bytes.Contains(body, []byte("invalid_client")) || bytes.Contains(body, []byte("invalid_grant"))
+}
+
+// SetRefreshBuffer sets how long before expiry the cached token is refreshed.
+// Non-positive values are ignored.
+func (a *Authenticator) SetRefreshBuffer(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.refreshBuffer = d
+}
+
 // ClearToken clears the cached token, forcing a refresh on next request.
 func (a *Authenticator) ClearToken() {
 	a.mu.Lock()
@@ -0,0 +1,176 @@
+package epo_ops
+
+import (
+	"bytes"
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL is how long cached responses stay valid unless Config.CacheTTL is set
+const defaultCacheTTL = 24 * time.Hour
+
+// ResponseCache stores successful API responses to avoid repeated requests
+// (and quota usage) for data that rarely changes, such as bibliographic data.
+//
+// Set Config.Cache to enable caching. Keys identify the API method and its
+// parameters (e.g., "GetBiblioRaw|publication|docdb|EP.1000000.B1"). Only
+// successful responses of single-document and classification lookups are
+// cached; errors, images, bulk (POST) requests, searches, usage statistics and
+// the volatile legal and register data never are. Implementations must be safe for concurrent use.
+//
+// Stored values are opaque to the cache: they hold the response body together
+// with its ETag and storage time. Responses with an ETag are stored without
+// expiry; once older than Config.CacheTTL they are revalidated with a
+// conditional request (If-None-Match), and a 304 Not Modified answer reuses
+// the cached body instead of downloading it again.
+type ResponseCache interface {
+	// Get returns the cached response for key, if present and not expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores a response for key. A ttl <= 0 means the entry does not expire.
+	Set(key string, data []byte, ttl time.Duration)
+}
+
+// LRUCache is an in-memory ResponseCache that evicts the least recently used
+// entry once it holds the configured number of entries.
+type LRUCache struct {
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // front = most recently used
+	mu         sync.Mutex
+}
+
+// lruEntry is a single cached response
+type lruEntry struct {
+	key     string
+	data    []byte
+	expires time.Time // zero = never
+}
+
+// NewLRUCache creates an in-memory LRU cache holding at most maxEntries responses.
+// Values < 1 are treated as 1.
+//
+// Example:
+//
+//	client, err := ops.NewClient(&ops.Config{
+//	    ConsumerKey:    "key",
+//	    ConsumerSecret: "secret",
+//	    Cache:          ops.NewLRUCache(1000),
+//	})
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &LRUCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the cached response for key, if present and not expired.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.data, true
+}
+
+// Set stores a response for key, evicting the least recently used entry if the cache is full.
+func (c *LRUCache) Set(key string, data []byte, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.data = data
+		entry.expires = expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, data: data, expires: expires})
+
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of cached entries (including expired ones not yet evicted).
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// cacheKey builds a ResponseCache key from the API method name and its parameters.
+func cacheKey(method string, params ...string) string {
+	return method + "|" + strings.Join(params, "|")
+}
+
+// ifNoneMatchKey is the context key under which executeRequest passes the ETag
+// of a stale cached response to authTransport.
+type ifNoneMatchKey struct{}
+
+// cachedResponse is a response body stored in a ResponseCache with its validator.
+type cachedResponse struct {
+	ETag     string
+	StoredAt time.Time
+	Body     []byte
+}
+
+// fresh reports whether the entry is younger than ttl (always true for ttl <= 0).
+func (r *cachedResponse) fresh(ttl time.Duration) bool {
+	return ttl <= 0 || time.Since(r.StoredAt) < ttl
+}
+
+// encode serializes the entry as "<etag>\n<stored-at>\n<body>".
+func (r *cachedResponse) encode() []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(r.ETag) + len(r.Body) + 40)
+	buf.WriteString(r.ETag)
+	buf.WriteByte('\n')
+	buf.WriteString(r.StoredAt.Format(time.RFC3339Nano))
+	buf.WriteByte('\n')
+	buf.Write(r.Body)
+	return buf.Bytes()
+}
+
+// decodeCachedResponse parses an entry written by encode.
+func decodeCachedResponse(data []byte) (*cachedResponse, bool) {
+	etag, rest, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+	storedAt, body, ok := bytes.Cut(rest, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(storedAt))
+	if err != nil {
+		return nil, false
+	}
+	return &cachedResponse{ETag: string(etag), StoredAt: t, Body: body}, true
+}
@@ -0,0 +1,303 @@
+package epo_ops
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("Evicts least recently used entry", func(t *testing.T) {
+		cache := NewLRUCache(2)
+		cache.Set("a", []byte("A"), 0)
+		cache.Set("b", []byte("B"), 0)
+
+		// Touch "a" so that "b" becomes the least recently used entry
+		if _, ok := cache.Get("a"); !ok {
+			t.Fatal("Expected cache hit for a")
+		}
+		cache.Set("c", []byte("C"), 0)
+
+		if _, ok := cache.Get("b"); ok {
+			t.Error("Expected b to be evicted")
+		}
+		if data, ok := cache.Get("a"); !ok || string(data) != "A" {
+			t.Errorf("Expected a=A, got %q (hit: %v)", data, ok)
+		}
+		if data, ok := cache.Get("c"); !ok || string(data) != "C" {
+			t.Errorf("Expected c=C, got %q (hit: %v)", data, ok)
+		}
+		if cache.Len() != 2 {
+			t.Errorf("Expected 2 entries, got %d", cache.Len())
+		}
+	})
+
+	t.Run("Expired entries are not returned", func(t *testing.T) {
+		cache := NewLRUCache(10)
+		cache.Set("short", []byte("x"), time.Millisecond)
+		cache.Set("forever", []byte("y"), 0)
+
+		time.Sleep(5 * time.Millisecond)
+
+		if _, ok := cache.Get("short"); ok {
+			t.Error("Expected expired entry to be a cache miss")
+		}
+		if _, ok := cache.Get("forever"); !ok {
+			t.Error("Expected entry without TTL to be a cache hit")
+		}
+	})
+
+	t.Run("Set replaces existing entry", func(t *testing.T) {
+		cache := NewLRUCache(10)
+		cache.Set("a", []byte("old"), 0)
+		cache.Set("a", []byte("new"), 0)
+
+		if data, _ := cache.Get("a"); string(data) != "new" {
+			t.Errorf("Expected new, got %q", data)
+		}
+		if cache.Len() != 1 {
+			t.Errorf("Expected 1 entry, got %d", cache.Len())
+		}
+	})
+}
+
+func TestClientCache(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var requests atomic.Int32
+	var fail atomic.Bool
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(loadTestData("error_404.xml"))
+			return
+		}
+		if strings.Contains(r.URL.Path, "/images/") {
+			w.Header().Set("Content-Type", "image/tiff")
+			_, _ = w.Write(minimalTIFF(false))
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		if strings.HasSuffix(r.URL.Path, "/claims") {
+			_, _ = w.Write(loadTestData("claims.xml"))
+			return
+		}
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	cache := NewLRUCache(10)
+	client, err := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		Cache:          cache,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// The second lookup of the same document is served from the cache
+	first, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetBiblio failed: %v", err)
+	}
+	second, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("Cached GetBiblio failed: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected 1 HTTP request, got %d", got)
+	}
+	if first.PatentNumber != second.PatentNumber {
+		t.Errorf("Cached result differs: %q vs %q", first.PatentNumber, second.PatentNumber)
+	}
+
+	// A different document is a cache miss
+	if _, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000001.A1"); err != nil {
+		t.Fatalf("GetBiblio failed: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 HTTP requests, got %d", got)
+	}
+
+	// Images are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetImage(ctx, "EP", "1000000", "B1", ImageTypeThumbnail, 1); err != nil {
+			t.Fatalf("GetImage failed: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Expected images to bypass the cache (4 requests), got %d", got)
+	}
+
+	// Error responses are never cached
+	fail.Store(true)
+	if _, err := client.GetClaims(ctx, "publication", "docdb", "EP.1000000.B1"); err == nil {
+		t.Fatal("Expected error for 404 response")
+	}
+	fail.Store(false)
+	if _, err := client.GetClaims(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("GetClaims failed after error: %v", err)
+	}
+	if got := requests.Load(); got != 6 {
+		t.Errorf("Expected error response not to be cached (6 requests), got %d", got)
+	}
+	if cache.Len() != 3 {
+		t.Errorf("Expected 3 cached responses, got %d", cache.Len())
+	}
+
+	// Legal and register status changes too often to be cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetLegalRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+			t.Fatalf("GetLegalRaw failed: %v", err)
+		}
+		if _, err := client.GetRegisterEventsRaw(ctx, "publication", "epodoc", "EP1000000"); err != nil {
+			t.Fatalf("GetRegisterEventsRaw failed: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 10 {
+		t.Errorf("Expected legal and register lookups to bypass the cache (10 requests), got %d", got)
+	}
+	if cache.Len() != 3 {
+		t.Errorf("Expected 3 cached responses, got %d", cache.Len())
+	}
+}
+
+func TestClientCache_ETagRevalidation(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var requests atomic.Int32
+	var ifNoneMatch atomic.Value
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		ifNoneMatch.Store(r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		Cache:          NewLRUCache(10),
+		CacheTTL:       time.Nanosecond, // every lookup revalidates
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	first, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetBiblio failed: %v", err)
+	}
+	if got := ifNoneMatch.Load(); got != "" {
+		t.Errorf("Expected no If-None-Match on first request, got %q", got)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	// The stale entry is revalidated and the server answers 304 Not Modified
+	second, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("Revalidated GetBiblio failed: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 HTTP requests, got %d", got)
+	}
+	if got := ifNoneMatch.Load(); got != `"v1"` {
+		t.Errorf("Expected If-None-Match %q, got %q", `"v1"`, got)
+	}
+	if first.PatentNumber == "" || first.PatentNumber != second.PatentNumber {
+		t.Errorf("Expected cached body on 304: %q vs %q", first.PatentNumber, second.PatentNumber)
+	}
+}
+
+func TestClientClassificationCache(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var requests atomic.Int32
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("classification_schema_subclass.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewClient(&Config{
+		ConsumerKey:          "test",
+		ConsumerSecret:       "test",
+		BaseURL:              opsServer.URL,
+		AuthURL:              authServer.URL + "/auth/accesstoken",
+		CacheClassifications: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if client.config.ClassificationCacheTTL != 24*time.Hour {
+		t.Errorf("Expected default ClassificationCacheTTL of 24h, got %v", client.config.ClassificationCacheTTL)
+	}
+
+	ctx := context.Background()
+
+	// The second identical schema request is served from the cache
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetClassificationSchemaRaw(ctx, "H04W", false, false); err != nil {
+			t.Fatalf("GetClassificationSchemaRaw failed: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected 1 HTTP request, got %d", got)
+	}
+
+	// Different flags are a cache miss
+	if _, err := client.GetClassificationSchemaRaw(ctx, "H04W", true, false); err != nil {
+		t.Fatalf("GetClassificationSchemaRaw failed: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 HTTP requests, got %d", got)
+	}
+
+	// Bulk lookups are cached by their class list
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetClassificationSchemaMultipleRaw(ctx, []string{"H04W", "H04L"}); err != nil {
+			t.Fatalf("GetClassificationSchemaMultipleRaw failed: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 HTTP requests, got %d", got)
+	}
+
+	// Without the option, bulk lookups are never cached
+	uncached, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := uncached.GetClassificationSchemaMultipleRaw(ctx, []string{"H04W", "H04L"}); err != nil {
+			t.Fatalf("GetClassificationSchemaMultipleRaw failed: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 5 {
+		t.Errorf("Expected 5 HTTP requests, got %d", got)
+	}
+}
@@ -0,0 +1,102 @@
+package epo_ops
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultCircuitBreakerCooldown is how long an open circuit rejects requests by default.
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// circuitBreaker stops requests during EPO outages.
+//
+// It opens after threshold consecutive calls failed with a ServiceUnavailableError or
+// without any response, e.g. refused connections (after their retries). While open,
+// calls fail with a CircuitOpenError without a request. Once the cooldown has passed,
+// a single probe call is let through: a response below 500 closes the circuit, another
+// such failure opens it again. Other server errors leave the breaker as it is.
+// A nil circuitBreaker lets all requests through.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int       // consecutive failed calls
+	openUntil time.Time // zero while the circuit is closed
+	probing   bool      // a probe call is in flight
+}
+
+// newCircuitBreaker returns a breaker for the threshold, or nil if threshold disables it.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may send its request. probe is true for the single
+// call let through after the cooldown; its outcome must be passed to record.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return false, nil
+	}
+	if wait := time.Until(b.openUntil); wait > 0 || b.probing {
+		return false, &CircuitOpenError{Failures: b.failures, RetryAfter: max(wait, 0)}
+	}
+	b.probing = true
+	return true, nil
+}
+
+// record updates the breaker with the outcome of a call. status is the HTTP status of
+// the final response, or 0 if the call got none.
+func (b *circuitBreaker) record(probe bool, status int, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	var unavailable *ServiceUnavailableError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		// The caller gave up; this says nothing about the service
+	case errors.As(err, &unavailable), status == 0 && isTransportError(err):
+		// No response at all (DNS failure, refused or reset connection) is an outage too
+		b.failures++
+		if probe || b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+	case status > 0 && status < http.StatusInternalServerError:
+		// Any other response, including error responses, shows the service is up
+		b.failures = 0
+		b.openUntil = time.Time{}
+	}
+}
+
+// isTransportError reports whether err means the request got no HTTP response because
+// the connection to the service failed.
+func isTransportError(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,179 @@
+package epo_ops
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var down atomic.Bool
+	var requests atomic.Int32
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	const cooldown = 100 * time.Millisecond
+	client, err := NewClient(&Config{
+		ConsumerKey:             "test",
+		ConsumerSecret:          "test",
+		BaseURL:                 opsServer.URL,
+		AuthURL:                 authServer.URL + "/auth/accesstoken",
+		MaxRetries:              1,
+		RetryDelay:              time.Millisecond,
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  cooldown,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	call := func() error {
+		_, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1")
+		return err
+	}
+	var unavailable *ServiceUnavailableError
+	var circuitErr *CircuitOpenError
+
+	// Two failed calls (with their retries) open the circuit
+	down.Store(true)
+	for i := range 2 {
+		if err := call(); !errors.As(err, &unavailable) {
+			t.Fatalf("Call %d: expected ServiceUnavailableError, got %v", i+1, err)
+		}
+	}
+	sent := requests.Load()
+	if err := call(); !errors.As(err, &circuitErr) {
+		t.Fatalf("Expected CircuitOpenError, got %v", err)
+	}
+	if circuitErr.Failures != 2 || circuitErr.RetryAfter <= 0 || circuitErr.RetryAfter > cooldown {
+		t.Errorf("CircuitOpenError: got %+v", circuitErr)
+	}
+	if !IsRetryable(circuitErr) {
+		t.Error("Expected CircuitOpenError to be retryable")
+	}
+	if got := requests.Load(); got != sent {
+		t.Errorf("Open circuit sent %d requests", got-sent)
+	}
+
+	// After the cooldown a failing probe opens the circuit again at once
+	time.Sleep(cooldown)
+	if err := call(); !errors.As(err, &unavailable) {
+		t.Fatalf("Probe: expected ServiceUnavailableError, got %v", err)
+	}
+	if err := call(); !errors.As(err, &circuitErr) {
+		t.Fatalf("Expected CircuitOpenError after failed probe, got %v", err)
+	}
+
+	// A successful probe closes it
+	down.Store(false)
+	time.Sleep(cooldown)
+	for i := range 2 {
+		if err := call(); err != nil {
+			t.Fatalf("Call %d after recovery failed: %v", i+1, err)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	b := newCircuitBreaker(1, 20*time.Millisecond)
+	unavailable := &ServiceUnavailableError{StatusCode: http.StatusServiceUnavailable}
+
+	b.record(false, http.StatusServiceUnavailable, unavailable)
+	if _, err := b.allow(); err == nil {
+		t.Fatal("Expected open circuit")
+	}
+
+	// Only one probe is let through after the cooldown
+	time.Sleep(20 * time.Millisecond)
+	probe, err := b.allow()
+	if !probe || err != nil {
+		t.Fatalf("Expected probe, got %v, %v", probe, err)
+	}
+	if _, err := b.allow(); err == nil {
+		t.Error("Expected second call to be rejected while probing")
+	}
+
+	// A cancelled probe leaves the circuit half-open for the next call
+	b.record(true, 0, context.Canceled)
+	if probe, err := b.allow(); !probe || err != nil {
+		t.Fatalf("Expected new probe, got %v, %v", probe, err)
+	}
+
+	// Any response below 500 closes the circuit
+	b.record(true, http.StatusNotFound, &NotFoundError{Message: "missing"})
+	if probe, err := b.allow(); probe || err != nil {
+		t.Errorf("Expected closed circuit, got %v, %v", probe, err)
+	}
+
+	// Disabled breakers let everything through
+	disabled := newCircuitBreaker(0, time.Second)
+	disabled.record(false, http.StatusServiceUnavailable, unavailable)
+	if _, err := disabled.allow(); err != nil {
+		t.Errorf("Disabled breaker rejected a call: %v", err)
+	}
+}
+
+func TestCircuitBreakerTransportErrors(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// A closed server refuses connections, as during an outage
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	baseURL := opsServer.URL
+	opsServer.Close()
+
+	client, err := NewClient(&Config{
+		ConsumerKey:             "test",
+		ConsumerSecret:          "test",
+		BaseURL:                 baseURL,
+		AuthURL:                 authServer.URL + "/auth/accesstoken",
+		MaxRetries:              1,
+		RetryDelay:              time.Millisecond,
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := range 2 {
+		if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err == nil {
+			t.Fatalf("Call %d: expected connection error", i+1)
+		}
+	}
+	var circuitErr *CircuitOpenError
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); !errors.As(err, &circuitErr) {
+		t.Fatalf("Expected CircuitOpenError after refused connections, got %v", err)
+	}
+
+	// Server errors other than 503 neither reset nor trip the breaker
+	b := newCircuitBreaker(2, time.Minute)
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	b.record(false, 0, refused)
+	b.record(false, http.StatusInternalServerError, &OPSError{HTTPStatus: http.StatusInternalServerError})
+	if b.failures != 1 {
+		t.Errorf("Expected 500 response to leave 1 failure, got %d", b.failures)
+	}
+	b.record(false, 0, refused)
+	if _, err := b.allow(); err == nil {
+		t.Error("Expected open circuit after two refused connections")
+	}
+}
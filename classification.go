@@ -0,0 +1,136 @@
+package epo_ops
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Classification symbols - parsing and formatting of CPC and IPC symbols.
+
+var (
+	// CPC symbol: section letter, two-digit class, subclass letter, main group, "/", subgroup
+	// (e.g., H04W 84/20). Spaces between the parts are optional.
+	cpcSymbolPattern = regexp.MustCompile(`^([A-Z])(\d{2})([A-Z])\s*(\d{1,4})\s*/\s*(\d{2,6})$`)
+
+	// IPCR text as found in bibliographic data: the symbol followed by optional version date,
+	// level, position, value, action date, status, source and office fields. The fields are
+	// fixed-width in the original format ("H04L  29/06        20060101AFI20120302BHEP")
+	// but frequently reach clients with collapsed or extra whitespace, so spacing is free.
+	ipcrTextPattern = regexp.MustCompile(`^([A-Z])(\d{2})([A-Z])\s*(\d{1,4})\s*/\s*(\d{2,6})` +
+		`(?:\s+(\d{8}))?\s*([ACS])?\s*([FL])?\s*([IN])?\s*(\d{8})?\s*([BOR])?\s*([HMGD])?\s*([A-Z]{2})?$`)
+
+	// Version indicator appended to symbols in biblio data and classification services,
+	// either as a date (20130101) or in parentheses ((2013.01))
+	classificationVersionPattern = regexp.MustCompile(`\s+(\d{8}|\(\d{4}\.\d{2}\))$`)
+)
+
+// ParseCPCSymbol parses a CPC symbol string into its components.
+//
+// Accepted spellings include:
+//   - "H04W84/20"
+//   - "H04W 84/20"
+//   - "H04W 84/20 20130101" (trailing version date is ignored)
+//   - "H04W 84/20 (2013.01)"
+//
+// Letters are accepted in either case. Returns a ValidationError if the symbol is not a
+// complete CPC group symbol (section letter, two-digit class, subclass letter, main group
+// of 1-4 digits, subgroup of 2-6 digits).
+//
+// Example:
+//
+//	class, err := ops.ParseCPCSymbol("H04W84/20")
+//	fmt.Println(class.MainGroup, class.String()) // 84 H04W 84/20
+func ParseCPCSymbol(s string) (CPCClass, error) {
+	symbol := strings.ToUpper(strings.TrimSpace(s))
+	symbol = classificationVersionPattern.ReplaceAllString(symbol, "")
+
+	match := cpcSymbolPattern.FindStringSubmatch(symbol)
+	if match == nil {
+		return CPCClass{}, &ValidationError{
+			Field:   "symbol",
+			Value:   s,
+			Message: "must be a CPC symbol: section letter, two-digit class, subclass letter, main group/subgroup (e.g., H04W 84/20)",
+		}
+	}
+
+	class := CPCClass{
+		Section:   match[1],
+		Class:     match[2],
+		Subclass:  match[3],
+		MainGroup: match[4],
+		Subgroup:  match[5],
+	}
+	class.Full = class.String()
+	return class, nil
+}
+
+// String returns the canonical form of the symbol (e.g., "H04W 84/20"),
+// the same format as Full in parsed bibliographic data.
+// Symbols without a group are rendered down to the subclass (e.g., "H04W").
+func (c CPCClass) String() string {
+	subclass := c.Section + c.Class + c.Subclass
+	if c.MainGroup == "" {
+		return subclass
+	}
+	return fmt.Sprintf("%s %s/%s", subclass, c.MainGroup, c.Subgroup)
+}
+
+// ParseIPCSymbol parses an IPC symbol or IPCR text into its components.
+//
+// Plain symbols ("H04W84/20", "H04W 84/20") and the IPCR texts of bibliographic
+// data are accepted, e.g.:
+//   - "H04W  84/20        20130101        A        F        I"
+//   - "H04L  29/06        20060101AFI20120302BHEP"
+//   - "H04W  84/    20            A I"
+//
+// Trailing fields are parsed into Version, Level, Position, Value, ActionDate,
+// Status, Source and Office. Returns a ValidationError if the text does not start
+// with a complete IPC group symbol or contains unrecognized trailing fields.
+//
+// Example:
+//
+//	class, err := ops.ParseIPCSymbol(biblio.IPCClasses[0])
+//	if err == nil && class.Value == "I" {
+//	    fmt.Println("inventive:", class.String())
+//	}
+func ParseIPCSymbol(s string) (IPCClass, error) {
+	text := strings.ToUpper(strings.TrimSpace(s))
+
+	match := ipcrTextPattern.FindStringSubmatch(text)
+	if match == nil {
+		return IPCClass{}, &ValidationError{
+			Field:   "symbol",
+			Value:   s,
+			Message: "must be an IPC symbol: section letter, two-digit class, subclass letter, main group/subgroup (e.g., H04W 84/20), optionally followed by IPCR fields",
+		}
+	}
+
+	class := IPCClass{
+		Section:    match[1],
+		Class:      match[2],
+		Subclass:   match[3],
+		MainGroup:  match[4],
+		Subgroup:   match[5],
+		Version:    match[6],
+		Level:      match[7],
+		Position:   match[8],
+		Value:      match[9],
+		ActionDate: match[10],
+		Status:     match[11],
+		Source:     match[12],
+		Office:     match[13],
+	}
+	class.Full = class.String()
+	return class, nil
+}
+
+// String returns the canonical form of the symbol (e.g., "H04W 84/20"), without IPCR fields.
+// Symbols without a group are rendered down to the subclass (e.g., "H04W").
+func (c IPCClass) String() string {
+	subclass := c.Section + c.Class + c.Subclass
+	if c.MainGroup == "" {
+		return subclass
+	}
+	return fmt.Sprintf("%s %s/%s", subclass, c.MainGroup, c.Subgroup)
+}
@@ -2,10 +2,149 @@ package epo_ops
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 )
 
+func TestParseCPCSymbol(t *testing.T) {
+	want := CPCClass{Section: "H", Class: "04", Subclass: "W", MainGroup: "84", Subgroup: "20", Full: "H04W 84/20"}
+
+	valid := []string{
+		"H04W84/20",
+		"H04W 84/20",
+		"H04W  84/20",
+		"H04W 84 / 20",
+		"H04W 84/20 20130101",
+		"H04W 84/20 (2013.01)",
+		"  h04w 84/20  ",
+	}
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			got, err := ParseCPCSymbol(input)
+			if err != nil {
+				t.Fatalf("ParseCPCSymbol(%q) error = %v", input, err)
+			}
+			if got != want {
+				t.Errorf("ParseCPCSymbol(%q) = %+v, want %+v", input, got, want)
+			}
+			if got.String() != "H04W 84/20" {
+				t.Errorf("String() = %q", got.String())
+			}
+		})
+	}
+
+	// Round trip of longer groups
+	for _, symbol := range []string{"A01D 2085/008", "G06F 16/9535", "Y02E 10/50"} {
+		got, err := ParseCPCSymbol(symbol)
+		if err != nil {
+			t.Fatalf("ParseCPCSymbol(%q) error = %v", symbol, err)
+		}
+		if got.String() != symbol {
+			t.Errorf("Round trip: %q -> %q", symbol, got.String())
+		}
+	}
+
+	malformed := []string{
+		"",
+		"H04W",              // no group
+		"H04W 84",           // no subgroup
+		"HH4W 84/20",        // section must be a single letter
+		"H4W 84/20",         // class must be two digits
+		"H04 84/20",         // missing subclass letter
+		"H04W 84/2",         // subgroup must have at least two digits
+		"H04W 12345/20",     // main group too long
+		"H04W 84/20 extra",  // trailing garbage
+		"H04W 84/20 201301", // not a version date
+		"104W 84/20",
+	}
+	for _, input := range malformed {
+		t.Run("malformed "+input, func(t *testing.T) {
+			_, err := ParseCPCSymbol(input)
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Errorf("ParseCPCSymbol(%q): expected ValidationError, got %v", input, err)
+			}
+		})
+	}
+
+	if got := (CPCClass{Section: "H", Class: "04", Subclass: "W"}).String(); got != "H04W" {
+		t.Errorf("Subclass-only String() = %q, want H04W", got)
+	}
+}
+
+func TestParseIPCSymbol(t *testing.T) {
+	tests := []struct {
+		input string
+		want  IPCClass
+	}{
+		{
+			input: "H04W84/20",
+			want:  IPCClass{Section: "H", Class: "04", Subclass: "W", MainGroup: "84", Subgroup: "20"},
+		},
+		{
+			input: "H04W  84/20        20130101        A        F        I",
+			want: IPCClass{Section: "H", Class: "04", Subclass: "W", MainGroup: "84", Subgroup: "20",
+				Version: "20130101", Level: "A", Position: "F", Value: "I"},
+		},
+		{
+			input: "H04L  29/06        20060101AFI20120302BHEP",
+			want: IPCClass{Section: "H", Class: "04", Subclass: "L", MainGroup: "29", Subgroup: "06",
+				Version: "20060101", Level: "A", Position: "F", Value: "I",
+				ActionDate: "20120302", Status: "B", Source: "H", Office: "EP"},
+		},
+		{
+			input: "G06F  16/9535      20190101ALI20200115BHEP",
+			want: IPCClass{Section: "G", Class: "06", Subclass: "F", MainGroup: "16", Subgroup: "9535",
+				Version: "20190101", Level: "A", Position: "L", Value: "I",
+				ActionDate: "20200115", Status: "B", Source: "H", Office: "EP"},
+		},
+		{
+			// Blank fields in biblio data collapse to single spaces
+			input: "H04W  84/    20            A I                    ",
+			want: IPCClass{Section: "H", Class: "04", Subclass: "W", MainGroup: "84", Subgroup: "20",
+				Level: "A", Value: "I"},
+		},
+		{
+			input: "A61K   8/00        20060101 C N",
+			want: IPCClass{Section: "A", Class: "61", Subclass: "K", MainGroup: "8", Subgroup: "00",
+				Version: "20060101", Level: "C", Value: "N"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseIPCSymbol(tt.input)
+			if err != nil {
+				t.Fatalf("ParseIPCSymbol(%q) error = %v", tt.input, err)
+			}
+			tt.want.Full = tt.want.String()
+			if got != tt.want {
+				t.Errorf("ParseIPCSymbol(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	malformed := []string{
+		"",
+		"H04W",
+		"H4W 84/20",
+		"H04W 84/20 20130101 X",
+		"H04W 84/20 20130101 A F I garbage",
+	}
+	for _, input := range malformed {
+		t.Run("malformed "+input, func(t *testing.T) {
+			_, err := ParseIPCSymbol(input)
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Errorf("ParseIPCSymbol(%q): expected ValidationError, got %v", input, err)
+			}
+		})
+	}
+}
+
 func TestGetClassificationSchemaRaw(t *testing.T) {
 	// Skip if no credentials
 	if testing.Short() {
@@ -339,34 +478,184 @@ func TestGetClassificationMedia(t *testing.T) {
 	}
 }
 
-// isImageData performs basic validation to check if data looks like an image
-func isImageData(data []byte) bool {
-	if len(data) < 4 {
-		return false
+func TestGetClassificationMediaWithInfo(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	gif := []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+	var contentType string
+	var body []byte
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/classification/cpc/media/1000.gif") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body)
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
 	}
 
-	// Check for common image file signatures
-	// GIF: "GIF8"
-	if len(data) >= 4 && string(data[0:4]) == "GIF8" {
-		return true
+	tests := []struct {
+		name            string
+		contentType     string
+		body            []byte
+		wantContentType string
+		wantExtension   string
+	}{
+		{"GIF by header", "image/gif", gif, "image/gif", ".gif"},
+		{"Header with parameters", "image/png; charset=binary", png, "image/png", ".png"},
+		{"GIF by signature", "application/octet-stream", gif, "image/gif", ".gif"},
+		{"Unknown type", "application/octet-stream", []byte("data"), "application/octet-stream", ".bin"},
 	}
 
-	// PNG: 0x89 0x50 0x4E 0x47
-	if len(data) >= 4 && data[0] == 0x89 && data[1] == 0x50 && data[2] == 0x4E && data[3] == 0x47 {
-		return true
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, body = tt.contentType, tt.body
+
+			media, err := client.GetClassificationMediaWithInfo(ctx, "1000.gif", false)
+			if err != nil {
+				t.Fatalf("GetClassificationMediaWithInfo failed: %v", err)
+			}
+			if media.ContentType != tt.wantContentType || media.Extension != tt.wantExtension {
+				t.Errorf("Got (%q, %q), want (%q, %q)", media.ContentType, media.Extension, tt.wantContentType, tt.wantExtension)
+			}
+			if string(media.Data) != string(tt.body) {
+				t.Errorf("Data: got %q, want %q", media.Data, tt.body)
+			}
+		})
 	}
 
-	// JPEG: 0xFF 0xD8 0xFF
-	if len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF {
-		return true
+	if _, err := client.GetClassificationMediaWithInfo(ctx, "", false); err == nil {
+		t.Error("Expected error for empty media name")
 	}
+}
+
+func TestEnrichCPCTitles(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	response := `<?xml version="1.0" encoding="utf-8"?>
+<ops:world-patent-data xmlns:ops="http://ops.epo.org" xmlns:cpc="http://www.epo.org/cpcexport">
+  <ops:classification-scheme><ops:cpc><cpc:class-scheme scheme-type="cpc">
+    <cpc:classification-item level="8" sort-key="H04W84/20">
+      <cpc:classification-symbol>H04W84/20</cpc:classification-symbol>
+      <cpc:class-title><cpc:title-part><cpc:text>Master-slave selection or change arrangements</cpc:text></cpc:title-part></cpc:class-title>
+    </cpc:classification-item>
+  </cpc:class-scheme></ops:cpc></ops:classification-scheme>
+  <ops:classification-scheme><ops:cpc><cpc:class-scheme scheme-type="cpc">
+    <cpc:classification-item level="7" sort-key="H04W4/00">
+      <cpc:classification-symbol>H04W4/00</cpc:classification-symbol>
+      <cpc:class-title>
+        <cpc:title-part><cpc:text>Services specially adapted for wireless communication networks</cpc:text></cpc:title-part>
+        <cpc:title-part><cpc:text>Facilities therefor</cpc:text><cpc:explanation><cpc:text>not part of the title</cpc:text></cpc:explanation></cpc:title-part>
+      </cpc:class-title>
+      <cpc:classification-item level="8" sort-key="H04W4/02">
+        <cpc:classification-symbol>H04W4/02</cpc:classification-symbol>
+        <cpc:class-title><cpc:title-part><cpc:text>Services making use of location information</cpc:text></cpc:title-part></cpc:class-title>
+      </cpc:classification-item>
+    </cpc:classification-item>
+  </cpc:class-scheme></ops:cpc></ops:classification-scheme>
+</ops:world-patent-data>`
+
+	var requests []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		if r.Method != http.MethodPost || r.URL.Path != "/classification/cpc" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(response))
+	})
+	defer opsServer.Close()
 
-	// TIFF: "II" (little-endian) or "MM" (big-endian)
-	if len(data) >= 2 && (string(data[0:2]) == "II" || string(data[0:2]) == "MM") {
-		return true
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		Cache:          NewLRUCache(10),
 	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
 
-	return false
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	newBiblio := func() *BiblioData {
+		biblio := &BiblioData{}
+		for _, symbol := range []string{"H04W 84/20", "H04W 4/00", "H04W84/20", "H04W 99/99"} {
+			class, err := ParseCPCSymbol(symbol)
+			if err != nil {
+				t.Fatalf("ParseCPCSymbol(%q) failed: %v", symbol, err)
+			}
+			biblio.CPCClasses = append(biblio.CPCClasses, class)
+		}
+		return biblio
+	}
+
+	ctx := context.Background()
+	biblio := newBiblio()
+	if err := client.EnrichCPCTitles(ctx, biblio); err != nil {
+		t.Fatalf("EnrichCPCTitles failed: %v", err)
+	}
+
+	want := []string{
+		"Master-slave selection or change arrangements",
+		"Services specially adapted for wireless communication networks; Facilities therefor",
+		"Master-slave selection or change arrangements",
+		"", // unknown to EPO
+	}
+	for i, class := range biblio.CPCClasses {
+		if class.Title != want[i] {
+			t.Errorf("%s: Title = %q, want %q", class.Full, class.Title, want[i])
+		}
+	}
+
+	// Duplicate symbols are requested once, without spaces
+	if len(requests) != 1 || requests[0] != "H04W84/20\nH04W4/00\nH04W99/99" {
+		t.Errorf("Expected one request for the distinct symbols, got %q", requests)
+	}
+
+	// Cached titles are not requested again
+	requests = nil
+	biblio = newBiblio()
+	if err := client.EnrichCPCTitles(ctx, biblio); err != nil {
+		t.Fatalf("EnrichCPCTitles failed: %v", err)
+	}
+	if len(requests) != 1 || requests[0] != "H04W99/99" {
+		t.Errorf("Expected only the uncached symbol to be requested, got %q", requests)
+	}
+	if biblio.CPCClasses[1].Title != want[1] {
+		t.Errorf("Cached title: got %q, want %q", biblio.CPCClasses[1].Title, want[1])
+	}
+
+	// Nested items are parsed as well
+	titles, err := ParseClassificationTitles(response)
+	if err != nil {
+		t.Fatalf("ParseClassificationTitles failed: %v", err)
+	}
+	if titles["H04W4/02"] != "Services making use of location information" || len(titles) != 3 {
+		t.Errorf("Unexpected titles: %v", titles)
+	}
+
+	if err := client.EnrichCPCTitles(ctx, nil); err == nil {
+		t.Error("Expected error for nil biblio")
+	}
 }
 
 func TestGetClassificationStatisticsRaw(t *testing.T) {
@@ -28,6 +28,7 @@ package epo_ops
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -45,6 +46,18 @@ type Client struct {
 	authenticator *Authenticator
 	generated     *generated.Client
 	quota         *quotaTracker
+	stats         clientStats
+	breaker       *circuitBreaker // nil when disabled
+
+	// classCache holds classification schema responses if Config.CacheClassifications is set
+	classCache ResponseCache
+	// inquiryCache holds image inquiry responses if Config.ValidateImagesBeforeFetch is set
+	inquiryCache ResponseCache
+
+	// transport is the client-owned transport shared by API and token requests.
+	// Close releases its idle connections.
+	transport *http.Transport
+	closed    atomic.Bool
 }
 
 // getAcceptHeader returns the appropriate Accept header value based on the endpoint type.
@@ -70,11 +83,12 @@ func getAcceptHeader(endpoint string) string {
 // This is used to determine the appropriate Accept header.
 func getEndpointFromPath(path string) string {
 	if strings.Contains(path, "/published-data/publication/") {
-		// Parse the constituent (biblio, abstract, claims, description, fulltext)
+		// Parse the constituent (biblio, abstract, claims, description, fulltext);
+		// combined constituents (e.g., "biblio,abstract") count as the first one
 		parts := strings.Split(path, "/")
 		for i, part := range parts {
 			if part == "publication" && i+3 < len(parts) {
-				constituent := parts[i+3]
+				constituent, _, _ := strings.Cut(parts[i+3], ",")
 				switch constituent {
 				case "biblio":
 					return EndpointBiblio
@@ -102,38 +116,107 @@ func getEndpointFromPath(path string) string {
 	if strings.Contains(path, "/published-data/search") {
 		return EndpointSearch
 	}
-	if strings.Contains(path, "/published-data/images") {
+	// Image retrieval and the image inquiry (".../publication/docdb/EP.1000000.B1/images")
+	// share the images service allowance
+	if strings.Contains(path, "/published-data/images") ||
+		(strings.Contains(path, "/published-data/") && strings.HasSuffix(path, "/images")) {
 		return EndpointImages
 	}
 	return ""
 }
 
 // authTransport wraps an http.RoundTripper to add OAuth2 Bearer token to requests.
+//
+// It also enforces Config.Timeout per attempt (instead of http.Client.Timeout) so that
+// a per-call WithRequestTimeout option can replace it, applies WithAcceptOverride, and
+// sets If-None-Match when revalidating a cached response. The prepared request is then
+// passed through Config.Interceptors (see chainInterceptors) to the base transport.
 type authTransport struct {
 	base          http.RoundTripper
 	authenticator *Authenticator
+	timeout       time.Duration
+	interceptors  []RequestInterceptor
+}
+
+// chainInterceptors composes interceptors around next, the first interceptor being the outermost.
+func chainInterceptors(interceptors []RequestInterceptor, next func(*http.Request) (*http.Response, error)) func(*http.Request) (*http.Response, error) {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		if interceptor == nil {
+			continue
+		}
+		next = func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, inner)
+		}
+	}
+	return next
 }
 
 func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	// Get valid token
-	token, err := t.authenticator.GetToken(req.Context())
-	if err != nil {
-		return nil, err
+	ctx := req.Context()
+	opts := requestOptionsFromContext(ctx)
+
+	// Apply the client-wide timeout unless the call has its own timeout
+	cancel := context.CancelFunc(func() {})
+	if t.timeout > 0 && (opts == nil || opts.timeout <= 0) {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	// Get valid token (not needed when only describing the request)
+	authorization := dryRunAuthorization
+	dryRun := dryRunFromContext(ctx)
+	if dryRun == nil {
+		token, err := t.authenticator.GetToken(ctx)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		authorization = "Bearer " + token
 	}
 
 	// Clone request to avoid modifying original
-	req2 := req.Clone(req.Context())
-	req2.Header.Set("Authorization", "Bearer "+token)
+	req2 := req.Clone(ctx)
+	req2.Header.Set("Authorization", authorization)
 
-	// Set Accept header based on endpoint type
-	endpoint := getEndpointFromPath(req.URL.Path)
-	if endpoint != "" {
+	// Set Accept header based on endpoint type (or per-call override)
+	if opts != nil && opts.accept != "" {
+		req2.Header.Set("Accept", opts.accept)
+	} else if endpoint := getEndpointFromPath(req.URL.Path); endpoint != "" {
 		acceptHeader := getAcceptHeader(endpoint)
 		req2.Header.Set("Accept", acceptHeader)
 	}
 
-	// Perform request
-	return t.base.RoundTrip(req2)
+	// Revalidate a cached response (see executeRequest)
+	if etag, ok := ctx.Value(ifNoneMatchKey{}).(string); ok {
+		req2.Header.Set("If-None-Match", etag)
+	}
+
+	// Perform request through the interceptor chain (DescribeRequest captures it instead)
+	send := t.base.RoundTrip
+	if dryRun != nil {
+		send = dryRun.roundTrip
+	}
+	resp, err := chainInterceptors(t.interceptors, send)(req2)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp == nil {
+		cancel()
+		return nil, errors.New("request interceptor returned nil response without error")
+	}
+
+	// Interceptors may short-circuit with a canned response missing these fields
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+	if resp.Request == nil {
+		resp.Request = req2
+	}
+
+	// Keep the attempt context alive until the body has been read and closed
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
 }
 
 // NewClient creates a new EPO OPS API client.
@@ -157,16 +240,53 @@ func NewClient(config *Config) (*Client, error) {
 	if config.MaxRetries == 0 {
 		config.MaxRetries = 3
 	}
+	if config.MaxRetryBudget == 0 {
+		config.MaxRetryBudget = defaultMaxRetryBudget
+	}
 	if config.RetryDelay == 0 {
 		config.RetryDelay = 1 * time.Second
 	}
+	if config.MaxRetryDelay == 0 {
+		config.MaxRetryDelay = 30 * time.Second
+	}
 	if config.Timeout == 0 {
 		config.Timeout = 30 * time.Second
 	}
+	if config.TokenRefreshBuffer == 0 {
+		config.TokenRefreshBuffer = defaultTokenRefreshBuffer
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = defaultCacheTTL
+	}
+	if config.ClassificationCacheTTL == 0 {
+		config.ClassificationCacheTTL = defaultCacheTTL
+	}
+	if config.CircuitBreakerCooldown == 0 {
+		config.CircuitBreakerCooldown = defaultCircuitBreakerCooldown
+	}
+
+	if config.RecordDir != "" && config.ReplayDir != "" {
+		return nil, &ConfigError{Message: "RecordDir and ReplayDir cannot be used together"}
+	}
+
+	// Create a client-owned transport so Close does not affect http.DefaultTransport users
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// API requests are sent, recorded while sent, or answered from recordings
+	// (token requests too, so replaying needs no network access)
+	var apiTransport, tokenTransport http.RoundTripper = transport, transport
+	if config.RecordDir != "" {
+		apiTransport = &RecordingTransport{Dir: config.RecordDir, Base: transport}
+	}
+	if config.ReplayDir != "" {
+		apiTransport = &ReplayTransport{Dir: config.ReplayDir}
+		tokenTransport = apiTransport
+	}
 
 	// Create base HTTP client
 	baseClient := &http.Client{
-		Timeout: config.Timeout,
+		Transport: tokenTransport,
+		Timeout:   config.Timeout,
 	}
 
 	// Create authenticator
@@ -176,13 +296,16 @@ func NewClient(config *Config) (*Client, error) {
 	if config.AuthURL != "" {
 		authenticator.authURL = config.AuthURL
 	}
+	authenticator.SetRefreshBuffer(config.TokenRefreshBuffer)
 
 	// Create HTTP client with auth transport
+	// Config.Timeout is enforced per attempt by the transport so it can be overridden per call
 	httpClient := &http.Client{
-		Timeout: config.Timeout,
 		Transport: &authTransport{
-			base:          http.DefaultTransport,
+			base:          apiTransport,
 			authenticator: authenticator,
+			timeout:       config.Timeout,
+			interceptors:  append([]RequestInterceptor(nil), config.Interceptors...),
 		},
 	}
 
@@ -192,67 +315,321 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{
+	client := &Client{
 		config:        config,
 		httpClient:    httpClient,
 		authenticator: authenticator,
 		generated:     genClient,
 		quota:         &quotaTracker{},
-	}, nil
+		breaker:       newCircuitBreaker(config.CircuitBreakerThreshold, config.CircuitBreakerCooldown),
+		transport:     transport,
+	}
+	if config.CacheClassifications {
+		client.classCache = NewLRUCache(classificationCacheEntries)
+	}
+	if config.ValidateImagesBeforeFetch {
+		client.inquiryCache = NewLRUCache(imageInquiryCacheEntries)
+	}
+	return client, nil
+}
+
+// NewTestClient creates a client for a mock OPS server, e.g. an httptest.Server,
+// with dummy credentials. EPO offers no sandbox, so tests and staging environments
+// point the client at their own servers.
+//
+// authURL is the full token endpoint URL; when empty, the token is requested from
+// baseURL + "/auth/accesstoken", so one server can serve both. Retry delays are
+// shortened to milliseconds to keep tests of error handling fast; use NewClient
+// for other settings.
+//
+// Example:
+//
+//	server := httptest.NewServer(handler) // serves /auth/accesstoken and the API paths
+//	defer server.Close()
+//	client, err := ops.NewTestClient(server.URL, "")
+func NewTestClient(baseURL, authURL string) (*Client, error) {
+	if baseURL == "" {
+		return nil, &ConfigError{Message: "baseURL is required for a test client"}
+	}
+	if authURL == "" {
+		authURL = strings.TrimSuffix(baseURL, "/") + "/auth/accesstoken"
+	}
+	return NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        baseURL,
+		AuthURL:        authURL,
+		RetryDelay:     time.Millisecond,
+		MaxRetryDelay:  10 * time.Millisecond,
+	})
+}
+
+// Close releases the resources held by the client by closing idle keep-alive
+// connections of its transport.
+//
+// A closed client must not be reused: subsequent API calls fail with a ConfigError.
+// Calling Close more than once is safe. Requests still in flight are not interrupted;
+// cancel their contexts to abort them.
+func (c *Client) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+// VerifyCredentials checks the consumer key and secret by acquiring an access token,
+// without calling a data service that spends quota. Use it to fail fast at startup or
+// as a readiness probe.
+//
+// Rejected credentials return an AuthError with Kind KindInvalidCredentials; a throttled
+// or blocked token request returns a QuotaExceededError. Network failures and token
+// endpoint outages are returned as they are. A valid cached token
+// counts as verified, so repeated calls do not hit the token endpoint.
+func (c *Client) VerifyCredentials(ctx context.Context) error {
+	if c.closed.Load() {
+		return &ConfigError{Message: "client is closed"}
+	}
+	_, err := c.authenticator.GetToken(ctx)
+	return err
 }
 
 // executeRequest is a common helper that executes an HTTP request with retry logic and 401 handling.
-// Returns the response body as bytes.
-func (c *Client) executeRequest(ctx context.Context, fn func() (*http.Response, error)) ([]byte, error) {
+// Returns the response body as bytes. fn receives the context to issue the request with.
+//
+// If cacheKey is non-empty and Config.Cache is set, a fresh cached response is returned without
+// issuing the request, and successful responses are stored in the cache. Stale entries with an
+// ETag are revalidated with If-None-Match; on 304 Not Modified the cached body is returned.
+func (c *Client) executeRequest(ctx context.Context, cacheKey string, fn func(context.Context) (*http.Response, error)) ([]byte, error) {
+	return c.executeCachedRequest(ctx, c.config.Cache, c.config.CacheTTL, cacheKey, fn)
+}
+
+// executeCachedRequest implements executeRequest with the given cache and TTL.
+func (c *Client) executeCachedRequest(ctx context.Context, cache ResponseCache, ttl time.Duration, cacheKey string, fn func(context.Context) (*http.Response, error)) ([]byte, error) {
+	if cacheKey == "" || dryRunFromContext(ctx) != nil {
+		cache = nil
+	}
+
+	var cached *cachedResponse
+	if cache != nil {
+		// Responses in a different format must not be served from the same entry
+		if opts := requestOptionsFromContext(ctx); opts != nil && opts.accept != "" {
+			cacheKey += "|accept=" + opts.accept
+		}
+		if data, ok := cache.Get(cacheKey); ok {
+			if entry, ok := decodeCachedResponse(data); ok {
+				if entry.fresh(ttl) {
+					return entry.Body, nil
+				}
+				cached = entry
+			}
+		}
+	}
+
+	requestCtx := ctx
+	if cached != nil && cached.ETag != "" {
+		requestCtx = context.WithValue(ctx, ifNoneMatchKey{}, cached.ETag)
+	}
+
+	resp, err := c.executeStreamRequest(ctx, func() (*http.Response, error) {
+		return fn(requestCtx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if cached == nil || cached.ETag == "" {
+			return nil, fmt.Errorf("unexpected 304 Not Modified response without a cached entry")
+		}
+		cached.StoredAt = time.Now()
+		cache.Set(cacheKey, cached.encode(), 0)
+		return cached.Body, nil
+	}
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		entry := &cachedResponse{ETag: resp.Header.Get("ETag"), StoredAt: time.Now(), Body: body}
+
+		// Entries with an ETag are kept past the TTL so they can be revalidated
+		expiry := ttl
+		if entry.ETag != "" {
+			expiry = 0
+		}
+		cache.Set(cacheKey, entry.encode(), expiry)
+	}
+
+	return body, nil
+}
+
+// defaultMaxResponseBytes is the Config.MaxResponseBytes set by DefaultConfig.
+const defaultMaxResponseBytes = 64 << 20
+
+// readBody reads the response body, failing with a ResponseTooLargeError if it
+// exceeds Config.MaxResponseBytes (0 or less reads it without a limit).
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
+	limit := c.config.MaxResponseBytes
+	if limit <= 0 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return body, nil
+	}
+
+	if resp.ContentLength > limit {
+		return nil, &ResponseTooLargeError{Limit: limit, ContentLength: resp.ContentLength}
+	}
+	// Read one byte past the limit to tell a body of exactly limit bytes from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, &ResponseTooLargeError{Limit: limit, ContentLength: resp.ContentLength}
+	}
+	return body, nil
+}
+
+// executeStreamRequest executes an HTTP request with retry logic and 401 handling and
+// returns the successful response with its body unread. The caller must close the body.
+// A 304 Not Modified response (to a conditional request) is also returned as is;
+// other non-200 responses are read and converted into typed errors.
+//
+// While the circuit breaker is open (see Config.CircuitBreakerThreshold), a CircuitOpenError
+// is returned without sending the request.
+func (c *Client) executeStreamRequest(ctx context.Context, fn func() (*http.Response, error)) (*http.Response, error) {
+	if c.closed.Load() {
+		return nil, &ConfigError{Message: "client is closed"}
+	}
+
+	// Described requests are never sent, so they neither pass through nor trip the breaker
+	breaker := c.breaker
+	if dryRunFromContext(ctx) != nil {
+		breaker = nil
+	}
+	probe, err := breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, status, err := c.sendRequest(ctx, fn)
+	breaker.record(probe, status, err)
+	return resp, err
+}
+
+// sendRequest implements executeStreamRequest without the circuit breaker. status is the
+// HTTP status of the final response, or 0 if none was received.
+func (c *Client) sendRequest(ctx context.Context, fn func() (*http.Response, error)) (resp *http.Response, status int, err error) {
 	var retriedAfter401 atomic.Bool
+	budget := c.newRetryBudget(ctx)
+
+	// send issues one attempt and counts the response for Stats
+	send := func() (*http.Response, error) {
+		budget.spend()
+		resp, err := fn()
+		if err == nil {
+			c.stats.requests.Add(1)
+		}
+		return resp, err
+	}
 
 	// Wrapper that handles 401 token refresh
 	requestWithAuth := func() (*http.Response, error) {
-		resp, err := fn()
+		resp, err := send()
 
 		// Special handling for 401 errors: clear token and retry once
 		// Use atomic swap to ensure only one retry happens even with concurrent requests
 		if err == nil && resp.StatusCode == http.StatusUnauthorized && !retriedAfter401.Swap(true) {
+			if !budget.allow() {
+				return resp, err
+			}
 			_ = resp.Body.Close() // Ignore close error, we're retrying the request
 
 			// Clear cached token to force refresh on next attempt
 			c.authenticator.ClearToken()
 
 			// Retry the request immediately (token will be refreshed by authTransport)
-			resp, err = fn()
+			c.stats.retries.Add(1)
+			resp, err = send()
 		}
 
 		return resp, err
 	}
 
 	// Execute with retry logic
-	resp, err := c.retryableRequest(ctx, requestWithAuth)
+	resp, err = c.retryWithBudget(ctx, budget, requestWithAuth)
 	if err != nil {
-		return nil, err
+		return nil, 0, budget.wrap(err)
 	}
-	defer resp.Body.Close()
 
 	// Parse and store quota information from headers
 	quotaInfo := ParseQuotaHeaders(resp.Header)
-	c.quota.Update(quotaInfo)
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	endpoint := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		endpoint = getEndpointFromPath(resp.Request.URL.Path)
 	}
+	c.quota.Update(endpoint, quotaInfo)
+
+	resp.Body = &countingBody{ReadCloser: resp.Body, stats: &c.stats}
 
 	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotModified {
+		defer resp.Body.Close()
+		body, err := c.readBody(resp)
+		if err != nil {
+			return nil, resp.StatusCode, err
+		}
+		err = c.handleErrorResponse(resp.StatusCode, body)
+
+		// Record the throttling status so callers can tell throttling from a blocked quota
+		var quotaErr *QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			quotaErr.Status = quotaInfo.Status
+		}
+		return nil, resp.StatusCode, budget.wrap(err)
 	}
 
-	return body, nil
+	return resp, resp.StatusCode, nil
 }
 
 // makeRequest executes an HTTP request with retry logic and returns the response body as a string.
 func (c *Client) makeRequest(ctx context.Context, fn func() (*http.Response, error)) (string, error) {
-	body, err := c.executeRequest(ctx, fn)
+	return c.makeCachedRequest(ctx, "", func(context.Context) (*http.Response, error) {
+		return fn()
+	})
+}
+
+// makeCachedRequest is like makeRequest but serves and stores the response in Config.Cache
+// under cacheKey (see cacheKey). Use it only for GET lookups whose response rarely changes.
+// fn must issue the request with the context it receives, which may carry an ETag to revalidate.
+func (c *Client) makeCachedRequest(ctx context.Context, cacheKey string, fn func(context.Context) (*http.Response, error)) (string, error) {
+	body, err := c.executeRequest(ctx, cacheKey, fn)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// classificationCacheEntries is the capacity of the cache enabled by Config.CacheClassifications.
+const classificationCacheEntries = 10000
+
+// makeClassificationRequest is like makeCachedRequest for classification schema lookups:
+// with Config.CacheClassifications set, the response is served from and stored in the
+// classification cache instead of Config.Cache.
+func (c *Client) makeClassificationRequest(ctx context.Context, cacheKey string, fn func(context.Context) (*http.Response, error)) (string, error) {
+	cache, ttl := c.config.Cache, c.config.CacheTTL
+	if c.classCache != nil {
+		cache, ttl = c.classCache, c.config.ClassificationCacheTTL
+	}
+	body, err := c.executeCachedRequest(ctx, cache, ttl, cacheKey, fn)
 	if err != nil {
 		return "", err
 	}
@@ -260,9 +637,11 @@ func (c *Client) makeRequest(ctx context.Context, fn func() (*http.Response, err
 }
 
 // makeBinaryRequest executes an HTTP request with retry logic and returns the response body as bytes.
-// This is used for binary data like images.
+// This is used for binary data like images, which are never cached.
 func (c *Client) makeBinaryRequest(ctx context.Context, fn func() (*http.Response, error)) ([]byte, error) {
-	return c.executeRequest(ctx, fn)
+	return c.executeRequest(ctx, "", func(context.Context) (*http.Response, error) {
+		return fn()
+	})
 }
 
 // handleErrorResponse converts HTTP error responses into appropriate error types.
@@ -270,25 +649,36 @@ func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
 	// Try to parse structured XML error first
 	opsErr, err := parseErrorXML(body, statusCode)
 	if err == nil && opsErr != nil {
+		// Rejected search queries (CLIENT.CQL and its sub-codes)
+		if opsErr.Code == "CLIENT.CQL" || strings.HasPrefix(opsErr.Code, "CLIENT.CQL.") {
+			return newCQLError(opsErr)
+		}
+
 		// Map specific error codes to appropriate error types
 		switch opsErr.Code {
 		case "CLIENT.InvalidReference", "SERVER.EntityNotFound", "HTTP.404":
 			return &NotFoundError{
 				Message: opsErr.Message,
+				Cause:   opsErr,
 			}
 		case "CLIENT.InvalidAccessToken", "CLIENT.MissingAccessToken", "HTTP.401":
 			return &AuthError{
-				StatusCode: statusCode,
-				Message:    opsErr.Message,
+				StatusCode:   statusCode,
+				Message:      opsErr.Message,
+				Cause:        opsErr,
+				Kind:         authErrorKindForCode(opsErr.Code),
+				InvalidToken: true,
 			}
 		case "SERVER.RateLimitExceeded", "SERVER.QuotaPerWeekExceeded", "HTTP.429", "HTTP.403":
 			return &QuotaExceededError{
 				Message: opsErr.Message,
+				Cause:   opsErr,
 			}
 		case "HTTP.503":
 			return &ServiceUnavailableError{
 				StatusCode: statusCode,
 				Message:    opsErr.Message,
+				Cause:      opsErr,
 			}
 		default:
 			// Return the parsed OPSError for other codes
@@ -304,8 +694,10 @@ func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
 		}
 	case http.StatusUnauthorized:
 		return &AuthError{
-			StatusCode: statusCode,
-			Message:    string(body),
+			StatusCode:   statusCode,
+			Message:      string(body),
+			Kind:         KindExpiredToken,
+			InvalidToken: true,
 		}
 	case http.StatusTooManyRequests, http.StatusForbidden:
 		return &QuotaExceededError{
@@ -336,10 +728,36 @@ func formatBulkBody(numbers []string) string {
 //   - Individual: Quota for individual users
 //   - Registered: Quota for registered/paying users
 //   - Images: Separate quota for image downloads
+//
+// Concurrency: the result reflects whichever response arrived last, across all
+// goroutines sharing the client. Another goroutine's request may complete between
+// your call and GetLastQuota; use LastQuotaFor to read the quota of one endpoint
+// category, e.g. images, without interference from other services.
 func (c *Client) GetLastQuota() *QuotaInfo {
 	return c.quota.Get()
 }
 
+// LastQuotaFor returns the quota information of the last response from an endpoint
+// category, or nil if no such request has completed yet.
+//
+// Parameters:
+//   - endpoint: Endpoint category (e.g., EndpointBiblio, EndpointSearch, EndpointImages)
+//
+// Each returned QuotaInfo is an immutable snapshot of one response and safe to use from
+// any goroutine. Concurrent requests to the same category still overwrite each other;
+// the most recent response wins. Requests outside the listed categories (e.g.,
+// classification, number service, bulk POST) are only reflected in GetLastQuota.
+//
+// Example:
+//
+//	images := client.LastQuotaFor(ops.EndpointImages)
+//	if images != nil && images.Images.UsagePercent() > 90 {
+//	    // Pause image downloads
+//	}
+func (c *Client) LastQuotaFor(endpoint string) *QuotaInfo {
+	return c.quota.GetFor(endpoint)
+}
+
 // GetUsageStats retrieves usage statistics from the EPO OPS Data Usage API.
 //
 // The Data Usage API provides historical usage data for quota monitoring and analysis.
@@ -370,6 +788,7 @@ func (c *Client) GetLastQuota() *QuotaInfo {
 //	        entry.Timestamp, entry.TotalResponseSize, entry.MessageCount)
 //	}
 func (c *Client) GetUsageStats(ctx context.Context, timeRange string) (*UsageStats, error) {
+	ctx = withRequestID(ctx)
 	// Validate time range format
 	if err := ValidateTimeRange(timeRange); err != nil {
 		return nil, err
@@ -392,3 +811,30 @@ func (c *Client) GetUsageStats(ctx context.Context, timeRange string) (*UsageSta
 	// Parse JSON response
 	return parseUsageStats(jsonData, timeRange)
 }
+
+// GetUsageStatsRange retrieves usage statistics for the days from through to (inclusive).
+//
+// The dates are formatted in EPO's day-first "dd/mm/yyyy~dd/mm/yyyy" form, so callers
+// don't have to build the time range string for GetUsageStats. Only the calendar dates
+// of from and to are used; a ConfigError is returned if to is before from.
+//
+// Example:
+//
+//	to := time.Now().UTC()
+//	stats, err := client.GetUsageStatsRange(ctx, to.AddDate(0, 0, -6), to)
+func (c *Client) GetUsageStatsRange(ctx context.Context, from, to time.Time) (*UsageStats, error) {
+	timeRange, err := FormatTimeRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetUsageStats(ctx, timeRange)
+}
+
+// GetUsageStatsDay retrieves usage statistics for a single day.
+//
+// Example:
+//
+//	stats, err := client.GetUsageStatsDay(ctx, time.Now().UTC())
+func (c *Client) GetUsageStatsDay(ctx context.Context, day time.Time) (*UsageStats, error) {
+	return c.GetUsageStats(ctx, day.Format(usageStatsDateLayout))
+}
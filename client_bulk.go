@@ -0,0 +1,223 @@
+package epo_ops
+
+import (
+	"context"
+	"fmt"
+)
+
+// Bulk retrieval - batching of arbitrarily long number lists.
+//
+// The *Multiple methods accept at most 100 numbers per request. The methods in this
+// file split longer lists into batches of 100 and run one *Multiple request per batch.
+
+// maxBulkBatchSize is the maximum number of patent numbers per POST request
+const maxBulkBatchSize = 100
+
+// GetBibliosBulk retrieves bibliographic data for any number of patents, in batches of 100.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - numbers: Slice of patent numbers (any length)
+//   - opts: Optional bulk settings (e.g., OnProgress callback); nil uses the defaults
+//
+// Returns the raw XML of each batch, in order. The first failing batch aborts the
+// retrieval; use GetBibliosBulkDetailed to keep the batches that succeeded.
+//
+// Example:
+//
+//	batches, err := client.GetBibliosBulk(ctx, ops.RefTypePublication, ops.FormatDocDB, numbers, nil)
+func (c *Client) GetBibliosBulk(ctx context.Context, refType, format string, numbers []string, opts *BulkOptions) ([]string, error) {
+	ctx = withRequestID(ctx)
+	if err := validateBulkInput(refType, numbers); err != nil {
+		return nil, err
+	}
+
+	results, err := c.runBulk(ctx, numbers, opts, true, func(ctx context.Context, batch []string) (string, error) {
+		return c.GetBiblioMultiple(ctx, refType, format, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkData(results)
+}
+
+// GetBibliosBulkDetailed retrieves bibliographic data for any number of patents, in batches
+// of 100, and continues past failing batches.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - numbers: Slice of patent numbers (any length)
+//   - opts: Optional bulk settings (e.g., OnProgress callback); nil uses the defaults
+//
+// Returns one BulkResult per batch with either its XML or its error, so callers can
+// retry just the failed batches. A top-level error is only returned for invalid input
+// (empty number list, invalid reference type) or when ctx is canceled; in the latter
+// case the results of the batches completed so far are returned as well.
+//
+// Example:
+//
+//	results, err := client.GetBibliosBulkDetailed(ctx, ops.RefTypePublication, ops.FormatDocDB, numbers, nil)
+//	for _, result := range results {
+//	    if result.Err != nil {
+//	        log.Printf("batch %d failed: %v", result.Batch, result.Err)
+//	        continue
+//	    }
+//	    // Process result.Data...
+//	}
+func (c *Client) GetBibliosBulkDetailed(ctx context.Context, refType, format string, numbers []string, opts *BulkOptions) ([]BulkResult, error) {
+	ctx = withRequestID(ctx)
+	if err := validateBulkInput(refType, numbers); err != nil {
+		return nil, err
+	}
+
+	return c.runBulk(ctx, numbers, opts, false, func(ctx context.Context, batch []string) (string, error) {
+		return c.GetBiblioMultiple(ctx, refType, format, batch)
+	})
+}
+
+// GetLegalBulk retrieves legal status data for any number of patents, in batches of 100.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - numbers: Slice of patent numbers (any length)
+//   - opts: Optional bulk settings (e.g., OnProgress callback); nil uses the defaults
+//
+// Returns the raw XML of each batch, in order (parse each with ParseLegal). The first
+// failing batch aborts the retrieval.
+func (c *Client) GetLegalBulk(ctx context.Context, refType, format string, numbers []string, opts *BulkOptions) ([]string, error) {
+	ctx = withRequestID(ctx)
+	if err := validateBulkInput(refType, numbers); err != nil {
+		return nil, err
+	}
+
+	results, err := c.runBulk(ctx, numbers, opts, true, func(ctx context.Context, batch []string) (string, error) {
+		return c.GetLegalMultipleRaw(ctx, refType, format, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkData(results)
+}
+
+// GetFamilyBulk retrieves INPADOC patent families for any number of patents, in batches of 100.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - numbers: Slice of patent numbers (any length)
+//   - opts: Optional bulk settings (e.g., OnProgress callback); nil uses the defaults
+//
+// Returns the raw XML of each batch, in order (split each with ParseFamilyMultiple).
+// The first failing batch aborts the retrieval.
+func (c *Client) GetFamilyBulk(ctx context.Context, refType, format string, numbers []string, opts *BulkOptions) ([]string, error) {
+	ctx = withRequestID(ctx)
+	if err := validateBulkInput(refType, numbers); err != nil {
+		return nil, err
+	}
+
+	results, err := c.runBulk(ctx, numbers, opts, true, func(ctx context.Context, batch []string) (string, error) {
+		return c.GetFamilyMultipleRaw(ctx, refType, format, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkData(results)
+}
+
+// GetFamilyWithBiblioBulk retrieves INPADOC patent families with bibliographic data for
+// any number of patents, in batches of 100.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - numbers: Slice of patent numbers (any length)
+//   - opts: Optional bulk settings (e.g., OnProgress callback); nil uses the defaults
+//
+// Returns the raw XML of each batch, in order (split each with ParseFamilyMultiple).
+// The first failing batch aborts the retrieval.
+func (c *Client) GetFamilyWithBiblioBulk(ctx context.Context, refType, format string, numbers []string, opts *BulkOptions) ([]string, error) {
+	ctx = withRequestID(ctx)
+	if err := validateBulkInput(refType, numbers); err != nil {
+		return nil, err
+	}
+
+	results, err := c.runBulk(ctx, numbers, opts, true, func(ctx context.Context, batch []string) (string, error) {
+		return c.GetFamilyWithBiblioMultipleRaw(ctx, refType, format, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkData(results)
+}
+
+// validateBulkInput checks the parameters shared by all bulk methods.
+// Individual numbers are validated per batch by the *Multiple methods.
+func validateBulkInput(refType string, numbers []string) error {
+	if err := ValidateRefType(refType); err != nil {
+		return err
+	}
+	if len(numbers) == 0 {
+		return &ValidationError{
+			Field:   "numbers",
+			Message: "at least one patent number required",
+		}
+	}
+	return nil
+}
+
+// runBulk splits numbers into batches and fetches them sequentially.
+//
+// With stopOnError, processing stops after the first failed batch (the last result
+// carries its error). A top-level error is only returned when ctx is done; results
+// of the batches completed so far are returned with it.
+func (c *Client) runBulk(ctx context.Context, numbers []string, opts *BulkOptions, stopOnError bool,
+	fetch func(ctx context.Context, batch []string) (string, error)) ([]BulkResult, error) {
+	total := (len(numbers) + maxBulkBatchSize - 1) / maxBulkBatchSize
+	results := make([]BulkResult, 0, total)
+
+	for i := 0; i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		end := (i + 1) * maxBulkBatchSize
+		if end > len(numbers) {
+			end = len(numbers)
+		}
+		result := BulkResult{
+			Batch:   i + 1,
+			Numbers: numbers[i*maxBulkBatchSize : end],
+		}
+		result.Data, result.Err = fetch(ctx, result.Numbers)
+
+		// A batch aborted by cancellation is not a batch failure
+		if result.Err != nil && ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+		results = append(results, result)
+
+		if opts != nil && opts.OnProgress != nil {
+			opts.OnProgress(i+1, total)
+		}
+		if stopOnError && result.Err != nil {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// bulkData returns the XML of all batches, or the error of the first failed batch.
+func bulkData(results []BulkResult) ([]string, error) {
+	data := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("batch %d: %w", result.Batch, result.Err)
+		}
+		data = append(data, result.Data)
+	}
+	return data, nil
+}
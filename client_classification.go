@@ -2,7 +2,10 @@ package epo_ops
 
 import (
 	"context"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/patent-dev/epo-ops/generated"
@@ -25,6 +28,7 @@ import (
 //   - Full symbol: "H04W84/18" retrieves specific classification
 //   - ancestors: If true, include ancestor classifications in the hierarchy
 //   - navigation: If true, include navigation links to related classifications
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing:
 //   - Classification hierarchy structure
@@ -39,7 +43,11 @@ import (
 //
 //	// Get with ancestors and navigation
 //	schema, err := client.GetClassificationSchema(ctx, "H04W84/18", true, true)
-func (c *Client) GetClassificationSchemaRaw(ctx context.Context, class string, ancestors, navigation bool) (string, error) {
+func (c *Client) GetClassificationSchemaRaw(ctx context.Context, class string, ancestors, navigation bool, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if class == "" {
 		return "", &ConfigError{Message: "classification class cannot be empty"}
 	}
@@ -55,12 +63,13 @@ func (c *Client) GetClassificationSchemaRaw(ctx context.Context, class string, a
 		params.Navigation = &navFlag
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeClassificationRequest(ctx, cacheKey("GetClassificationSchemaRaw", class, strconv.FormatBool(ancestors), strconv.FormatBool(navigation)), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.ClassificationSchemaService(ctx, class, params)
 	})
 }
 
-// GetClassificationSchemaSubclass retrieves CPC classification schema for a specific subclass.
+// GetClassificationSchemaSubclass retrieves CPC classification schema for a specific subclass
+// and parses it into a tree of groups and subgroups.
 //
 // This is a more specific version of GetClassificationSchema that retrieves classification
 // hierarchy for a specific class/subclass combination.
@@ -70,14 +79,41 @@ func (c *Client) GetClassificationSchemaRaw(ctx context.Context, class string, a
 //   - subclass: CPC subclass identifier (e.g., "00")
 //   - ancestors: If true, include ancestor classifications in the hierarchy
 //   - navigation: If true, include navigation links to related classifications
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout)
 //
-// Returns XML containing the subclass classification hierarchy.
+// Returns the classification items with their titles, notes and nested subgroups.
 //
 // Example:
 //
 //	// Get specific subclass hierarchy
 //	schema, err := client.GetClassificationSchemaSubclass(ctx, "A01B1", "00", false, false)
-func (c *Client) GetClassificationSchemaSubclassRaw(ctx context.Context, class, subclass string, ancestors, navigation bool) (string, error) {
+//	for _, group := range schema.Items {
+//	    fmt.Println(group.Symbol, group.Title, len(group.Children))
+//	}
+func (c *Client) GetClassificationSchemaSubclass(ctx context.Context, class, subclass string, ancestors, navigation bool, opts ...RequestOption) (*ClassificationSchemaData, error) {
+	xmlData, err := c.GetClassificationSchemaSubclassRaw(ctx, class, subclass, ancestors, navigation, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClassificationSchema(xmlData)
+}
+
+// GetClassificationSchemaSubclassRaw retrieves CPC classification schema for a specific
+// subclass as raw XML. For parsed data, use GetClassificationSchemaSubclass() instead.
+//
+// Parameters:
+//   - class: CPC class identifier (e.g., "A01B1")
+//   - subclass: CPC subclass identifier (e.g., "00")
+//   - ancestors: If true, include ancestor classifications in the hierarchy
+//   - navigation: If true, include navigation links to related classifications
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
+//
+// Returns XML containing the subclass classification hierarchy.
+func (c *Client) GetClassificationSchemaSubclassRaw(ctx context.Context, class, subclass string, ancestors, navigation bool, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if class == "" {
 		return "", &ConfigError{Message: "classification class cannot be empty"}
 	}
@@ -96,7 +132,7 @@ func (c *Client) GetClassificationSchemaSubclassRaw(ctx context.Context, class,
 		params.Navigation = &navFlag
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeClassificationRequest(ctx, cacheKey("GetClassificationSchemaSubclassRaw", class, subclass, strconv.FormatBool(ancestors), strconv.FormatBool(navigation)), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.ClassificationSchemaSubclassService(ctx, class, subclass, params)
 	})
 }
@@ -109,6 +145,7 @@ func (c *Client) GetClassificationSchemaSubclassRaw(ctx context.Context, class,
 // Parameters:
 //   - classes: Slice of CPC classification symbols (max 100)
 //   - Each can be in any supported format: "A01", "A01B", "H04W84/18"
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing classification hierarchies for all requested symbols.
 //
@@ -116,7 +153,11 @@ func (c *Client) GetClassificationSchemaSubclassRaw(ctx context.Context, class,
 //
 //	classes := []string{"A01B", "H04W", "G06F17/30"}
 //	schemas, err := client.GetClassificationSchemaMultiple(ctx, classes)
-func (c *Client) GetClassificationSchemaMultipleRaw(ctx context.Context, classes []string) (string, error) {
+func (c *Client) GetClassificationSchemaMultipleRaw(ctx context.Context, classes []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if len(classes) == 0 {
 		return "", &ConfigError{Message: "classes list cannot be empty"}
 	}
@@ -127,12 +168,98 @@ func (c *Client) GetClassificationSchemaMultipleRaw(ctx context.Context, classes
 	// Build request body (newline-separated class list)
 	body := strings.Join(classes, "\n")
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	// Like other bulk requests, the response is not stored in Config.Cache, only in
+	// the classification cache
+	key := ""
+	if c.classCache != nil {
+		key = cacheKey("GetClassificationSchemaMultipleRaw", classes...)
+	}
+	return c.makeClassificationRequest(ctx, key, func(ctx context.Context) (*http.Response, error) {
 		return c.generated.ClassificationSchemaServicePOSTWithTextBody(ctx,
 			generated.ClassificationSchemaServicePOSTTextRequestBody(body))
 	})
 }
 
+// EnrichCPCTitles looks up the titles of the CPC classifications of biblio and sets
+// CPCClass.Title in place.
+//
+// The distinct symbols are requested with GetClassificationSchemaMultipleRaw in batches
+// of 100. With Config.Cache or Config.CacheClassifications set, titles are cached per
+// symbol, so symbols shared by many documents are only looked up once. Symbols EPO
+// does not know keep an empty Title.
+//
+// Example:
+//
+//	biblio, err := client.GetBiblio(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.1000000.B1")
+//	if err == nil && client.EnrichCPCTitles(ctx, biblio) == nil {
+//	    for _, class := range biblio.CPCClasses {
+//	        fmt.Printf("%s: %s\n", class.Full, class.Title)
+//	    }
+//	}
+func (c *Client) EnrichCPCTitles(ctx context.Context, biblio *BiblioData, opts ...RequestOption) error {
+	ctx = withRequestID(ctx)
+	if biblio == nil {
+		return &ConfigError{Message: "biblio cannot be nil"}
+	}
+
+	cache, ttl := c.config.Cache, c.config.CacheTTL
+	if c.classCache != nil {
+		cache, ttl = c.classCache, c.config.ClassificationCacheTTL
+	}
+	if dryRunFromContext(ctx) != nil {
+		cache = nil
+	}
+
+	titles := make(map[string]string)
+	var missing []string
+	for _, class := range biblio.CPCClasses {
+		symbol := cpcSchemaSymbol(class)
+		if _, ok := titles[symbol]; ok || slices.Contains(missing, symbol) {
+			continue
+		}
+		if cache != nil {
+			if title, ok := cache.Get(cacheKey("CPCTitle", symbol)); ok {
+				titles[symbol] = string(title)
+				continue
+			}
+		}
+		missing = append(missing, symbol)
+	}
+
+	for start := 0; start < len(missing); start += maxBulkBatchSize {
+		batch := missing[start:min(start+maxBulkBatchSize, len(missing))]
+		xmlData, err := c.GetClassificationSchemaMultipleRaw(ctx, batch, opts...)
+		if err != nil {
+			return err
+		}
+		found, err := ParseClassificationTitles(xmlData)
+		if err != nil {
+			return err
+		}
+		for _, symbol := range batch {
+			title, ok := found[symbol]
+			if !ok {
+				continue
+			}
+			titles[symbol] = title
+			if cache != nil {
+				cache.Set(cacheKey("CPCTitle", symbol), []byte(title), ttl)
+			}
+		}
+	}
+
+	for i := range biblio.CPCClasses {
+		biblio.CPCClasses[i].Title = titles[cpcSchemaSymbol(biblio.CPCClasses[i])]
+	}
+	return nil
+}
+
+// cpcSchemaSymbol returns the symbol of class as used by the classification schema
+// service (e.g., "H04W84/20").
+func cpcSchemaSymbol(class CPCClass) string {
+	return strings.ReplaceAll(class.String(), " ", "")
+}
+
 // GetClassificationMedia retrieves media files (images/diagrams) for CPC classifications.
 //
 // The CPC classification system includes illustrative diagrams and images to help
@@ -145,8 +272,10 @@ func (c *Client) GetClassificationSchemaMultipleRaw(ctx context.Context, classes
 //   - asAttachment: If true, sets Content-Disposition to attachment (forces download)
 //   - false (default): inline display
 //   - true: download as attachment
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
-// Returns binary image data that can be saved to a file or displayed.
+// Returns binary image data that can be saved to a file or displayed. To learn the
+// image type, use GetClassificationMediaWithInfo.
 //
 // Example:
 //
@@ -158,7 +287,11 @@ func (c *Client) GetClassificationSchemaMultipleRaw(ctx context.Context, classes
 //
 //	// Save to file
 //	err = os.WriteFile("classification-1000.gif", imageData, 0644)
-func (c *Client) GetClassificationMedia(ctx context.Context, mediaName string, asAttachment bool) ([]byte, error) {
+func (c *Client) GetClassificationMedia(ctx context.Context, mediaName string, asAttachment bool, opts ...RequestOption) ([]byte, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if mediaName == "" {
 		return nil, &ConfigError{Message: "media name cannot be empty"}
 	}
@@ -177,37 +310,120 @@ func (c *Client) GetClassificationMedia(ctx context.Context, mediaName string, a
 	})
 }
 
+// GetClassificationMediaWithInfo retrieves a classification media file like
+// GetClassificationMedia, together with its content type and file extension.
+//
+// The type is taken from the Content-Type header of the response. If EPO sends no
+// specific type (e.g. "application/octet-stream"), it is detected from the GIF, PNG,
+// JPEG or TIFF signature of the data.
+//
+// Example:
+//
+//	media, err := client.GetClassificationMediaWithInfo(ctx, "1000.gif", false)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = os.WriteFile("classification-1000"+media.Extension, media.Data, 0644)
+func (c *Client) GetClassificationMediaWithInfo(ctx context.Context, mediaName string, asAttachment bool, opts ...RequestOption) (*MediaResult, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	if mediaName == "" {
+		return nil, &ConfigError{Message: "media name cannot be empty"}
+	}
+
+	var params *generated.ClassificationMediaServiceParams
+	if asAttachment {
+		attachment := generated.ClassificationMediaServiceParamsAttachmentTrue
+		params = &generated.ClassificationMediaServiceParams{
+			Attachment: &attachment,
+		}
+	}
+
+	raw, err := c.executeRawRequest(ctx, func() (*http.Response, error) {
+		return c.generated.ClassificationMediaService(ctx, mediaName, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, extension := mediaType(raw.ContentType(), raw.Body)
+	return &MediaResult{
+		Data:        raw.Body,
+		ContentType: contentType,
+		Extension:   extension,
+	}, nil
+}
+
+// mediaExtensions maps the media types served by the classification media service
+// to file extensions
+var mediaExtensions = map[string]string{
+	"image/gif":       ".gif",
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/tiff":      ".tiff",
+	"image/svg+xml":   ".svg",
+	"application/pdf": ".pdf",
+}
+
+// mediaType returns the MIME type and file extension of media data, preferring the
+// Content-Type header and falling back to the data signature.
+func mediaType(contentType string, data []byte) (string, string) {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		if ext, ok := mediaExtensions[parsed]; ok {
+			return parsed, ext
+		}
+	}
+	if sniffed := sniffImageType(data); sniffed != "" {
+		return sniffed, mediaExtensions[sniffed]
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return contentType, ".bin"
+}
+
 // GetClassificationStatistics searches for CPC classification statistics.
 //
-// This method retrieves statistical information about patent counts across CPC
-// classification codes. It allows searching for classification codes and returns
-// the number of patents in each classification.
+// This method retrieves statistical information about how documents matching a query
+// are distributed across CPC classification codes.
 //
 // Parameters:
 //   - query: Search query for classification codes
 //   - Can be a keyword (e.g., "plastic", "wireless")
 //   - Can be a classification code (e.g., "H04W", "A01B")
 //   - Can use wildcard patterns
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
-// Returns XML or JSON containing:
-//   - Classification codes matching the query
-//   - Patent counts for each classification
-//   - Classification titles and descriptions
-//
-// The response format depends on the Accept header sent by the client.
-// By default, XML is returned.
+// Returns the matching classifications with title and share of documents, sorted by
+// descending share. A query without matches returns an empty slice.
 //
 // Example:
 //
-//	// Search for statistics on "wireless" classifications
-//	stats, err := client.GetClassificationStatistics(ctx, "wireless")
+//	stats, err := client.GetClassificationStatistics(ctx, "H04W")
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//
-//	// Search for specific classification
-//	stats, err := client.GetClassificationStatistics(ctx, "H04W")
-func (c *Client) GetClassificationStatisticsRaw(ctx context.Context, query string) (string, error) {
+//	for _, stat := range stats {
+//	    fmt.Printf("%-12s %5.2f%% %s\n", stat.Symbol, stat.Percentage, stat.Title)
+//	}
+func (c *Client) GetClassificationStatistics(ctx context.Context, query string, opts ...RequestOption) ([]ClassificationStat, error) {
+	data, err := c.GetClassificationStatisticsRaw(ctx, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClassificationStatistics(data)
+}
+
+// GetClassificationStatisticsRaw searches for CPC classification statistics as raw XML
+// (or JSON with WithAcceptOverride("application/json")).
+// For parsed data, use GetClassificationStatistics() instead.
+func (c *Client) GetClassificationStatisticsRaw(ctx context.Context, query string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if query == "" {
 		return "", &ConfigError{Message: "search query cannot be empty"}
 	}
@@ -225,31 +441,44 @@ func (c *Client) GetClassificationStatisticsRaw(ctx context.Context, query strin
 //
 // This method maps classification codes between the Cooperative Patent Classification (CPC)
 // and European Classification (ECLA) systems. This is useful when working with patents that
-// use different classification systems.
+// use different classification systems, e.g. migrating ECLA-tagged portfolios to CPC.
 //
 // Parameters:
 //   - inputFormat: Format of the input classification ("cpc" or "ecla")
 //   - class: Classification class code (e.g., "A01D2085")
 //   - subclass: Classification subclass code (e.g., "8")
 //   - outputFormat: Desired output format ("cpc" or "ecla")
-//   - additional: If true, include additional/invention information
+//   - additional: If true, include additional (non-invention) classifications
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout)
 //
-// Returns XML containing:
-//   - Mapped classification codes
-//   - Mapping relationships
-//   - Classification descriptions
+// Returns the mapped symbols; MappedClass.Additional distinguishes additional from
+// invention classifications.
 //
 // Example:
 //
 //	// Convert ECLA to CPC
-//	mapping, err := client.GetClassificationMapping(ctx, "ecla", "A01D2085", "8", "cpc", false)
+//	mapping, err := client.GetClassificationMapping(ctx, "ecla", "A01D2085", "8", "cpc", true)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//
-//	// Convert CPC to ECLA with additional information
-//	mapping, err := client.GetClassificationMapping(ctx, "cpc", "H04W84", "18", "ecla", true)
-func (c *Client) GetClassificationMappingRaw(ctx context.Context, inputFormat, class, subclass, outputFormat string, additional bool) (string, error) {
+//	for _, mapped := range mapping.Mappings {
+//	    fmt.Println(mapped.Symbol, mapped.Additional)
+//	}
+func (c *Client) GetClassificationMapping(ctx context.Context, inputFormat, class, subclass, outputFormat string, additional bool, opts ...RequestOption) (*ClassificationMappingResult, error) {
+	xmlData, err := c.GetClassificationMappingRaw(ctx, inputFormat, class, subclass, outputFormat, additional, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClassificationMapping(xmlData)
+}
+
+// GetClassificationMappingRaw converts between CPC and ECLA classification formats and returns raw XML.
+// For parsed data, use GetClassificationMapping() instead.
+func (c *Client) GetClassificationMappingRaw(ctx context.Context, inputFormat, class, subclass, outputFormat string, additional bool, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if inputFormat == "" {
 		return "", &ConfigError{Message: "input format cannot be empty"}
 	}
@@ -290,7 +519,7 @@ func (c *Client) GetClassificationMappingRaw(ctx context.Context, inputFormat, c
 		Additional: additional,
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetClassificationMappingRaw", inputFormat, class, subclass, outputFormat, strconv.FormatBool(additional)), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.ClassificationMappingService(ctx, inputFmt, class, subclass, outputFmt, params)
 	})
 }
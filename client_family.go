@@ -22,7 +22,8 @@ import (
 // Returns parsed family data containing all family members with their bibliographic details.
 //
 // INPADOC (International Patent Documentation) family includes all patents
-// related through priority claims.
+// related through priority claims (the extended family). For the simple family,
+// use GetFamilyByType with FamilySimple.
 func (c *Client) GetFamily(ctx context.Context, refType, format, number string) (*FamilyData, error) {
 	xmlData, err := c.GetFamilyRaw(ctx, refType, format, number)
 	if err != nil {
@@ -31,16 +32,178 @@ func (c *Client) GetFamily(ctx context.Context, refType, format, number string)
 	return ParseFamily(xmlData)
 }
 
+// GetFamilyWithRepresentative retrieves the INPADOC patent family like GetFamily together
+// with the bibliographic data of its representative member, in two requests.
+//
+// The representative is the member with the earliest priority date, chosen by
+// FamilyData.Representative: the earliest of a member's priority claim dates, or its
+// application date if it claims no priority; ties go to the member published first.
+// Its biblio is retrieved by publication number (docdb if the member has a kind code).
+//
+// If the biblio request fails, the family is still returned along with the error.
+//
+// Example:
+//
+//	family, biblio, err := client.GetFamilyWithRepresentative(ctx, ops.RefTypePublication,
+//	    ops.FormatDocDB, "EP.2400812.B1")
+//	fmt.Println(len(family.Members), biblio.Titles["en"])
+func (c *Client) GetFamilyWithRepresentative(ctx context.Context, refType, format, number string) (*FamilyData, *BiblioData, error) {
+	ctx = withRequestID(ctx)
+	family, err := c.GetFamily(ctx, refType, format, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	member, ok := family.Representative()
+	if !ok {
+		return family, nil, &DataValidationError{
+			Parser:       "GetFamilyWithRepresentative",
+			MissingField: "Members",
+			Message:      "family has no members",
+		}
+	}
+	biblio, err := c.GetBiblioFor(ctx, RefTypePublication, PatentNumber{
+		Country: member.Country,
+		Number:  member.DocNumber,
+		Kind:    member.Kind,
+	})
+	if err != nil {
+		return family, nil, err
+	}
+	return family, biblio, nil
+}
+
+// GetFamilyForCountries retrieves the INPADOC patent family like GetFamily, keeping only
+// the members published in one of countries (case-insensitive, e.g. "US", "EP", "CN").
+//
+// The full family is retrieved and filtered on the client, so the request costs the same
+// quota as GetFamily. TotalCount still reports the size of the whole family.
+//
+// Example:
+//
+//	family, err := client.GetFamilyForCountries(ctx, ops.RefTypePublication, ops.FormatDocDB,
+//	    "EP.1000000.B1", "US", "EP", "CN")
+func (c *Client) GetFamilyForCountries(ctx context.Context, refType, format, number string, countries ...string) (*FamilyData, error) {
+	if len(countries) == 0 {
+		return nil, &ValidationError{
+			Field:   "countries",
+			Message: "at least one country required",
+		}
+	}
+
+	family, err := c.GetFamily(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	family.Members = family.MembersForCountries(countries...)
+	return family, nil
+}
+
+// GetFamilyByType retrieves the simple or the extended patent family of a patent.
+//
+// Parameters:
+//   - refType: Reference type (e.g., "publication", "application", "priority")
+//   - format: Number format (e.g., "docdb", "epodoc")
+//   - number: Patent number (e.g., "EP1000000")
+//   - familyType: FamilySimple or FamilyExtended
+//
+// FamilySimple uses the published-data equivalents service: publications that claim
+// exactly the same priorities. Members carry country, number and kind as returned by
+// that service (epodoc numbers); there is no family ID or application data.
+//
+// FamilyExtended is the INPADOC family of GetFamily: every publication linked directly
+// or indirectly by a shared priority. It is always a superset of the simple family.
+//
+// Both are returned as FamilyData with Type set accordingly.
+//
+// Example:
+//
+//	family, err := client.GetFamilyByType(ctx, ops.RefTypePublication, ops.FormatEPODOC, "EP1000000", ops.FamilySimple)
+func (c *Client) GetFamilyByType(ctx context.Context, refType, format, number string, familyType FamilyType) (*FamilyData, error) {
+	switch familyType {
+	case FamilyExtended:
+		return c.GetFamily(ctx, refType, format, number)
+	case FamilySimple:
+		equivalents, err := c.GetPublishedEquivalents(ctx, refType, format, number)
+		if err != nil {
+			return nil, err
+		}
+		return familyFromEquivalents(equivalents), nil
+	default:
+		return nil, &ValidationError{
+			Field:   "familyType",
+			Value:   string(familyType),
+			Message: "must be 'simple' or 'extended'",
+		}
+	}
+}
+
+// familyFromEquivalents converts an equivalents inquiry result into a simple family.
+func familyFromEquivalents(equivalents *EquivalentsData) *FamilyData {
+	data := &FamilyData{
+		PatentNumber: equivalents.PatentNumber,
+		TotalCount:   len(equivalents.Equivalents),
+		Type:         FamilySimple,
+	}
+	for _, equivalent := range equivalents.Equivalents {
+		data.Members = append(data.Members, FamilyMember{
+			Country:   equivalent.Country,
+			DocNumber: equivalent.DocNumber,
+			Kind:      equivalent.Kind,
+			Publications: []PublicationReference{{
+				Country:   equivalent.Country,
+				DocNumber: equivalent.DocNumber,
+				Kind:      equivalent.Kind,
+				Type:      FormatEPODOC,
+			}},
+		})
+	}
+	return data
+}
+
+// GetFamilyByPublication retrieves the INPADOC patent family for a publication number.
+// It is equivalent to GetFamily with RefTypePublication.
+//
+// Example:
+//
+//	family, err := client.GetFamilyByPublication(ctx, ops.FormatDocDB, "EP.1000000.B1")
+func (c *Client) GetFamilyByPublication(ctx context.Context, format, number string) (*FamilyData, error) {
+	return c.GetFamily(ctx, RefTypePublication, format, number)
+}
+
+// GetFamilyByApplication retrieves the INPADOC patent family for an application number.
+// It is equivalent to GetFamily with RefTypeApplication.
+//
+// Example:
+//
+//	family, err := client.GetFamilyByApplication(ctx, ops.FormatEPODOC, "EP99203729")
+func (c *Client) GetFamilyByApplication(ctx context.Context, format, number string) (*FamilyData, error) {
+	return c.GetFamily(ctx, RefTypeApplication, format, number)
+}
+
+// GetFamilyByPriority retrieves the INPADOC patent family for a priority number.
+// It is equivalent to GetFamily with RefTypePriority.
+//
+// Example:
+//
+//	family, err := client.GetFamilyByPriority(ctx, ops.FormatEPODOC, "US19990465270")
+func (c *Client) GetFamilyByPriority(ctx context.Context, format, number string) (*FamilyData, error) {
+	return c.GetFamily(ctx, RefTypePriority, format, number)
+}
+
 // GetFamilyRaw retrieves the INPADOC patent family as raw XML.
 // For parsed data, use GetFamily() instead.
-func (c *Client) GetFamilyRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetFamilyRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
 	if err := ValidateFormat(format, number); err != nil {
 		return "", err
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetFamilyRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.INPADOCFamilyRetrievalService(ctx,
 			generated.INPADOCFamilyRetrievalServiceParamsType(refType),
 			generated.INPADOCFamilyRetrievalServiceParamsFormat(format),
@@ -55,51 +218,167 @@ func (c *Client) GetFamilyRaw(ctx context.Context, refType, format, number strin
 //   - format: Number format (e.g., "docdb", "epodoc")
 //   - number: Patent number (e.g., "EP1000000")
 //
-// Returns parsed family data with bibliographic details for all family members.
+// Returns parsed family data for all family members. The bibliographic data of each
+// member is not included; use GetFamilyWithBiblioParsed() for titles, applicants and
+// classifications per member.
 func (c *Client) GetFamilyWithBiblio(ctx context.Context, refType, format, number string) (*FamilyData, error) {
-	if err := ValidateRefType(refType); err != nil {
+	xmlData, err := c.GetFamilyWithBiblioRaw(ctx, refType, format, number)
+	if err != nil {
 		return nil, err
 	}
-	if err := ValidateFormat(format, number); err != nil {
+	return ParseFamily(xmlData)
+}
+
+// GetFamilyWithBiblioParsed retrieves the INPADOC patent family with parsed bibliographic data per member.
+//
+// Parameters:
+//   - refType: Reference type (e.g., "publication", "application", "priority")
+//   - format: Number format (e.g., "docdb", "epodoc")
+//   - number: Patent number (e.g., "EP1000000")
+//
+// Returns family data where each member carries its BiblioData (titles, applicants,
+// inventors, IPC and CPC classifications), replacing one GetBiblio call per member.
+//
+// Example:
+//
+//	family, err := client.GetFamilyWithBiblioParsed(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP2400812")
+//	for _, member := range family.Members {
+//	    if member.Biblio != nil {
+//	        fmt.Println(member.Country, member.DocNumber, member.Biblio.Titles["en"])
+//	    }
+//	}
+func (c *Client) GetFamilyWithBiblioParsed(ctx context.Context, refType, format, number string) (*FamilyBiblioData, error) {
+	xmlData, err := c.GetFamilyWithBiblioRaw(ctx, refType, format, number)
+	if err != nil {
 		return nil, err
 	}
-	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
+	return ParseFamilyBiblio(xmlData)
+}
+
+// GetFamilyWithBiblioRaw retrieves the INPADOC patent family with bibliographic data as raw XML.
+// For parsed data, use GetFamilyWithBiblioParsed() instead.
+func (c *Client) GetFamilyWithBiblioRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return "", err
+	}
+	return c.makeCachedRequest(ctx, cacheKey("GetFamilyWithBiblioRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.INPADOCFamilyRetrievalServiceWithBiblio(ctx,
 			generated.INPADOCFamilyRetrievalServiceWithBiblioParamsType(refType),
 			generated.INPADOCFamilyRetrievalServiceWithBiblioParamsFormat(format),
 			number)
 	})
+}
+
+// GetFamilyWithLegal retrieves the INPADOC patent family with legal status data.
+//
+// Parameters:
+//   - refType: Reference type (e.g., "publication", "application", "priority")
+//   - format: Number format (e.g., "docdb", "epodoc")
+//   - number: Patent number (e.g., "EP1000000")
+//
+// Returns parsed family data for all family members. Legal events are not
+// included; use GetFamilyWithLegalParsed() to get the events per member.
+func (c *Client) GetFamilyWithLegal(ctx context.Context, refType, format, number string) (*FamilyData, error) {
+	xmlData, err := c.GetFamilyWithLegalRaw(ctx, refType, format, number)
 	if err != nil {
 		return nil, err
 	}
 	return ParseFamily(xmlData)
 }
 
-// GetFamilyWithLegal retrieves the INPADOC patent family with legal status data.
+// GetFamilyWithLegalParsed retrieves the INPADOC patent family with parsed legal events per member.
 //
 // Parameters:
 //   - refType: Reference type (e.g., "publication", "application", "priority")
 //   - format: Number format (e.g., "docdb", "epodoc")
 //   - number: Patent number (e.g., "EP1000000")
 //
-// Returns parsed family data with legal status events for all family members.
-func (c *Client) GetFamilyWithLegal(ctx context.Context, refType, format, number string) (*FamilyData, error) {
-	if err := ValidateRefType(refType); err != nil {
+// Returns family data where each member carries its own legal events, replacing
+// one GetLegal call per member.
+//
+// Example:
+//
+//	family, err := client.GetFamilyWithLegalParsed(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.2400812.A1")
+//	for _, member := range family.Members {
+//	    fmt.Println(member.Country, member.DocNumber, len(member.LegalEvents))
+//	}
+func (c *Client) GetFamilyWithLegalParsed(ctx context.Context, refType, format, number string) (*FamilyLegalData, error) {
+	xmlData, err := c.GetFamilyWithLegalRaw(ctx, refType, format, number)
+	if err != nil {
 		return nil, err
 	}
+	return ParseFamilyLegal(xmlData)
+}
+
+// GetFamilyWithLegalRaw retrieves the INPADOC patent family with legal status data as raw XML.
+// For parsed data, use GetFamilyWithLegalParsed() instead.
+func (c *Client) GetFamilyWithLegalRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
 	if err := ValidateFormat(format, number); err != nil {
-		return nil, err
+		return "", err
 	}
-	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.INPADOCFamilyRetrievalServiceWithLegal(ctx,
 			generated.INPADOCFamilyRetrievalServiceWithLegalParamsType(refType),
 			generated.INPADOCFamilyRetrievalServiceWithLegalParamsFormat(format),
 			number)
 	})
+}
+
+// GetFamilyMultiple retrieves INPADOC patent families for multiple patents.
+//
+// This method uses the POST endpoint to retrieve family data for multiple patent
+// numbers in a single request.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
+//
+// Returns one FamilyData per patent-family element in the response, in order.
+func (c *Client) GetFamilyMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) ([]*FamilyData, error) {
+	xmlData, err := c.GetFamilyMultipleRaw(ctx, refType, format, numbers, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseFamily(xmlData)
+	return ParseFamilyMultiple(xmlData)
+}
+
+// GetFamilyMultipleRaw retrieves INPADOC families for multiple patents as raw XML.
+// The response contains one patent-family element per patent; use ParseFamilyMultiple() to split it.
+func (c *Client) GetFamilyMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
+
+	if err := ValidateBulkNumbers(numbers, format); err != nil {
+		return "", err
+	}
+
+	// Use generated POST method
+	body := formatBulkBody(numbers)
+	return c.makeRequest(ctx, func() (*http.Response, error) {
+		return c.generated.INPADOCFamilyRetrievalServicePOSTWithTextBody(ctx,
+			generated.INPADOCFamilyRetrievalServicePOSTParamsType(refType),
+			generated.INPADOCFamilyRetrievalServicePOSTParamsFormat(format),
+			body)
+	})
 }
 
 // GetFamilyWithBiblioMultiple retrieves INPADOC patent family with bibliographic data for multiple patents.
@@ -111,29 +390,42 @@ func (c *Client) GetFamilyWithLegal(ctx context.Context, refType, format, number
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns parsed family data with bibliographic details for all requested patents.
-func (c *Client) GetFamilyWithBiblioMultiple(ctx context.Context, refType, format string, numbers []string) (*FamilyData, error) {
-	if err := ValidateRefType(refType); err != nil {
+// Members of all families are merged into a single FamilyData; to keep the families
+// separate, use GetFamilyWithBiblioMultipleRaw() with ParseFamilyMultiple().
+func (c *Client) GetFamilyWithBiblioMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (*FamilyData, error) {
+	xmlData, err := c.GetFamilyWithBiblioMultipleRaw(ctx, refType, format, numbers, opts...)
+	if err != nil {
 		return nil, err
 	}
+	return ParseFamily(xmlData)
+}
+
+// GetFamilyWithBiblioMultipleRaw retrieves INPADOC families with bibliographic data for multiple patents as raw XML.
+// The response contains one patent-family element per patent; use ParseFamilyMultiple() to split it.
+func (c *Client) GetFamilyWithBiblioMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
 
 	if err := ValidateBulkNumbers(numbers, format); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Use generated POST method
 	body := formatBulkBody(numbers)
-	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.INPADOCFamilyRetrievalServiceWithBiblioPOSTWithTextBody(ctx,
 			generated.INPADOCFamilyRetrievalServiceWithBiblioPOSTParamsType(refType),
 			generated.INPADOCFamilyRetrievalServiceWithBiblioPOSTParamsFormat(format),
 			body)
 	})
-	if err != nil {
-		return nil, err
-	}
-	return ParseFamily(xmlData)
 }
 
 // GetFamilyWithLegalMultiple retrieves INPADOC patent family with legal status data for multiple patents.
@@ -145,27 +437,40 @@ func (c *Client) GetFamilyWithBiblioMultiple(ctx context.Context, refType, forma
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns parsed family data with legal status events for all requested patents.
-func (c *Client) GetFamilyWithLegalMultiple(ctx context.Context, refType, format string, numbers []string) (*FamilyData, error) {
-	if err := ValidateRefType(refType); err != nil {
+// Members of all families are merged into a single FamilyData; to keep the families
+// separate, use GetFamilyWithLegalMultipleRaw() with ParseFamilyMultiple().
+func (c *Client) GetFamilyWithLegalMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (*FamilyData, error) {
+	xmlData, err := c.GetFamilyWithLegalMultipleRaw(ctx, refType, format, numbers, opts...)
+	if err != nil {
 		return nil, err
 	}
+	return ParseFamily(xmlData)
+}
+
+// GetFamilyWithLegalMultipleRaw retrieves INPADOC families with legal status data for multiple patents as raw XML.
+// The response contains one patent-family element per patent; use ParseFamilyMultiple() to split it.
+func (c *Client) GetFamilyWithLegalMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
 
 	if err := ValidateBulkNumbers(numbers, format); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Use generated POST method
 	body := formatBulkBody(numbers)
-	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.INPADOCFamilyRetrievalServiceWithLegalPOSTWithTextBody(ctx,
 			generated.INPADOCFamilyRetrievalServiceWithLegalPOSTParamsType(refType),
 			generated.INPADOCFamilyRetrievalServiceWithLegalPOSTParamsFormat(format),
 			body)
 	})
-	if err != nil {
-		return nil, err
-	}
-	return ParseFamily(xmlData)
 }
@@ -2,7 +2,15 @@ package epo_ops
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/patent-dev/epo-ops/generated"
 )
@@ -28,7 +36,17 @@ import (
 //
 // Note: EPO typically returns images in TIFF format. Use tiffutil.TIFFToPNG()
 // to convert to PNG format.
+//
+// With Config.ValidateImagesBeforeFetch set, the image inquiry of the document is run
+// (and cached) first, and a ValidationError is returned without fetching the image if
+// the document has no instance of the image type or fewer pages than requested.
 func (c *Client) GetImage(ctx context.Context, country, number, kind, imageType string, page int) ([]byte, error) {
+	ctx = withRequestID(ctx)
+	if c.config.ValidateImagesBeforeFetch {
+		if err := c.validateImageRequest(ctx, country, number, kind, imageType, page); err != nil {
+			return nil, err
+		}
+	}
 	params := &generated.PublishedImagesRetrievalServiceParams{
 		Range: page,
 	}
@@ -38,6 +56,379 @@ func (c *Client) GetImage(ctx context.Context, country, number, kind, imageType
 	})
 }
 
+// imageTypeDocTypes maps the GetImage image types to the document instance type
+// they retrieve in the image inquiry.
+var imageTypeDocTypes = map[string]string{
+	ImageTypeThumbnail: "Drawing",
+	ImageTypeFullImage: "FullDocument",
+	ImageTypeFirstPage: "FirstPageClipping",
+}
+
+// validateImageRequest checks a GetImage request against the image inquiry of the
+// document (see Config.ValidateImagesBeforeFetch).
+func (c *Client) validateImageRequest(ctx context.Context, country, number, kind, imageType string, page int) error {
+	if page < 1 {
+		return &ValidationError{
+			Field:   "page",
+			Value:   strconv.Itoa(page),
+			Message: "page must be >= 1",
+		}
+	}
+	docType, ok := imageTypeDocTypes[strings.ToLower(imageType)]
+	if !ok {
+		return &ValidationError{
+			Field:   "imageType",
+			Value:   imageType,
+			Message: fmt.Sprintf("must be %s, %s or %s", ImageTypeThumbnail, ImageTypeFullImage, ImageTypeFirstPage),
+		}
+	}
+
+	// First-page clippings are requested under kind "PA", which is not a publication
+	// kind, so their inquiry is made for the number alone
+	if strings.EqualFold(kind, "PA") {
+		kind = ""
+	}
+	format, ref, err := patentNumberRef(PatentNumber{Country: country, Number: number, Kind: kind})
+	if err != nil {
+		return err
+	}
+	inquiry, err := c.GetImageInquiry(ctx, RefTypePublication, format, ref)
+	if err != nil {
+		return err
+	}
+	instance, err := findDocumentInstance(inquiry, ref, docType)
+	if err != nil {
+		return err
+	}
+	if page > instance.NumberOfPages {
+		return &ValidationError{
+			Field:   "page",
+			Value:   strconv.Itoa(page),
+			Message: fmt.Sprintf("out of range: %s of %s has %d pages", instance.DocType, ref, instance.NumberOfPages),
+		}
+	}
+	return nil
+}
+
+// findDocumentInstance returns the instance of docType (compared case-insensitively)
+// from an image inquiry of ref, or a ValidationError listing the available types.
+func findDocumentInstance(inquiry *ImageInquiry, ref, docType string) (DocumentInstance, error) {
+	var docTypes []string
+	for _, instance := range inquiry.DocumentInstances {
+		if strings.EqualFold(instance.DocType, docType) {
+			return instance, nil
+		}
+		docTypes = append(docTypes, instance.DocType)
+	}
+	return DocumentInstance{}, &ValidationError{
+		Field:   "docType",
+		Value:   docType,
+		Message: fmt.Sprintf("not available for %s (available: %s)", ref, strings.Join(docTypes, ", ")),
+	}
+}
+
+// GetImageThumbnail retrieves the drawings thumbnail of a patent.
+//
+// Thumbnails are small and well suited for search-result galleries without
+// downloading full-size pages.
+//
+// Parameters:
+//   - country: Two-letter country code (e.g., "EP", "US", "WO")
+//   - number: Patent number without country code (e.g., "2400812")
+//   - kind: Kind code (e.g., "A1", "B1")
+//
+// Returns the thumbnail image data (typically TIFF or PNG). A DataValidationError is
+// returned if the response does not carry an image signature.
+//
+// Example:
+//
+//	thumb, err := client.GetImageThumbnail(ctx, "EP", "2400812", "A1")
+func (c *Client) GetImageThumbnail(ctx context.Context, country, number, kind string) ([]byte, error) {
+	data, err := c.GetImage(ctx, country, number, kind, ImageTypeThumbnail, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !isImageData(data) {
+		return nil, &DataValidationError{
+			Parser:  "GetImageThumbnail",
+			Message: fmt.Sprintf("response is not image data (%d bytes)", len(data)),
+		}
+	}
+	return data, nil
+}
+
+// GetFirstPageImage retrieves the first-page clipping of a patent.
+//
+// EPO serves first-page clippings only under the special kind code "PA", regardless of
+// the document's actual kind (A1, B1, ...). This method always requests kind "PA", so
+// callers only pass the country and number.
+//
+// Parameters:
+//   - country: Two-letter country code (e.g., "EP", "US", "WO")
+//   - number: Patent number without country code (e.g., "2400812")
+//
+// Returns the first-page image data. Not all documents have a first-page clipping;
+// in that case a NotFoundError naming the document is returned.
+//
+// Example:
+//
+//	firstPage, err := client.GetFirstPageImage(ctx, "EP", "2400812")
+//	var notFound *ops.NotFoundError
+//	if errors.As(err, &notFound) {
+//	    // No clipping available for this document
+//	}
+func (c *Client) GetFirstPageImage(ctx context.Context, country, number string) ([]byte, error) {
+	data, err := c.GetImage(ctx, country, number, "PA", ImageTypeFirstPage, 1)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, &NotFoundError{
+				Resource: fmt.Sprintf("first page clipping %s%s", country, number),
+				Message:  "document has no first-page clipping: " + notFound.Message,
+				Cause:    notFound.Cause,
+			}
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetImageByLink retrieves one page of an image using a link from the image inquiry.
+//
+// EPO returns canonical image paths in DocumentInstance.Link (e.g.,
+// "/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage").
+// Fetching by link avoids reconstructing the path from number components.
+//
+// Parameters:
+//   - link: DocumentInstance.Link from GetImageInquiry (relative or absolute)
+//   - page: Page number (1-based, e.g., 1)
+//   - format: MIME type to request (e.g., "image/tiff", "application/pdf"); empty uses the default
+//
+// The format is not checked against the instance; request one listed in
+// DocumentInstance.Formats (see BestFormat) or use DownloadAllPages, which checks it.
+//
+// Only links to the published-data images service of Config.BaseURL are followed;
+// any other link (other host, other service, path traversal) is rejected with a
+// ValidationError before a request is made.
+//
+// Example:
+//
+//	inquiry, _ := client.GetImageInquiry(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.1000000.B1")
+//	for _, instance := range inquiry.DocumentInstances {
+//	    for page := 1; page <= instance.NumberOfPages; page++ {
+//	        data, err := client.GetImageByLink(ctx, instance.Link, page, "application/pdf")
+//	        // Process page...
+//	    }
+//	}
+func (c *Client) GetImageByLink(ctx context.Context, link string, page int, format string) ([]byte, error) {
+	ctx = withRequestID(ctx)
+	if page < 1 {
+		return nil, &ValidationError{
+			Field:   "page",
+			Value:   strconv.Itoa(page),
+			Message: "page must be >= 1",
+		}
+	}
+
+	imageURL, err := c.resolveImageLink(link)
+	if err != nil {
+		return nil, err
+	}
+	query := imageURL.Query()
+	query.Set("Range", strconv.Itoa(page))
+	imageURL.RawQuery = query.Encode()
+
+	if format != "" {
+		var cancel context.CancelFunc
+		ctx, cancel = applyRequestOptions(ctx, []RequestOption{WithAcceptOverride(format)})
+		defer cancel()
+	}
+
+	return c.makeBinaryRequest(ctx, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.httpClient.Do(req)
+	})
+}
+
+// DownloadAllPages retrieves every page of a document instance from the image inquiry.
+//
+// Parameters:
+//   - instance: DocumentInstance from GetImageInquiry
+//   - format: Format to request (e.g., "application/pdf", "pdf", "image/tiff"); empty uses the default
+//
+// The format is checked against instance.Formats before any request: a format the
+// instance does not offer is rejected with a ValidationError listing the available
+// formats (instead of a 406 from EPO). Short names are mapped to the offered MIME type,
+// so "pdf" requests "application/pdf". Use instance.BestFormat to pick among several
+// acceptable formats. Instances without format information are requested as is.
+//
+// Pages are returned in order; the first failing page aborts the download.
+//
+// Example:
+//
+//	inquiry, _ := client.GetImageInquiry(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.1000000.B1")
+//	for _, instance := range inquiry.DocumentInstances {
+//	    format, ok := instance.BestFormat("pdf", "tiff")
+//	    if !ok {
+//	        continue
+//	    }
+//	    pages, err := client.DownloadAllPages(ctx, instance, format)
+//	    // Process pages...
+//	}
+func (c *Client) DownloadAllPages(ctx context.Context, instance DocumentInstance, format string) ([][]byte, error) {
+	ctx = withRequestID(ctx)
+	if instance.NumberOfPages < 1 {
+		return nil, &ValidationError{
+			Field:   "instance",
+			Value:   instance.Description,
+			Message: "document instance has no pages",
+		}
+	}
+
+	if format != "" && len(instance.Formats) > 0 {
+		offered, ok := instance.BestFormat(format)
+		if !ok {
+			return nil, &ValidationError{
+				Field: "format",
+				Value: format,
+				Message: fmt.Sprintf("not offered for %s (available: %s)",
+					instance.Description, strings.Join(instance.Formats, ", ")),
+			}
+		}
+		format = offered
+	}
+
+	return c.downloadPages(ctx, instance, format, 1, instance.NumberOfPages)
+}
+
+// downloadPages retrieves pages from to to (inclusive) of a document instance in order;
+// the first failing page aborts the download.
+func (c *Client) downloadPages(ctx context.Context, instance DocumentInstance, format string, from, to int) ([][]byte, error) {
+	pages := make([][]byte, 0, to-from+1)
+	for page := from; page <= to; page++ {
+		data, err := c.GetImageByLink(ctx, instance.Link, page, format)
+		if err != nil {
+			return nil, fmt.Errorf("page %d of %d: %w", page, instance.NumberOfPages, err)
+		}
+		pages = append(pages, data)
+	}
+	return pages, nil
+}
+
+// GetImageRange retrieves pages fromPage to toPage (1-based, inclusive) of a document
+// instance, e.g. pages 3-7 of a 20-page full document.
+//
+// Parameters:
+//   - country: Two-letter country code (e.g., "EP")
+//   - number: Patent number without country code (e.g., "1000000")
+//   - kind: Kind code (e.g., "B1"); may be empty
+//   - docType: Document instance type from the image inquiry (e.g., "Drawing", "FullDocument"),
+//     compared case-insensitively
+//   - fromPage, toPage: Page range
+//
+// The page count is taken from an image inquiry, which costs one extra request. A range
+// with fromPage < 1 or fromPage > toPage is rejected with a ValidationError before any
+// request, as is a docType the document does not have or a toPage beyond its pages.
+//
+// Pages are requested one after another, so retries on throttling and quota errors apply
+// to each page, and are returned in order; the first failing page aborts the download.
+//
+// Example:
+//
+//	pages, err := client.GetImageRange(ctx, "EP", "1000000", "B1", "FullDocument", 3, 7)
+func (c *Client) GetImageRange(ctx context.Context, country, number, kind, docType string, fromPage, toPage int) ([][]byte, error) {
+	ctx = withRequestID(ctx)
+	if fromPage < 1 || fromPage > toPage {
+		return nil, &ValidationError{
+			Field:   "pages",
+			Value:   fmt.Sprintf("%d-%d", fromPage, toPage),
+			Message: "must start at page 1 or later and not end before it starts",
+		}
+	}
+
+	format, ref, err := patentNumberRef(PatentNumber{Country: country, Number: number, Kind: kind})
+	if err != nil {
+		return nil, err
+	}
+	inquiry, err := c.GetImageInquiry(ctx, RefTypePublication, format, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := findDocumentInstance(inquiry, ref, docType)
+	if err != nil {
+		return nil, err
+	}
+	if toPage > instance.NumberOfPages {
+		return nil, &ValidationError{
+			Field:   "pages",
+			Value:   fmt.Sprintf("%d-%d", fromPage, toPage),
+			Message: fmt.Sprintf("%s of %s has %d pages", instance.DocType, ref, instance.NumberOfPages),
+		}
+	}
+	return c.downloadPages(ctx, instance, "", fromPage, toPage)
+}
+
+// imagesServicePath is the path of the published-data images service below the base URL.
+const imagesServicePath = "/published-data/images/"
+
+// resolveImageLink maps an image inquiry link onto Config.BaseURL.
+//
+// EPO links are rooted at "/rest-services", while BaseURL already ends with the
+// versioned rest-services path, so only the part from imagesServicePath on is kept.
+// Absolute links must point to the same scheme, host and base path as BaseURL.
+func (c *Client) resolveImageLink(link string) (*url.URL, error) {
+	invalid := func(reason string) error {
+		return &ValidationError{
+			Field:   "link",
+			Value:   link,
+			Message: reason,
+		}
+	}
+
+	base, err := url.Parse(c.config.BaseURL)
+	if err != nil {
+		return nil, &ConfigError{Message: fmt.Sprintf("invalid BaseURL: %v", err)}
+	}
+
+	target, err := url.Parse(link)
+	if err != nil {
+		return nil, invalid(fmt.Sprintf("invalid link: %v", err))
+	}
+	if target.User != nil || target.Fragment != "" || target.RawQuery != "" {
+		return nil, invalid("link must be a plain image path without credentials, query or fragment")
+	}
+
+	basePath := strings.TrimSuffix(base.Path, "/")
+	prefix, rest, found := strings.Cut(target.Path, imagesServicePath)
+	if !found || rest == "" {
+		return nil, invalid("link is not a published-data images path")
+	}
+	if target.IsAbs() || target.Host != "" {
+		if target.Scheme != base.Scheme || target.Host != base.Host || prefix != basePath {
+			return nil, invalid("link does not point to the configured OPS base URL")
+		}
+	} else if prefix != "" && prefix != "/rest-services" && prefix != basePath {
+		return nil, invalid("link is not a published-data images path")
+	}
+
+	// Reject traversal out of the images service (e.g., ".../images/../auth")
+	imagePath := imagesServicePath + rest
+	if path.Clean(imagePath) != imagePath {
+		return nil, invalid("link must not contain relative path segments")
+	}
+
+	resolved := *base
+	resolved.Path = basePath + imagePath
+	resolved.RawPath = ""
+	resolved.RawQuery = ""
+	resolved.Fragment = ""
+	return &resolved, nil
+}
+
 // GetImagePOST retrieves a patent image using POST method (keeps document identifier encrypted in body).
 // This is identical to GetImage but uses POST instead of GET, keeping the document identifier
 // in the encrypted request body rather than the URL. Both methods return one page at a time.
@@ -57,6 +448,7 @@ func (c *Client) GetImage(ctx context.Context, country, number, kind, imageType
 //	// Get first page of full document
 //	data, err := client.GetImagePOST(ctx, 1, "EP/1000000/A1/fullimage")
 func (c *Client) GetImagePOST(ctx context.Context, page int, identifier string) ([]byte, error) {
+	ctx = withRequestID(ctx)
 	if identifier == "" {
 		return nil, &ValidationError{
 			Field:   "identifier",
@@ -88,11 +480,13 @@ func (c *Client) GetImagePOST(ctx context.Context, page int, identifier string)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - number: Patent number (e.g., "EP1000000")
 //
-// Returns an ImageInquiry struct with available image metadata.
+// Returns an ImageInquiry struct with available image metadata. With
+// Config.ValidateImagesBeforeFetch set, inquiries are served from and stored in the
+// client's image inquiry cache.
 //
 // Example:
 //
-//	inquiry, err := client.GetImageInquiry(ctx, ops.RefTypePublication, "docdb", "EP1000000")
+//	inquiry, err := client.GetImageInquiry(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.1000000.B1")
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
@@ -106,6 +500,7 @@ func (c *Client) GetImagePOST(ctx context.Context, page int, identifier string)
 //	    }
 //	}
 func (c *Client) GetImageInquiry(ctx context.Context, refType, format, number string) (*ImageInquiry, error) {
+	ctx = withRequestID(ctx)
 	if err := ValidateRefType(refType); err != nil {
 		return nil, err
 	}
@@ -113,14 +508,167 @@ func (c *Client) GetImageInquiry(ctx context.Context, refType, format, number st
 		return nil, err
 	}
 
-	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
-		return c.generated.PublishedImagesInquiryService(ctx,
-			generated.PublishedImagesInquiryServiceParamsType(refType),
-			generated.PublishedImagesInquiryServiceParamsFormat(format),
-			number)
-	})
+	xmlData, err := c.executeCachedRequest(ctx, c.inquiryCache, c.config.CacheTTL,
+		cacheKey("GetImageInquiry", refType, format, number),
+		func(ctx context.Context) (*http.Response, error) {
+			return c.generated.PublishedImagesInquiryService(ctx,
+				generated.PublishedImagesInquiryServiceParamsType(refType),
+				generated.PublishedImagesInquiryServiceParamsFormat(format),
+				number)
+		})
 	if err != nil {
 		return nil, err
 	}
-	return ParseImageInquiry(xmlData)
+	return ParseImageInquiry(string(xmlData))
+}
+
+// imageInquiryCacheEntries is the capacity of the cache enabled by Config.ValidateImagesBeforeFetch.
+const imageInquiryCacheEntries = 1000
+
+// GetImageInquiriesBulk retrieves the image inquiry of many patents, e.g. to find out
+// which documents have drawings before downloading them.
+//
+// Parameters:
+//   - numbers: Publication numbers in DOCDB ("EP.1000000.B1") or EPODOC ("EP1000000B1") format
+//   - opts: Optional bulk settings; MaxConcurrent sets the number of parallel inquiries
+//     (default 1), OnProgress is called after each number. nil uses the defaults
+//
+// The inquiry service has no POST bulk variant, so this issues one GetImageInquiry per
+// number. Requests are spaced according to the images allowance (requests per minute)
+// that EPO reports in the X-Throttling-Control header of the last images response.
+//
+// Returns the inquiries and the errors keyed by patent number; every distinct number is
+// in exactly one of the maps. When ctx is canceled, the numbers not yet retrieved are
+// reported with the context error.
+//
+// Example:
+//
+//	inquiries, errs := client.GetImageInquiriesBulk(ctx, numbers, &ops.BulkOptions{MaxConcurrent: 4})
+//	for number, inquiry := range inquiries {
+//	    for _, instance := range inquiry.DocumentInstances {
+//	        if instance.DocType == "Drawing" {
+//	            fmt.Println(number, "has", instance.NumberOfPages, "drawing pages")
+//	        }
+//	    }
+//	}
+//	for number, err := range errs {
+//	    log.Printf("%s: %v", number, err)
+//	}
+func (c *Client) GetImageInquiriesBulk(ctx context.Context, numbers []string, opts *BulkOptions) (map[string]*ImageInquiry, map[string]error) {
+	ctx = withRequestID(ctx)
+	inquiries := make(map[string]*ImageInquiry)
+	errs := make(map[string]error)
+
+	// Deduplicate, keeping the order of first occurrence
+	seen := make(map[string]bool, len(numbers))
+	unique := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		if !seen[number] {
+			seen[number] = true
+			unique = append(unique, number)
+		}
+	}
+	if len(unique) == 0 {
+		return inquiries, errs
+	}
+
+	workers := 1
+	if opts != nil && opts.MaxConcurrent > 1 {
+		workers = min(opts.MaxConcurrent, len(unique))
+	}
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	completed := 0
+	pacer := &imagesPacer{client: c}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range jobs {
+				var inquiry *ImageInquiry
+				err := pacer.wait(ctx)
+				if err == nil {
+					inquiry, err = c.GetImageInquiry(ctx, RefTypePublication, inquiryNumberFormat(number), number)
+				}
+				// A request aborted by cancellation is reported with the context error
+				if err != nil && ctx.Err() != nil {
+					err = ctx.Err()
+				}
+
+				mu.Lock()
+				if err != nil {
+					errs[number] = err
+				} else {
+					inquiries[number] = inquiry
+				}
+				completed++
+				if opts != nil && opts.OnProgress != nil {
+					opts.OnProgress(completed, len(unique))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, number := range unique {
+		jobs <- number
+	}
+	close(jobs)
+	wg.Wait()
+
+	return inquiries, errs
+}
+
+// inquiryNumberFormat returns the number format of a publication number for the image inquiry.
+func inquiryNumberFormat(number string) string {
+	if ValidateDocdbFormat(number) == nil {
+		return FormatDocDB
+	}
+	return FormatEPODOC
+}
+
+// imagesPacer spaces image requests according to the images allowance reported by EPO.
+type imagesPacer struct {
+	client *Client
+	mu     sync.Mutex
+	next   time.Time
+}
+
+// wait blocks until the next image request may start, or until ctx is done.
+func (p *imagesPacer) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	interval := time.Duration(0)
+	if quota := p.client.LastQuotaFor(EndpointImages); quota != nil {
+		if _, perMinute, ok := quota.ServiceStatus("images"); ok && perMinute > 0 {
+			interval = time.Minute / time.Duration(perMinute)
+		}
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	start := now
+	if p.next.After(now) {
+		start = p.next
+	}
+	p.next = start.Add(interval)
+	p.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/patent-dev/epo-ops/cql"
 	"github.com/patent-dev/epo-ops/generated"
 )
 
@@ -20,9 +23,65 @@ func (c *Client) GetLegal(ctx context.Context, refType, format, number string) (
 	return ParseLegal(xmlData)
 }
 
+// GetLegalSince retrieves and parses legal status data, keeping only the events recorded
+// on or after the calendar day of since (see LegalData.EventsSince), e.g. for a watch
+// service that polls for news since its last check.
+//
+// EPO always returns the complete legal history, so this costs the same request as
+// GetLegal; the filtering happens client-side. Legal data is never cached, so each
+// poll sees the current status.
+//
+// Example:
+//
+//	legal, err := client.GetLegalSince(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.2400812.A1", lastCheck)
+//	for _, event := range legal.LegalEvents {
+//	    fmt.Println(event.DateMigr, event.Code, event.Description)
+//	}
+func (c *Client) GetLegalSince(ctx context.Context, refType, format, number string, since time.Time) (*LegalData, error) {
+	data, err := c.GetLegal(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	data.LegalEvents = data.EventsSince(since)
+	return data, nil
+}
+
+// GetLegalByPublication retrieves and parses legal status data for a publication number.
+// It is equivalent to GetLegal with RefTypePublication.
+//
+// Example:
+//
+//	legal, err := client.GetLegalByPublication(ctx, ops.FormatDocDB, "EP.1000000.B1")
+func (c *Client) GetLegalByPublication(ctx context.Context, format, number string) (*LegalData, error) {
+	return c.GetLegal(ctx, RefTypePublication, format, number)
+}
+
+// GetLegalByApplication retrieves and parses legal status data for an application number.
+// It is equivalent to GetLegal with RefTypeApplication.
+//
+// Example:
+//
+//	legal, err := client.GetLegalByApplication(ctx, ops.FormatEPODOC, "EP99203729")
+func (c *Client) GetLegalByApplication(ctx context.Context, format, number string) (*LegalData, error) {
+	return c.GetLegal(ctx, RefTypeApplication, format, number)
+}
+
+// GetLegalByPriority retrieves and parses legal status data for a priority number.
+// It is equivalent to GetLegal with RefTypePriority.
+//
+// Example:
+//
+//	legal, err := client.GetLegalByPriority(ctx, ops.FormatEPODOC, "US19990465270")
+func (c *Client) GetLegalByPriority(ctx context.Context, format, number string) (*LegalData, error) {
+	return c.GetLegal(ctx, RefTypePriority, format, number)
+}
+
 // GetLegalRaw retrieves legal status data as raw XML.
 // For parsed data, use GetLegal() instead.
-func (c *Client) GetLegalRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetLegalRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -44,43 +103,76 @@ func (c *Client) GetLegalRaw(ctx context.Context, refType, format, number string
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns parsed legal status data for all requested patents.
-func (c *Client) GetLegalMultiple(ctx context.Context, refType, format string, numbers []string) (*LegalData, error) {
-	if err := ValidateRefType(refType); err != nil {
+func (c *Client) GetLegalMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (*LegalData, error) {
+	xmlData, err := c.GetLegalMultipleRaw(ctx, refType, format, numbers, opts...)
+	if err != nil {
 		return nil, err
 	}
+	return ParseLegal(xmlData)
+}
+
+// GetLegalMultipleRaw retrieves legal status data for multiple patents as raw XML.
+// For parsed data, use GetLegalMultiple() instead.
+func (c *Client) GetLegalMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
 
 	if err := ValidateBulkNumbers(numbers, format); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Use generated POST method
 	body := formatBulkBody(numbers)
-	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.LegalDataRetrievalServicePOSTWithTextBody(ctx,
 			generated.LegalDataRetrievalServicePOSTParamsType(refType),
 			generated.LegalDataRetrievalServicePOSTParamsFormat(format),
 			body)
 	})
-	if err != nil {
-		return nil, err
-	}
-	return ParseLegal(xmlData)
 }
 
-// GetRegisterBiblio retrieves bibliographic data from the EPO Register.
+// GetRegisterBiblio retrieves bibliographic data from the EPO Register and parses it.
 //
 // Parameters:
 //   - refType: Reference type (e.g., "publication", "application", "priority")
 //   - format: Number format (e.g., "docdb", "epodoc")
 //   - number: Patent number (e.g., "EP1000000")
 //
-// Returns EPO Register bibliographic data as XML.
+// Returns parsed Register data including status history, designated states and
+// lapses. See ParseRegisterBiblio for how renewal status is derived.
 //
 // Note: The EPO Register contains more detailed and up-to-date information
 // than the standard bibliographic service.
-func (c *Client) GetRegisterBiblioRaw(ctx context.Context, refType, format, number string) (string, error) {
+//
+// Example:
+//
+//	biblio, err := client.GetRegisterBiblio(ctx, "publication", "epodoc", "EP2400812")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(biblio.Status, biblio.DesignatedStates)
+func (c *Client) GetRegisterBiblio(ctx context.Context, refType, format, number string) (*RegisterBiblioData, error) {
+	xmlData, err := c.GetRegisterBiblioRaw(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterBiblio(xmlData)
+}
+
+// GetRegisterBiblioRaw retrieves bibliographic data from the EPO Register as raw XML.
+// For parsed data, use GetRegisterBiblio() instead.
+func (c *Client) GetRegisterBiblioRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -101,9 +193,14 @@ func (c *Client) GetRegisterBiblioRaw(ctx context.Context, refType, format, numb
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing EPO Register bibliographic data for all requested patents.
-func (c *Client) GetRegisterBiblioMultipleRaw(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetRegisterBiblioMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -140,12 +237,35 @@ func (c *Client) GetRegisterBiblioMultipleRaw(ctx context.Context, refType, form
 //   - format: Number format (e.g., "docdb", "epodoc")
 //   - number: Patent number (e.g., "EP1000000")
 //
-// Returns EPO Register events as XML, including:
+// Returns parsed EPO Register events (most recent first), including:
 //   - Filing events
 //   - Publication events
 //   - Examination events
-//   - Grant/refusal events
-func (c *Client) GetRegisterEventsRaw(ctx context.Context, refType, format, number string) (string, error) {
+//   - Grant/refusal and opposition events
+//
+// Example:
+//
+//	events, err := client.GetRegisterEvents(ctx, "publication", "epodoc", "EP2400812")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, event := range events.Events {
+//	    fmt.Println(event.Date, event.Code, event.Description)
+//	}
+func (c *Client) GetRegisterEvents(ctx context.Context, refType, format, number string) (*RegisterEventsData, error) {
+	xmlData, err := c.GetRegisterEventsRaw(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterEvents(xmlData)
+}
+
+// GetRegisterEventsRaw retrieves procedural events from the EPO Register as raw XML.
+// For parsed data, use GetRegisterEvents() instead.
+func (c *Client) GetRegisterEventsRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -166,9 +286,14 @@ func (c *Client) GetRegisterEventsRaw(ctx context.Context, refType, format, numb
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing EPO Register events for all requested patents.
-func (c *Client) GetRegisterEventsMultipleRaw(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetRegisterEventsMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -208,11 +333,10 @@ func (c *Client) GetRegisterEventsMultipleRaw(ctx context.Context, refType, form
 //   - format: Number format ("epodoc" only)
 //   - number: Patent number (e.g., "EP1000000")
 //
-// Returns XML containing:
-//   - Procedural step history
-//   - Step dates and descriptions
-//   - Administrative actions
-//   - Milestone information
+// Returns parsed procedural steps including:
+//   - Step code, phase and description (e.g., examination requests, R71(3) communications, grant)
+//   - Step dates and time limits
+//   - Register status of the application
 //
 // Example:
 //
@@ -221,7 +345,23 @@ func (c *Client) GetRegisterEventsMultipleRaw(ctx context.Context, refType, form
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (c *Client) GetRegisterProceduralStepsRaw(ctx context.Context, refType, format, number string) (string, error) {
+//	for _, step := range steps.Steps {
+//	    fmt.Println(step.Date, step.Code, step.Description)
+//	}
+func (c *Client) GetRegisterProceduralSteps(ctx context.Context, refType, format, number string) (*ProceduralStepsData, error) {
+	xmlData, err := c.GetRegisterProceduralStepsRaw(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProceduralSteps(xmlData)
+}
+
+// GetRegisterProceduralStepsRaw retrieves procedural steps from the EPO Register as raw XML.
+// For parsed data, use GetRegisterProceduralSteps() instead.
+func (c *Client) GetRegisterProceduralStepsRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -253,6 +393,7 @@ func (c *Client) GetRegisterProceduralStepsRaw(ctx context.Context, refType, for
 //   - refType: Reference type ("publication" or "application")
 //   - format: Number format ("epodoc" only)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing procedural steps for all requested patents.
 //
@@ -260,7 +401,11 @@ func (c *Client) GetRegisterProceduralStepsRaw(ctx context.Context, refType, for
 //
 //	numbers := []string{"EP1000000", "EP1000001", "EP1000002"}
 //	steps, err := client.GetRegisterProceduralStepsMultiple(ctx, "publication", "epodoc", numbers)
-func (c *Client) GetRegisterProceduralStepsMultipleRaw(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetRegisterProceduralStepsMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if len(numbers) == 0 {
 		return "", &ConfigError{Message: "numbers list cannot be empty"}
 	}
@@ -297,15 +442,34 @@ func (c *Client) GetRegisterProceduralStepsMultipleRaw(ctx context.Context, refT
 //   - format: Number format (must be "epodoc")
 //   - number: Patent number in specified format
 //
-// Returns XML or JSON with unitary patent information including:
-//   - Unified Patent Court opt-out status
-//   - Unitary effect registration
+// Returns parsed unitary patent information including:
+//   - Unitary effect registration and request date
 //   - Participating member states
+//   - Unified Patent Court opt-out status
 //
 // Example:
 //
 //	unip, err := client.GetRegisterUNIP(ctx, epo_ops.RefTypePublication, "epodoc", "EP3000000")
-func (c *Client) GetRegisterUNIPRaw(ctx context.Context, refType, format, number string) (string, error) {
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if unip.UPCOptOut {
+//	    fmt.Println("Opted out of the UPC since", unip.OptOutDate)
+//	}
+func (c *Client) GetRegisterUNIP(ctx context.Context, refType, format, number string) (*UnitaryPatentData, error) {
+	xmlData, err := c.GetRegisterUNIPRaw(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterUNIP(xmlData)
+}
+
+// GetRegisterUNIPRaw retrieves unitary patent package (UPP) information from the EPO Register as raw XML.
+// For parsed data, use GetRegisterUNIP() instead.
+func (c *Client) GetRegisterUNIPRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	// Validate reference type
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
@@ -336,6 +500,7 @@ func (c *Client) GetRegisterUNIPRaw(ctx context.Context, refType, format, number
 //   - refType: Reference type (RefTypePublication or RefTypeApplication)
 //   - format: Number format (must be "epodoc")
 //   - numbers: List of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML or JSON with unitary patent information for all requested patents.
 //
@@ -343,7 +508,11 @@ func (c *Client) GetRegisterUNIPRaw(ctx context.Context, refType, format, number
 //
 //	numbers := []string{"EP3000000", "EP3000001"}
 //	unip, err := client.GetRegisterUNIPMultiple(ctx, epo_ops.RefTypePublication, "epodoc", numbers)
-func (c *Client) GetRegisterUNIPMultipleRaw(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetRegisterUNIPMultipleRaw(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	// Validate reference type
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
@@ -385,45 +554,98 @@ func (c *Client) GetRegisterUNIPMultipleRaw(ctx context.Context, refType, format
 // SearchRegister searches the EPO Register for patents matching a query.
 //
 // Parameters:
-//   - query: Search query (e.g., "ti=plastic", "applicant=google")
+//   - query: Search query (e.g., "ti=plastic", "pa=google")
 //   - rangeSpec: Optional range specification (e.g., "1-25", "26-50"). Empty string uses default (1-25).
+//     A range may span at most 100 results.
+//   - opts: Optional per-call settings, e.g. WithRange to pass a SearchRange instead of rangeSpec
 //
-// The query parameter supports various search fields including:
-//   - ti (title), ab (abstract), ta (title and abstract)
-//   - applicant, inventor
-//   - pn (publication number), an (application number)
+// The query parameter supports the register search fields, including:
+//   - ti (title)
+//   - pa (applicant), in (inventor), re (representative), op (opponent)
+//   - pn (publication number), ap (application number), pr (priority number)
 //   - pd (publication date), ad (application date)
-//   - cl (classification)
+//   - ic (IPC classification)
+//
+// Queries using other fields (see cql.FieldsForEndpoint with cql.EndpointRegister) are
+// rejected before the request; see cql.ValidateForEndpoint. So are queries longer than
+// cql.MaxQueryLength, with a ValidationError.
 //
 // Returns XML or JSON with matching register entries including bibliographic data.
 //
 // Example:
 //
-//	results, err := client.SearchRegister(ctx, "ti=battery AND applicant=tesla", "1-100")
-func (c *Client) SearchRegister(ctx context.Context, query, rangeSpec string) (string, error) {
+//	results, err := client.SearchRegister(ctx, "ti=battery AND pa=tesla", "1-100")
+func (c *Client) SearchRegister(ctx context.Context, query, rangeSpec string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
 	if query == "" {
 		return "", &ConfigError{Message: "search query cannot be empty"}
 	}
+	if err := validateQueryLength(query); err != nil {
+		return "", err
+	}
+	// Validate CQL query against the register search fields
+	if err := cql.ValidateForEndpoint(query, cql.EndpointRegister); err != nil {
+		return "", err
+	}
 
-	params := &generated.RegisterSearchServiceWithoutConstituentsParams{
-		Q: query,
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	rangeParam, err := searchRangeParam(ctx, rangeSpec)
+	if err != nil {
+		return "", err
 	}
 
-	if rangeSpec != "" {
-		params.Range = &rangeSpec
+	params := &generated.RegisterSearchServiceWithoutConstituentsParams{
+		Q:     query,
+		Range: &rangeParam,
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	data, err := c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.RegisterSearchServiceWithoutConstituents(ctx, params)
 	})
+	return data, withCQLQuery(err, query)
+}
+
+// SearchRegisterAll searches the EPO Register and collects the parsed results of all pages.
+//
+// Parameters:
+//   - query: Search query (e.g., "ti=plastic", "pa=google")
+//   - maxResults: Maximum number of results to return (0 or less for all, capped at MaxRegisterSearchResults)
+//   - opts: Optional per-call settings; any WithRange option is replaced by the page ranges
+//
+// Pages of MaxSearchRangeSize results are requested like in SearchAll. A query without
+// matches returns an empty slice and a nil error.
+//
+// Example:
+//
+//	results, err := client.SearchRegisterAll(ctx, "pa=tesla", 250)
+//	for _, result := range results {
+//	    fmt.Println(result.Country+result.PublicationNumber, result.Title)
+//	}
+func (c *Client) SearchRegisterAll(ctx context.Context, query string, maxResults int, opts ...RequestOption) ([]RegisterSearchResult, error) {
+	ctx = withRequestID(ctx)
+	return searchAllPages(maxResults, MaxRegisterSearchResults, func(r SearchRange) ([]RegisterSearchResult, int, error) {
+		xmlData, err := c.SearchRegister(ctx, query, "", append(slices.Clip(opts), WithRange(r))...)
+		if err != nil {
+			return nil, 0, err
+		}
+		page, err := ParseRegisterSearch(xmlData)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Results, page.TotalCount, nil
+	})
 }
 
 // SearchRegisterWithConstituent searches the EPO Register and returns specific constituent data.
 //
 // Parameters:
 //   - constituent: The type of data to return ("biblio", "events", "procedural-steps", "upp")
-//   - query: Search query (e.g., "ti=plastic", "applicant=google")
+//   - query: Search query (e.g., "ti=plastic", "pa=google")
 //   - rangeSpec: Optional range specification (e.g., "1-25"). Empty string uses default (1-25).
+//     A range may span at most 100 results.
+//   - opts: Optional per-call settings, e.g. WithRange
 //
 // Constituents:
 //   - "biblio": Bibliographic data
@@ -431,13 +653,16 @@ func (c *Client) SearchRegister(ctx context.Context, query, rangeSpec string) (s
 //   - "procedural-steps": Procedural step information
 //   - "upp": Unified Patent Package data
 //
+// Queries are validated like in SearchRegister before the request.
+//
 // Returns XML or JSON with matching register entries for the specified constituent.
 //
 // Example:
 //
 //	// Search for patents and get legal events
-//	events, err := client.SearchRegisterWithConstituent(ctx, "events", "applicant=tesla", "1-50")
-func (c *Client) SearchRegisterWithConstituent(ctx context.Context, constituent, query, rangeSpec string) (string, error) {
+//	events, err := client.SearchRegisterWithConstituent(ctx, "events", "pa=tesla", "1-50")
+func (c *Client) SearchRegisterWithConstituent(ctx context.Context, constituent, query, rangeSpec string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
 	if constituent == "" {
 		return "", &ConfigError{Message: "constituent cannot be empty"}
 	}
@@ -445,6 +670,16 @@ func (c *Client) SearchRegisterWithConstituent(ctx context.Context, constituent,
 	if query == "" {
 		return "", &ConfigError{Message: "search query cannot be empty"}
 	}
+	if err := validateQueryLength(query); err != nil {
+		return "", err
+	}
+	// Validate CQL query against the register search fields
+	if err := cql.ValidateForEndpoint(query, cql.EndpointRegister); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 
 	// Validate and convert constituent to enum
 	validConstituents := map[string]generated.RegisterSearchServiceWithVariableConstituentsParamsConstituent{
@@ -459,15 +694,18 @@ func (c *Client) SearchRegisterWithConstituent(ctx context.Context, constituent,
 		return "", &ConfigError{Message: "constituent must be one of: biblio, events, procedural-steps, upp"}
 	}
 
-	params := &generated.RegisterSearchServiceWithVariableConstituentsParams{
-		Q: query,
+	rangeParam, err := searchRangeParam(ctx, rangeSpec)
+	if err != nil {
+		return "", err
 	}
 
-	if rangeSpec != "" {
-		params.Range = &rangeSpec
+	params := &generated.RegisterSearchServiceWithVariableConstituentsParams{
+		Q:     query,
+		Range: &rangeParam,
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	data, err := c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.RegisterSearchServiceWithVariableConstituents(ctx, constituentEnum, params)
 	})
+	return data, withCQLQuery(err, query)
 }
@@ -12,6 +12,7 @@ import (
 //
 // This file contains methods for converting patent numbers between formats.
 func (c *Client) ConvertPatentNumber(ctx context.Context, refType, inputFormat, number, outputFormat string) (string, error) {
+	ctx = withRequestID(ctx)
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -26,7 +27,7 @@ func (c *Client) ConvertPatentNumber(ctx context.Context, refType, inputFormat,
 			Message: "must be 'docdb', 'epodoc', or 'original'",
 		}
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("ConvertPatentNumber", refType, inputFormat, number, outputFormat), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.NumberService(ctx,
 			generated.NumberServiceParamsType(refType),
 			generated.NumberServiceParamsInputFormat(inputFormat),
@@ -43,9 +44,14 @@ func (c *Client) ConvertPatentNumber(ctx context.Context, refType, inputFormat,
 //   - inputFormat: Input format ("original", "epodoc", "docdb")
 //   - numbers: Slice of patent numbers in input format (max 100)
 //   - outputFormat: Output format ("original", "epodoc", "docdb")
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing converted patent numbers for all requested patents.
-func (c *Client) ConvertPatentNumberMultiple(ctx context.Context, refType, inputFormat string, numbers []string, outputFormat string) (string, error) {
+func (c *Client) ConvertPatentNumberMultiple(ctx context.Context, refType, inputFormat string, numbers []string, outputFormat string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -91,3 +97,42 @@ func (c *Client) ConvertPatentNumberMultiple(ctx context.Context, refType, input
 			body)
 	})
 }
+
+// ConvertToDocdb converts a number in original format (as written by the issuing
+// authority, e.g. "JP.(2006-147056).A") to docdb format using the EPO number service.
+//
+// Unlike the local NormalizeToDocdb, the conversion is done by EPO, so it also
+// handles national numbering schemes with year prefixes, slashes or dashes that
+// cannot be converted by string rules. Each call issues one request.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - originalNumber: Number in original format
+//
+// Returns the docdb number (e.g., "JP.2006147056.A"), ready for other client methods.
+//
+// Example:
+//
+//	number, err := client.ConvertToDocdb(ctx, ops.RefTypeApplication, "JP.(2006-147056).A")
+func (c *Client) ConvertToDocdb(ctx context.Context, refType, originalNumber string) (string, error) {
+	return c.convertOriginal(ctx, refType, originalNumber, FormatDocDB)
+}
+
+// ConvertToEpodoc is like ConvertToDocdb but returns the number in epodoc format
+// (e.g., "JP20060147056A").
+func (c *Client) ConvertToEpodoc(ctx context.Context, refType, originalNumber string) (string, error) {
+	return c.convertOriginal(ctx, refType, originalNumber, FormatEPODOC)
+}
+
+// convertOriginal converts a number in original format to outputFormat with the number service.
+func (c *Client) convertOriginal(ctx context.Context, refType, number, outputFormat string) (string, error) {
+	xmlData, err := c.ConvertPatentNumber(ctx, refType, FormatOriginal, number, outputFormat)
+	if err != nil {
+		return "", err
+	}
+	data, err := ParseNumberConversion(xmlData)
+	if err != nil {
+		return "", err
+	}
+	return data.Number, nil
+}
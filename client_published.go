@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/patent-dev/epo-ops/generated"
 )
@@ -12,13 +15,16 @@ import (
 //
 // This file contains all methods for retrieving published patent data including:
 //   - Bibliographic data (GetBiblio)
-//   - Claims (GetClaims)
+//   - Claims (GetClaims, GetClaimsByLanguage)
 //   - Descriptions (GetDescription)
 //   - Abstracts (GetAbstract)
 //   - Fulltext (GetFulltext)
 //   - Equivalents (GetPublishedEquivalents)
+//   - Publication history (GetFullCycle)
 //
 // Each method has both a parsed version (returns Go structs) and a Raw version (returns XML string).
+// The core retrieval methods also have a WithMeta version returning the response headers and quota,
+// and a For version taking a parsed PatentNumber (e.g., GetBiblioFor).
 
 // GetBiblio retrieves and parses bibliographic data for a patent.
 //
@@ -36,22 +42,115 @@ func (c *Client) GetBiblio(ctx context.Context, refType, format, number string)
 	return ParseBiblio(xml)
 }
 
+// GetBiblioByPublication retrieves and parses bibliographic data for a publication number.
+// It is equivalent to GetBiblio with RefTypePublication.
+//
+// Example:
+//
+//	biblio, err := client.GetBiblioByPublication(ctx, ops.FormatDocDB, "EP.1000000.B1")
+func (c *Client) GetBiblioByPublication(ctx context.Context, format, number string) (*BiblioData, error) {
+	return c.GetBiblio(ctx, RefTypePublication, format, number)
+}
+
+// GetBiblioByApplication retrieves and parses bibliographic data for an application number.
+// It is equivalent to GetBiblio with RefTypeApplication.
+//
+// Example:
+//
+//	biblio, err := client.GetBiblioByApplication(ctx, ops.FormatEPODOC, "EP99203729")
+func (c *Client) GetBiblioByApplication(ctx context.Context, format, number string) (*BiblioData, error) {
+	return c.GetBiblio(ctx, RefTypeApplication, format, number)
+}
+
+// GetBiblioByPriority retrieves and parses bibliographic data for a priority number.
+// It is equivalent to GetBiblio with RefTypePriority.
+//
+// Example:
+//
+//	biblio, err := client.GetBiblioByPriority(ctx, ops.FormatEPODOC, "US19990465270")
+func (c *Client) GetBiblioByPriority(ctx context.Context, format, number string) (*BiblioData, error) {
+	return c.GetBiblio(ctx, RefTypePriority, format, number)
+}
+
+// GetBiblioFor retrieves and parses bibliographic data for a parsed patent number.
+//
+// A number with a kind code is sent in docdb format (e.g., "EP.2400812.A1"), one
+// without in epodoc format (e.g., "EP2400812"), so a PatentNumber from
+// ParsePatentNumber can be used as is:
+//
+//	biblio, err := client.GetBiblioFor(ctx, ops.RefTypePublication, ops.ParsePatentNumber("EP2400812A1"))
+func (c *Client) GetBiblioFor(ctx context.Context, refType string, pn PatentNumber) (*BiblioData, error) {
+	format, number, err := patentNumberRef(pn)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetBiblio(ctx, refType, format, number)
+}
+
+// patentNumberRef returns the format and number string for a parsed patent number:
+// docdb if it has a kind code, epodoc otherwise.
+func patentNumberRef(pn PatentNumber) (format, number string, err error) {
+	format, number = pn.Ref()
+	if number == "" {
+		return "", "", &ValidationError{
+			Field:   "number",
+			Value:   pn.Country + pn.Number + pn.Kind,
+			Message: "patent number needs a country code and a number",
+		}
+	}
+	return format, number, nil
+}
+
 // GetBiblioRaw retrieves bibliographic data for a patent as raw XML.
 //
 // Parameters:
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - number: Patent number (e.g., "EP1000000B1")
+//   - opts: Optional per-call settings, e.g. WithAcceptOverride to request another representation
 //
 // Returns the bibliographic data as an XML string.
-func (c *Client) GetBiblioRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetBiblioRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
 	if err := ValidateFormat(format, number); err != nil {
 		return "", err
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetBiblioRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
+		return c.generated.PublishedDataRetrieval(ctx,
+			generated.PublishedDataRetrievalParamsType(refType),
+			generated.PublishedDataRetrievalParamsFormat(format),
+			number)
+	})
+}
+
+// GetBiblioWithMeta retrieves bibliographic data for a patent together with the response metadata.
+//
+// Parameters are the same as for GetBiblioRaw. The returned RawResponse carries the XML body,
+// the status code and the response headers, including the ETag and the quota parsed from
+// this response (unlike GetLastQuota, unaffected by concurrent calls). The response is
+// always fetched from EPO; Config.Cache is neither read nor written.
+//
+// Example:
+//
+//	raw, err := client.GetBiblioWithMeta(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.1000000.B1")
+//	biblio, err := ops.ParseBiblio(string(raw.Body))
+//	fmt.Println(raw.ETag(), raw.Quota.Individual.Used)
+func (c *Client) GetBiblioWithMeta(ctx context.Context, refType, format, number string, opts ...RequestOption) (*RawResponse, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return nil, err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return nil, err
+	}
+	return c.executeRawRequest(ctx, func() (*http.Response, error) {
 		return c.generated.PublishedDataRetrieval(ctx,
 			generated.PublishedDataRetrievalParamsType(refType),
 			generated.PublishedDataRetrievalParamsFormat(format),
@@ -75,22 +174,123 @@ func (c *Client) GetClaims(ctx context.Context, refType, format, number string)
 	return ParseClaims(xml)
 }
 
+// GetClaimsByPublication retrieves and parses claims for a publication number.
+// It is equivalent to GetClaims with RefTypePublication.
+//
+// Example:
+//
+//	claims, err := client.GetClaimsByPublication(ctx, ops.FormatDocDB, "EP.1000000.B1")
+func (c *Client) GetClaimsByPublication(ctx context.Context, format, number string) (*ClaimsData, error) {
+	return c.GetClaims(ctx, RefTypePublication, format, number)
+}
+
+// GetClaimsByApplication retrieves and parses claims for an application number.
+// It is equivalent to GetClaims with RefTypeApplication.
+//
+// Example:
+//
+//	claims, err := client.GetClaimsByApplication(ctx, ops.FormatEPODOC, "EP99203729")
+func (c *Client) GetClaimsByApplication(ctx context.Context, format, number string) (*ClaimsData, error) {
+	return c.GetClaims(ctx, RefTypeApplication, format, number)
+}
+
+// GetClaimsByPriority retrieves and parses claims for a priority number.
+// It is equivalent to GetClaims with RefTypePriority.
+//
+// Example:
+//
+//	claims, err := client.GetClaimsByPriority(ctx, ops.FormatEPODOC, "US19990465270")
+func (c *Client) GetClaimsByPriority(ctx context.Context, format, number string) (*ClaimsData, error) {
+	return c.GetClaims(ctx, RefTypePriority, format, number)
+}
+
+// GetClaimsFor retrieves and parses claims for a parsed patent number, in docdb format
+// if it has a kind code and in epodoc format otherwise (see GetBiblioFor).
+func (c *Client) GetClaimsFor(ctx context.Context, refType string, pn PatentNumber) (*ClaimsData, error) {
+	format, number, err := patentNumberRef(pn)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetClaims(ctx, refType, format, number)
+}
+
+// GetClaimsByLanguage retrieves and parses claims for a patent in a specific language.
+//
+// EP grants (B1/B2) carry claims in English, German and French; other documents often
+// have a single language. The language match is case-insensitive.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - number: Patent number (e.g., "EP1000000B1")
+//   - lang: Language code (e.g., "en", "de", "fr")
+//
+// Returns the claims in the requested language, or a NotFoundError listing the
+// available languages if the document has no claims in that language.
+//
+// Example:
+//
+//	claims, err := client.GetClaimsByLanguage(ctx, "publication", "docdb", "EP1000000B1", "de")
+func (c *Client) GetClaimsByLanguage(ctx context.Context, refType, format, number, lang string) (*ClaimsData, error) {
+	if lang == "" {
+		return nil, &ValidationError{Field: "lang", Value: lang, Message: "language cannot be empty"}
+	}
+
+	data, err := c.GetClaims(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := data.InLanguage(lang)
+	if claims == nil {
+		return nil, &NotFoundError{
+			Resource: fmt.Sprintf("claims of %s in language %q", number, lang),
+			Message:  fmt.Sprintf("available languages: %s", strings.Join(data.AllLanguages(), ", ")),
+		}
+	}
+	return claims, nil
+}
+
 // GetClaimsRaw retrieves claims for a patent as raw XML.
 //
 // Parameters:
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - number: Patent number (e.g., "EP1000000B1")
+//   - opts: Optional per-call settings, e.g. WithAcceptOverride to request another representation
 //
 // Returns the claims as an XML string.
-func (c *Client) GetClaimsRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetClaimsRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
 	if err := ValidateFormat(format, number); err != nil {
 		return "", err
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetClaimsRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
+		return c.generated.PublishedDataClaimsRetrievalService(ctx,
+			generated.PublishedDataClaimsRetrievalServiceParamsType(refType),
+			generated.PublishedDataClaimsRetrievalServiceParamsFormat(format),
+			number)
+	})
+}
+
+// GetClaimsWithMeta retrieves the claims for a patent together with the response metadata
+// (status code, headers and quota); see GetBiblioWithMeta.
+func (c *Client) GetClaimsWithMeta(ctx context.Context, refType, format, number string, opts ...RequestOption) (*RawResponse, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return nil, err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return nil, err
+	}
+	return c.executeRawRequest(ctx, func() (*http.Response, error) {
 		return c.generated.PublishedDataClaimsRetrievalService(ctx,
 			generated.PublishedDataClaimsRetrievalServiceParamsType(refType),
 			generated.PublishedDataClaimsRetrievalServiceParamsFormat(format),
@@ -114,16 +314,49 @@ func (c *Client) GetDescription(ctx context.Context, refType, format, number str
 	return ParseDescription(xmlData)
 }
 
+// GetDescriptionFor retrieves and parses the description for a parsed patent number,
+// in docdb format if it has a kind code and in epodoc format otherwise (see GetBiblioFor).
+func (c *Client) GetDescriptionFor(ctx context.Context, refType string, pn PatentNumber) (*DescriptionData, error) {
+	format, number, err := patentNumberRef(pn)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetDescription(ctx, refType, format, number)
+}
+
 // GetDescriptionRaw retrieves patent description as raw XML.
 // For parsed data, use GetDescription() instead.
-func (c *Client) GetDescriptionRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetDescriptionRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
 	if err := ValidateFormat(format, number); err != nil {
 		return "", err
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetDescriptionRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
+		return c.generated.PublishedDataDescriptionRetrievalService(ctx,
+			generated.PublishedDataDescriptionRetrievalServiceParamsType(refType),
+			generated.PublishedDataDescriptionRetrievalServiceParamsFormat(format),
+			number)
+	})
+}
+
+// GetDescriptionWithMeta retrieves the description for a patent together with the response metadata
+// (status code, headers and quota); see GetBiblioWithMeta.
+func (c *Client) GetDescriptionWithMeta(ctx context.Context, refType, format, number string, opts ...RequestOption) (*RawResponse, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return nil, err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return nil, err
+	}
+	return c.executeRawRequest(ctx, func() (*http.Response, error) {
 		return c.generated.PublishedDataDescriptionRetrievalService(ctx,
 			generated.PublishedDataDescriptionRetrievalServiceParamsType(refType),
 			generated.PublishedDataDescriptionRetrievalServiceParamsFormat(format),
@@ -131,6 +364,49 @@ func (c *Client) GetDescriptionRaw(ctx context.Context, refType, format, number
 	})
 }
 
+// GetDescriptionStream retrieves a patent description and streams its paragraphs to fn.
+//
+// The HTTP response body is piped directly into StreamDescription instead of being
+// buffered into a string, so very long descriptions (e.g., chemical or pharmaceutical
+// patents with thousands of paragraphs) can be written to disk or a database incrementally.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - number: Patent number (e.g., "EP1000000B1")
+//   - fn: Called once per paragraph, in document order; returning an error stops the stream
+//
+// Retries only cover establishing the response; errors while streaming the body
+// (including those returned by fn) are returned as-is.
+//
+// Example:
+//
+//	err := client.GetDescriptionStream(ctx, "publication", "docdb", "EP1000000B1",
+//	    func(p ops.Paragraph) error {
+//	        return db.InsertParagraph(p.Num, p.Text)
+//	    })
+func (c *Client) GetDescriptionStream(ctx context.Context, refType, format, number string, fn func(Paragraph) error) error {
+	ctx = withRequestID(ctx)
+	if err := ValidateRefType(refType); err != nil {
+		return err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return err
+	}
+	resp, err := c.executeStreamRequest(ctx, func() (*http.Response, error) {
+		return c.generated.PublishedDataDescriptionRetrievalService(ctx,
+			generated.PublishedDataDescriptionRetrievalServiceParamsType(refType),
+			generated.PublishedDataDescriptionRetrievalServiceParamsFormat(format),
+			number)
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return StreamDescription(resp.Body, fn)
+}
+
 // GetAbstract retrieves and parses the abstract for a patent.
 //
 // Parameters:
@@ -147,22 +423,56 @@ func (c *Client) GetAbstract(ctx context.Context, refType, format, number string
 	return ParseAbstract(xml)
 }
 
+// GetAbstractFor retrieves and parses the abstract for a parsed patent number, in
+// docdb format if it has a kind code and in epodoc format otherwise (see GetBiblioFor).
+func (c *Client) GetAbstractFor(ctx context.Context, refType string, pn PatentNumber) (*AbstractData, error) {
+	format, number, err := patentNumberRef(pn)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAbstract(ctx, refType, format, number)
+}
+
 // GetAbstractRaw retrieves the abstract for a patent as raw XML.
 //
 // Parameters:
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - number: Patent number (e.g., "EP1000000B1")
+//   - opts: Optional per-call settings, e.g. WithAcceptOverride to request another representation
 //
 // Returns the abstract as an XML string.
-func (c *Client) GetAbstractRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetAbstractRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
 	if err := ValidateFormat(format, number); err != nil {
 		return "", err
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetAbstractRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
+		return c.generated.PublishedDataAbstractService(ctx,
+			generated.PublishedDataAbstractServiceParamsType(refType),
+			generated.PublishedDataAbstractServiceParamsFormat(format),
+			number)
+	})
+}
+
+// GetAbstractWithMeta retrieves the abstract for a patent together with the response metadata
+// (status code, headers and quota); see GetBiblioWithMeta.
+func (c *Client) GetAbstractWithMeta(ctx context.Context, refType, format, number string, opts ...RequestOption) (*RawResponse, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return nil, err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return nil, err
+	}
+	return c.executeRawRequest(ctx, func() (*http.Response, error) {
 		return c.generated.PublishedDataAbstractService(ctx,
 			generated.PublishedDataAbstractServiceParamsType(refType),
 			generated.PublishedDataAbstractServiceParamsFormat(format),
@@ -186,16 +496,29 @@ func (c *Client) GetFulltext(ctx context.Context, refType, format, number string
 	return ParseFulltext(xmlData)
 }
 
+// GetFulltextFor retrieves and parses the full text for a parsed patent number, in
+// docdb format if it has a kind code and in epodoc format otherwise (see GetBiblioFor).
+func (c *Client) GetFulltextFor(ctx context.Context, refType string, pn PatentNumber) (*FulltextData, error) {
+	format, number, err := patentNumberRef(pn)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetFulltext(ctx, refType, format, number)
+}
+
 // GetFulltextRaw retrieves full text as raw XML.
 // For parsed data, use GetFulltext() instead.
-func (c *Client) GetFulltextRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetFulltextRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
 	if err := ValidateFormat(format, number); err != nil {
 		return "", err
 	}
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetFulltextRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.PublishedDataFulltextInquiryService(ctx,
 			generated.PublishedDataFulltextInquiryServiceParamsType(refType),
 			generated.PublishedDataFulltextInquiryServiceParamsFormat(format),
@@ -203,6 +526,137 @@ func (c *Client) GetFulltextRaw(ctx context.Context, refType, format, number str
 	})
 }
 
+// GetFulltextWithMeta retrieves the full text for a patent together with the response metadata
+// (status code, headers and quota); see GetBiblioWithMeta.
+func (c *Client) GetFulltextWithMeta(ctx context.Context, refType, format, number string, opts ...RequestOption) (*RawResponse, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return nil, err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return nil, err
+	}
+	return c.executeRawRequest(ctx, func() (*http.Response, error) {
+		return c.generated.PublishedDataFulltextInquiryService(ctx,
+			generated.PublishedDataFulltextInquiryServiceParamsType(refType),
+			generated.PublishedDataFulltextInquiryServiceParamsFormat(format),
+			number)
+	})
+}
+
+// GetConstituents retrieves several constituents of a patent in one request and parses
+// each of them.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - number: Patent number (e.g., "EP.1000000.B1")
+//   - constituents: Any of ConstituentBiblio, ConstituentAbstract and ConstituentFullCycle
+//
+// Returns the requested sections; the others are nil. For raw XML, use GetConstituentsRaw().
+//
+// Example:
+//
+//	data, err := client.GetConstituents(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.1000000.B1",
+//	    ops.ConstituentBiblio, ops.ConstituentAbstract)
+//	fmt.Println(data.Biblio.Titles["en"], data.Abstract.Text)
+func (c *Client) GetConstituents(ctx context.Context, refType, format, number string, constituents ...string) (*ConstituentsData, error) {
+	xmlData, err := c.GetConstituentsRaw(ctx, refType, format, number, constituents...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConstituents(xmlData, constituents...)
+}
+
+// GetConstituentsRaw retrieves several constituents of a patent in one request as raw XML,
+// e.g. ".../publication/docdb/EP.1000000.B1/biblio,abstract". Constituents are requested in
+// the given order; duplicates are rejected. For parsed data, use GetConstituents() instead.
+func (c *Client) GetConstituentsRaw(ctx context.Context, refType, format, number string, constituents ...string) (string, error) {
+	ctx = withRequestID(ctx)
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return "", err
+	}
+	if len(constituents) == 0 {
+		return "", &ValidationError{Field: "constituents", Message: "at least one constituent is required"}
+	}
+	for i, constituent := range constituents {
+		switch constituent {
+		case ConstituentBiblio, ConstituentAbstract, ConstituentFullCycle:
+		default:
+			return "", invalidConstituentError(constituent)
+		}
+		if slices.Contains(constituents[:i], constituent) {
+			return "", &ValidationError{Field: "constituents", Value: constituent, Message: "constituent requested twice"}
+		}
+	}
+	joined := strings.Join(constituents, ",")
+
+	base, err := url.Parse(c.config.BaseURL)
+	if err != nil {
+		return "", &ConfigError{Message: fmt.Sprintf("invalid BaseURL: %v", err)}
+	}
+	requestURL := *base
+	requestURL.Path = strings.TrimSuffix(base.Path, "/") + "/published-data/" + refType + "/" + format + "/" + number + "/" + joined
+	requestURL.RawPath = ""
+
+	return c.makeCachedRequest(ctx, cacheKey("GetConstituentsRaw", refType, format, number, joined), func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.httpClient.Do(req)
+	})
+}
+
+// GetFullCycle retrieves and parses the publication history (full cycle) of a patent.
+//
+// Parameters:
+//   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
+//   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
+//   - number: Patent number (e.g., "EP.2400812.A1")
+//
+// Returns one stage per publication (e.g., A1, B1) with its date and bibliographic data,
+// ordered by publication date. For raw XML, use GetFullCycleRaw().
+//
+// Example:
+//
+//	cycle, err := client.GetFullCycle(ctx, ops.RefTypePublication, ops.FormatDocDB, "EP.2400812.A1")
+//	for _, stage := range cycle.Stages {
+//	    fmt.Println(stage.Date, stage.DocumentID)
+//	}
+func (c *Client) GetFullCycle(ctx context.Context, refType, format, number string) (*FullCycleData, error) {
+	xmlData, err := c.GetFullCycleRaw(ctx, refType, format, number)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFullCycle(xmlData)
+}
+
+// GetFullCycleRaw retrieves the publication history (full cycle) of a patent as raw XML.
+// For parsed data, use GetFullCycle() instead.
+func (c *Client) GetFullCycleRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+	if err := ValidateRefType(refType); err != nil {
+		return "", err
+	}
+	if err := ValidateFormat(format, number); err != nil {
+		return "", err
+	}
+	return c.makeCachedRequest(ctx, cacheKey("GetFullCycleRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
+		return c.generated.PublishedDataFullCycleService(ctx,
+			generated.PublishedDataFullCycleServiceParamsType(refType),
+			generated.PublishedDataFullCycleServiceParamsFormat(format),
+			number)
+	})
+}
+
 // GetFullCycleMultiple retrieves full cycle data for multiple patents (bulk operation).
 // Uses POST endpoint for efficient batch retrieval of up to 100 patents in one request.
 //
@@ -213,9 +667,14 @@ func (c *Client) GetFulltextRaw(ctx context.Context, refType, format, number str
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing full cycle data for all requested patents.
-func (c *Client) GetFullCycleMultiple(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetFullCycleMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -259,6 +718,7 @@ func (c *Client) GetFullCycleMultiple(ctx context.Context, refType, format strin
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML string containing bibliographic data for all requested patents.
 //
@@ -267,7 +727,11 @@ func (c *Client) GetFullCycleMultiple(ctx context.Context, refType, format strin
 // while others return XML (GetBiblioMultiple, GetClaimsMultiple, GetAbstractMultiple).
 // This inconsistency is technical debt to be addressed in a future major version.
 // For now, callers can parse the XML using ParseBiblio() if needed.
-func (c *Client) GetBiblioMultiple(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetBiblioMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -293,11 +757,16 @@ func (c *Client) GetBiblioMultiple(ctx context.Context, refType, format string,
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML containing claims for all requested patents.
 //
 // Note: Returns raw XML. See GetBiblioMultiple() documentation for notes on return type inconsistency.
-func (c *Client) GetClaimsMultiple(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetClaimsMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -322,9 +791,14 @@ func (c *Client) GetClaimsMultiple(ctx context.Context, refType, format string,
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns parsed description data including paragraphs for all requested patents.
-func (c *Client) GetDescriptionMultiple(ctx context.Context, refType, format string, numbers []string) (*DescriptionData, error) {
+func (c *Client) GetDescriptionMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (*DescriptionData, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return nil, err
 	}
@@ -354,11 +828,16 @@ func (c *Client) GetDescriptionMultiple(ctx context.Context, refType, format str
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns XML string containing abstracts for all requested patents.
 //
 // Note: Returns raw XML. See GetBiblioMultiple() documentation for notes on return type inconsistency.
-func (c *Client) GetAbstractMultiple(ctx context.Context, refType, format string, numbers []string) (string, error) {
+func (c *Client) GetAbstractMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
 	}
@@ -383,9 +862,14 @@ func (c *Client) GetAbstractMultiple(ctx context.Context, refType, format string
 //   - refType: Reference type (e.g., RefTypePublication, RefTypeApplication, RefTypePriority)
 //   - format: Number format (e.g., FormatDocDB, FormatEPODOC)
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns parsed fulltext data including biblio, abstract, description, and claims for all requested patents.
-func (c *Client) GetFulltextMultiple(ctx context.Context, refType, format string, numbers []string) (*FulltextData, error) {
+func (c *Client) GetFulltextMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (*FulltextData, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return nil, err
 	}
@@ -412,7 +896,8 @@ func (c *Client) GetFulltextMultiple(ctx context.Context, refType, format string
 //
 // This returns the "simple family" - equivalent publications of the same invention
 // (same priority claim). This is different from the INPADOC family which includes
-// extended family members.
+// extended family members. GetFamilyByType with FamilySimple returns the same
+// publications as FamilyData.
 //
 // Parameters:
 //   - refType: Reference type (RefTypePublication, RefTypeApplication, or RefTypePriority)
@@ -434,7 +919,10 @@ func (c *Client) GetPublishedEquivalents(ctx context.Context, refType, format, n
 
 // GetPublishedEquivalentsRaw retrieves equivalent publications as raw XML.
 // For parsed data, use GetPublishedEquivalents() instead.
-func (c *Client) GetPublishedEquivalentsRaw(ctx context.Context, refType, format, number string) (string, error) {
+func (c *Client) GetPublishedEquivalentsRaw(ctx context.Context, refType, format, number string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
 	// Validate reference type
 	if err := ValidateRefType(refType); err != nil {
 		return "", err
@@ -445,7 +933,7 @@ func (c *Client) GetPublishedEquivalentsRaw(ctx context.Context, refType, format
 		return "", err
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	return c.makeCachedRequest(ctx, cacheKey("GetPublishedEquivalentsRaw", refType, format, number), func(ctx context.Context) (*http.Response, error) {
 		return c.generated.PublishedEquivalentsRetrievalService(ctx,
 			generated.PublishedEquivalentsRetrievalServiceParamsType(refType),
 			generated.PublishedEquivalentsRetrievalServiceParamsFormat(format),
@@ -462,9 +950,14 @@ func (c *Client) GetPublishedEquivalentsRaw(ctx context.Context, refType, format
 //   - refType: Reference type (RefTypePublication, RefTypeApplication, or RefTypePriority)
 //   - format: Number format ("epodoc" or "docdb")
 //   - numbers: Slice of patent numbers (max 100)
+//   - opts: Optional per-call settings (e.g., WithRequestTimeout, WithAcceptOverride)
 //
 // Returns parsed equivalents data for all requested patents.
-func (c *Client) GetPublishedEquivalentsMultiple(ctx context.Context, refType, format string, numbers []string) (*EquivalentsData, error) {
+func (c *Client) GetPublishedEquivalentsMultiple(ctx context.Context, refType, format string, numbers []string, opts ...RequestOption) (*EquivalentsData, error) {
+	ctx = withRequestID(ctx)
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
 	if err := ValidateRefType(refType); err != nil {
 		return nil, err
 	}
@@ -2,7 +2,11 @@ package epo_ops
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/patent-dev/epo-ops/cql"
 	"github.com/patent-dev/epo-ops/generated"
@@ -15,10 +19,20 @@ import (
 //
 // Parameters:
 //   - query: CQL query string (e.g., "ti=plastic", "pa=Siemens and de")
-//   - rangeStr: Optional range in format "1-25" (default: "1-25")
+//   - rangeStr: Optional range in format "1-25" (default: "1-25", at most 100 results)
+//   - opts: Optional per-call settings, e.g. WithRange to pass a SearchRange instead of rangeStr
+//     or WithSort to order the results
 //
 // Returns the search results as XML containing matching patents.
 //
+// Queries using fields of the register search only (e.g. "re") are rejected
+// before the request; see cql.ValidateForEndpoint. So are queries longer than
+// cql.MaxQueryLength, with a ValidationError.
+//
+// Invalid ranges (begin < 1, inverted, or spanning more than MaxSearchRangeSize results)
+// are rejected with a ValidationError before any request is sent. Queries EPO cannot
+// parse fail with a CQLError carrying the reported position.
+//
 // Example queries:
 //   - "ti=plastic" - Title contains "plastic"
 //   - "pa=Siemens" - Applicant is Siemens
@@ -26,8 +40,8 @@ import (
 //   - "ti=plastic and pa=Siemens" - Combined search
 //
 // See OPS documentation for full CQL syntax.
-func (c *Client) Search(ctx context.Context, query string, rangeStr string) (*SearchResultData, error) {
-	xmlData, err := c.SearchRaw(ctx, query, rangeStr)
+func (c *Client) Search(ctx context.Context, query string, rangeStr string, opts ...RequestOption) (*SearchResultData, error) {
+	xmlData, err := c.SearchRaw(ctx, query, rangeStr, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -36,28 +50,189 @@ func (c *Client) Search(ctx context.Context, query string, rangeStr string) (*Se
 
 // SearchRaw performs a bibliographic search and returns raw XML.
 // For parsed data, use Search() instead.
-func (c *Client) SearchRaw(ctx context.Context, query string, rangeStr string) (string, error) {
-	// Validate CQL query
-	cqlQuery, err := cql.ParseCQL(query)
-	if err != nil {
+func (c *Client) SearchRaw(ctx context.Context, query string, rangeStr string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	if err := validateQueryLength(query); err != nil {
 		return "", err
 	}
-	if err := cqlQuery.Validate(); err != nil {
+	// Validate CQL query against the published-data search fields
+	if err := cql.ValidateForEndpoint(query, cql.EndpointSearch); err != nil {
 		return "", err
 	}
 
-	if rangeStr == "" {
-		rangeStr = "1-25"
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	rangeParam, err := searchRangeParam(ctx, rangeStr)
+	if err != nil {
+		return "", err
+	}
+	queryParam, err := searchQueryParam(ctx, query)
+	if err != nil {
+		return "", err
 	}
 
 	params := &generated.PublishedDataKeywordsSearchWithoutConsituentsParams{
-		Q:     query,
-		Range: &rangeStr,
+		Q:     queryParam,
+		Range: &rangeParam,
 	}
 
-	return c.makeRequest(ctx, func() (*http.Response, error) {
+	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
 		return c.generated.PublishedDataKeywordsSearchWithoutConsituents(ctx, params)
 	})
+	return xmlData, withCQLQuery(err, queryParam)
+}
+
+// SearchAll performs a bibliographic search and collects the results of all pages.
+//
+// Parameters:
+//   - query: CQL query string (e.g., "ti=plastic", "pa=Siemens and de")
+//   - maxResults: Maximum number of results to return (0 or less for all, capped at MaxSearchResults)
+//   - opts: Optional per-call settings; any WithRange option is replaced by the page ranges,
+//     a WithSort option orders the results across all pages
+//
+// Pages of MaxSearchRangeSize results are requested until maxResults results, the total
+// result count or MaxSearchResults is reached. A query without matches returns an
+// empty slice and a nil error, whether EPO answers with a zero total-result-count
+// or with a 404 "no results found".
+func (c *Client) SearchAll(ctx context.Context, query string, maxResults int, opts ...RequestOption) ([]SearchResult, error) {
+	ctx = withRequestID(ctx)
+	return searchAllPages(maxResults, MaxSearchResults, func(r SearchRange) ([]SearchResult, int, error) {
+		page, err := c.Search(ctx, query, "", append(slices.Clip(opts), WithRange(r))...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Results, page.TotalCount, nil
+	})
+}
+
+// SearchByNumbers searches for a list of publication numbers of possibly different formats
+// and countries, e.g. to look up 500 numbers from a spreadsheet.
+//
+// The numbers are OR-joined into CQL queries ("pn=EP1000000 OR pn=US5551212A ..."),
+// split so that no query exceeds cql.MaxQueryLength. Each query is paged like in
+// SearchAll, and the results of all queries are merged in order, without duplicates.
+// TotalCount, RangeBegin and RangeEnd describe the merged results; Query is the
+// complete OR-joined query.
+//
+// Numbers may be given in epodoc (EP1000000, EP1000000B1) or docdb (EP.1000000.B1)
+// format. Blank entries are skipped; numbers with other characters than letters,
+// digits and dots are rejected with a ValidationError before any request is sent.
+//
+// Unlike the POST bulk methods (GetBiblioMultiple etc.), which take up to 100 numbers
+// of one format, this uses the search service and returns search results; a number
+// without kind code matches all its publications.
+func (c *Client) SearchByNumbers(ctx context.Context, numbers []string, opts ...RequestOption) (*SearchResultData, error) {
+	ctx = withRequestID(ctx)
+	queries, err := numberQueries(numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &SearchResultData{Query: strings.Join(queries, " OR "), Results: []SearchResult{}}
+	seen := make(map[string]bool)
+	for _, query := range queries {
+		results, err := c.SearchAll(ctx, query, 0, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			key := result.Country + result.DocNumber + result.Kind
+			if !seen[key] {
+				seen[key] = true
+				merged.Results = append(merged.Results, result)
+			}
+		}
+	}
+
+	merged.TotalCount = len(merged.Results)
+	if merged.TotalCount > 0 {
+		merged.RangeBegin, merged.RangeEnd = 1, merged.TotalCount
+	}
+	return merged, nil
+}
+
+// searchNumberPattern matches the publication numbers accepted by SearchByNumbers.
+var searchNumberPattern = regexp.MustCompile(`^[A-Za-z0-9.]+$`)
+
+// numberQueries builds "pn=X OR pn=Y" queries for SearchByNumbers, each at most
+// cql.MaxQueryLength characters long.
+func numberQueries(numbers []string) ([]string, error) {
+	var queries []string
+	var query strings.Builder
+	seen := make(map[string]bool)
+	for _, number := range numbers {
+		number = strings.TrimSpace(number)
+		if number == "" || seen[number] {
+			continue
+		}
+		if !searchNumberPattern.MatchString(number) {
+			return nil, &ValidationError{
+				Field:   "numbers",
+				Value:   number,
+				Message: "must contain letters, digits and dots only (e.g., EP1000000B1 or EP.1000000.B1)",
+			}
+		}
+		seen[number] = true
+
+		term := "pn=" + number
+		if query.Len() > 0 && query.Len()+len(" OR ")+len(term) > cql.MaxQueryLength {
+			queries = append(queries, query.String())
+			query.Reset()
+		}
+		if query.Len() > 0 {
+			query.WriteString(" OR ")
+		}
+		query.WriteString(term)
+	}
+	if query.Len() > 0 {
+		queries = append(queries, query.String())
+	}
+
+	if len(queries) == 0 {
+		return nil, &ValidationError{
+			Field:   "numbers",
+			Message: "at least one publication number is required",
+		}
+	}
+	return queries, nil
+}
+
+// searchAllPages collects search results page by page. fetch requests one page and
+// returns its results and the total result count; pages span MaxSearchRangeSize
+// results until maxResults results (all if maxResults <= 0), the total count or ceiling
+// is reached.
+func searchAllPages[T any](maxResults, ceiling int, fetch func(SearchRange) ([]T, int, error)) ([]T, error) {
+	limit := ceiling
+	if maxResults > 0 {
+		limit = min(limit, maxResults)
+	}
+
+	all := []T{}
+	for begin := 1; begin <= limit; {
+		end := min(begin+MaxSearchRangeSize-1, limit)
+		results, total, err := fetch(SearchRange{Begin: begin, End: end})
+		if err != nil {
+			// EPO may answer a search without matches with 404 instead of a zero count
+			var notFound *NotFoundError
+			if begin == 1 && errors.As(err, &notFound) {
+				return all, nil
+			}
+			return nil, err
+		}
+		// An empty page ends the search, also when the count promised more results
+		if len(results) == 0 {
+			break
+		}
+
+		all = append(all, results...)
+		if total > 0 {
+			limit = min(limit, total)
+		}
+		begin = end + 1
+	}
+
+	return all, nil
 }
 
 // SearchWithConstituent performs a bibliographic search with specific constituent.
@@ -65,26 +240,78 @@ func (c *Client) SearchRaw(ctx context.Context, query string, rangeStr string) (
 // Parameters:
 //   - constituent: The constituent to retrieve (e.g., "biblio", "abstract", "full-cycle")
 //   - query: CQL query string
-//   - rangeStr: Optional range in format "1-25"
+//   - rangeStr: Optional range in format "1-25" (default: "1-25", at most 100 results)
+//   - opts: Optional per-call settings, e.g. WithRange
 //
 // Returns parsed search results with the requested constituent data.
-func (c *Client) SearchWithConstituent(ctx context.Context, constituent, query string, rangeStr string) (*SearchResultData, error) {
-	// Validate CQL query
-	cqlQuery, err := cql.ParseCQL(query)
+// Only the result identifiers and titles are kept; for the full bibliographic
+// data per result, use SearchWithConstituentParsed() with the biblio constituent,
+// and for the publication stages per result, ParseSearchFullCycle() on the output
+// of SearchWithConstituentRaw() with the full-cycle constituent.
+func (c *Client) SearchWithConstituent(ctx context.Context, constituent, query string, rangeStr string, opts ...RequestOption) (*SearchResultData, error) {
+	xmlData, err := c.SearchWithConstituentRaw(ctx, constituent, query, rangeStr, opts...)
 	if err != nil {
 		return nil, err
 	}
-	if err := cqlQuery.Validate(); err != nil {
+	return ParseSearch(xmlData)
+}
+
+// SearchWithConstituentParsed performs a bibliographic search and returns each result
+// with its parsed bibliographic data.
+//
+// Parameters:
+//   - constituent: The constituent to retrieve; must include "biblio" (e.g., "biblio", "biblio,abstract")
+//   - query: CQL query string
+//   - rangeStr: Optional range in format "1-25" (default: "1-25", at most 100 results)
+//   - opts: Optional per-call settings, e.g. WithRange
+//
+// Returns search results where each result carries its BiblioData (titles, applicants,
+// inventors, IPC and CPC classifications, publication date), replacing one GetBiblio
+// call per result.
+//
+// Example:
+//
+//	results, err := client.SearchWithConstituentParsed(ctx, "biblio", "ti=battery", "1-10")
+//	for _, result := range results.Results {
+//	    if result.Biblio != nil {
+//	        fmt.Println(result.Country, result.DocNumber, result.Biblio.Applicants)
+//	    }
+//	}
+func (c *Client) SearchWithConstituentParsed(ctx context.Context, constituent, query string, rangeStr string, opts ...RequestOption) (*SearchBiblioData, error) {
+	xmlData, err := c.SearchWithConstituentRaw(ctx, constituent, query, rangeStr, opts...)
+	if err != nil {
 		return nil, err
 	}
+	return ParseSearchBiblio(xmlData)
+}
 
-	if rangeStr == "" {
-		rangeStr = "1-25"
+// SearchWithConstituentRaw performs a bibliographic search with specific constituent and returns raw XML.
+// For parsed data, use SearchWithConstituent() or SearchWithConstituentParsed() instead.
+func (c *Client) SearchWithConstituentRaw(ctx context.Context, constituent, query string, rangeStr string, opts ...RequestOption) (string, error) {
+	ctx = withRequestID(ctx)
+	if err := validateQueryLength(query); err != nil {
+		return "", err
+	}
+	// Validate CQL query against the published-data search fields
+	if err := cql.ValidateForEndpoint(query, cql.EndpointSearch); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := applyRequestOptions(ctx, opts)
+	defer cancel()
+
+	rangeParam, err := searchRangeParam(ctx, rangeStr)
+	if err != nil {
+		return "", err
+	}
+	queryParam, err := searchQueryParam(ctx, query)
+	if err != nil {
+		return "", err
 	}
 
 	params := &generated.PublishedDataKeywordsSearchWithVariableConstituentsParams{
-		Q:     query,
-		Range: &rangeStr,
+		Q:     queryParam,
+		Range: &rangeParam,
 	}
 
 	xmlData, err := c.makeRequest(ctx, func() (*http.Response, error) {
@@ -92,8 +319,5 @@ func (c *Client) SearchWithConstituent(ctx context.Context, constituent, query s
 			generated.PublishedDataKeywordsSearchWithVariableConstituentsParamsConstituent(constituent),
 			params)
 	})
-	if err != nil {
-		return nil, err
-	}
-	return ParseSearch(xmlData)
+	return xmlData, withCQLQuery(err, queryParam)
 }
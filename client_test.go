@@ -1,16 +1,24 @@
 package epo_ops
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/patent-dev/epo-ops/cql"
 )
 
 //go:embed testdata/*.xml
@@ -165,6 +173,64 @@ func TestGetBiblio(t *testing.T) {
 	}
 }
 
+func TestGetBiblioFor(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var gotPaths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/biblio"):
+			_, _ = w.Write(loadTestData("biblio.xml"))
+		case strings.HasSuffix(r.URL.Path, "/claims"):
+			_, _ = w.Write(loadTestData("claims.xml"))
+		default:
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	// With a kind code the number is sent in docdb format
+	biblio, err := client.GetBiblioFor(ctx, RefTypePublication, ParsePatentNumber("EP2400812A1"))
+	if err != nil {
+		t.Fatalf("GetBiblioFor failed: %v", err)
+	}
+	if biblio.PatentNumber != "EP2400812A1" {
+		t.Errorf("PatentNumber: got %q, want %q", biblio.PatentNumber, "EP2400812A1")
+	}
+
+	// Without a kind code it is sent in epodoc format
+	if _, err := client.GetClaimsFor(ctx, RefTypePublication, PatentNumber{Country: "EP", Number: "2400812"}); err != nil {
+		t.Fatalf("GetClaimsFor failed: %v", err)
+	}
+
+	want := []string{
+		"/published-data/publication/docdb/EP.2400812.A1/biblio",
+		"/published-data/publication/epodoc/EP2400812/claims",
+	}
+	if !reflect.DeepEqual(gotPaths, want) {
+		t.Errorf("Request paths:\ngot  %v\nwant %v", gotPaths, want)
+	}
+
+	// An unparseable number fails before any request
+	var validationErr *ValidationError
+	if _, err := client.GetBiblioFor(ctx, RefTypePublication, ParsePatentNumber("DE123")); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError for an empty PatentNumber, got %v", err)
+	}
+	if len(gotPaths) != 2 {
+		t.Errorf("Expected no request for an invalid number, got %d requests", len(gotPaths))
+	}
+}
+
 func TestGetClaims(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
@@ -208,6 +274,51 @@ func TestGetClaims(t *testing.T) {
 	}
 }
 
+func TestGetClaimsByLanguage(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("claims_multilang.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	claims, err := client.GetClaimsByLanguage(ctx, "publication", "docdb", "EP.1000000.B1", "fr")
+	if err != nil {
+		t.Fatalf("GetClaimsByLanguage failed: %v", err)
+	}
+	if claims.Language != "FR" {
+		t.Errorf("Expected language FR, got %q", claims.Language)
+	}
+	if len(claims.Claims) != 3 || !strings.HasPrefix(claims.Claims[0].Text, "1. Dispositif") {
+		t.Errorf("Expected 3 French claims, got %+v", claims.Claims)
+	}
+
+	// Missing languages produce a NotFoundError listing the available ones
+	_, err = client.GetClaimsByLanguage(ctx, "publication", "docdb", "EP.1000000.B1", "es")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected NotFoundError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "DE, EN, FR") {
+		t.Errorf("Expected available languages in error, got: %v", err)
+	}
+}
+
 func TestGetDescription(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
@@ -250,6 +361,56 @@ func TestGetDescription(t *testing.T) {
 	}
 }
 
+func TestGetDescriptionStream(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/description") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("description.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	var nums []string
+	err = client.GetDescriptionStream(ctx, "publication", "docdb", "EP.1000000.B1", func(p Paragraph) error {
+		nums = append(nums, p.Num)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GetDescriptionStream failed: %v", err)
+	}
+	if strings.Join(nums, ",") != "0001,0002,0003,0004" {
+		t.Errorf("Unexpected paragraphs: %v", nums)
+	}
+
+	// Validation errors are returned before any request is made
+	err = client.GetDescriptionStream(ctx, "invalid", "docdb", "EP.1000000.B1", func(p Paragraph) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected validation error for invalid reference type")
+	}
+}
+
 func TestGetAbstract(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
@@ -344,20 +505,19 @@ func TestSearch(t *testing.T) {
 	}
 }
 
-// Test family endpoints
-func TestGetFamily(t *testing.T) {
+func TestSearchWithConstituentParsed(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
 
 	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.URL.Path, "/family") {
+		if !strings.HasSuffix(r.URL.Path, "/published-data/search/biblio") {
 			t.Errorf("Unexpected path: %s", r.URL.Path)
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/xml")
-		_, _ = w.Write(loadTestData("family.xml"))
+		_, _ = w.Write(loadTestData("search_biblio.xml"))
 	})
 	defer opsServer.Close()
 
@@ -374,37 +534,30 @@ func TestGetFamily(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	family, err := client.GetFamily(ctx, "publication", "docdb", "EP.1000000.B1")
+	results, err := client.SearchWithConstituentParsed(ctx, "biblio", "ti=battery", "1-2")
 	if err != nil {
-		t.Fatalf("GetFamily failed: %v", err)
+		t.Fatalf("SearchWithConstituentParsed failed: %v", err)
 	}
-
-	if family == nil {
-		t.Fatal("Expected parsed family data, got nil")
+	if len(results.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results.Results))
 	}
-	if len(family.Members) == 0 {
-		t.Errorf("Expected family members in family data")
+	for _, result := range results.Results {
+		if result.Biblio == nil || len(result.Biblio.Applicants) == 0 {
+			t.Errorf("Expected applicants for %s%s", result.Country, result.DocNumber)
+		}
+	}
+	if results.Results[0].Biblio.Applicants[0].Name == results.Results[1].Biblio.Applicants[0].Name {
+		t.Error("Expected distinct applicants per result")
 	}
-	// FamilyID is optional in the API response, so we don't assert on it
 }
 
-// Test image endpoints
-func TestGetImage(t *testing.T) {
+func TestSearchRejectsRegisterFields(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
 
-	// Mock TIFF header (little-endian)
-	mockTIFF := []byte{0x49, 0x49, 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00}
-
 	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.URL.Path, "/images/") {
-			t.Errorf("Unexpected path: %s", r.URL.Path)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
-
-		w.Header().Set("Content-Type", "image/tiff")
-		_, _ = w.Write(mockTIFF)
+		t.Errorf("Unexpected request: %s", r.URL)
+		w.WriteHeader(http.StatusBadRequest)
 	})
 	defer opsServer.Close()
 
@@ -420,36 +573,250 @@ func TestGetImage(t *testing.T) {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 
+	// "re" (representative) is a register search field only
+	_, err = client.Search(context.Background(), "ti=battery and re=smith", "1-5")
+	if err == nil || !strings.Contains(err.Error(), "not valid for published-data search") {
+		t.Errorf("Expected published-data field error, got %v", err)
+	}
+}
+
+func TestSearchRegisterRejectsPublishedDataFields(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request: %s", r.URL)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
 	ctx := context.Background()
-	imageData, err := client.GetImage(ctx, "EP", "1000000", "B1", "Drawing", 1)
+
+	// "ab" (abstract) is a published-data search field only
+	_, err = client.SearchRegister(ctx, "ti=battery and ab=lithium", "1-5")
+	if err == nil || !strings.Contains(err.Error(), "not valid for register search") {
+		t.Errorf("SearchRegister: expected register field error, got %v", err)
+	}
+	_, err = client.SearchRegisterWithConstituent(ctx, "events", "ab=lithium", "1-5")
+	if err == nil || !strings.Contains(err.Error(), "not valid for register search") {
+		t.Errorf("SearchRegisterWithConstituent: expected register field error, got %v", err)
+	}
+	if _, err := client.SearchRegister(ctx, "ti=(battery", ""); err == nil {
+		t.Error("SearchRegister: expected error for malformed CQL")
+	}
+}
+
+func TestNewTestClient(t *testing.T) {
+	// One server serves the token endpoint and the API
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/accesstoken" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"test_token_12345","expires_in":"3600"}`))
+			return
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test_token_12345" {
+			t.Errorf("Authorization header: got %q", auth)
+		}
+		// The first attempt fails to exercise the shortened retry delay
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	}))
+	defer server.Close()
+
+	client, err := NewTestClient(server.URL, "")
 	if err != nil {
-		t.Fatalf("GetImage failed: %v", err)
+		t.Fatalf("NewTestClient failed: %v", err)
 	}
 
-	if len(imageData) == 0 {
-		t.Error("Expected image data, got empty response")
+	start := time.Now()
+	if _, err := client.GetBiblio(context.Background(), "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("GetBiblio failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Retry took %v, expected a short test delay", elapsed)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("API requests: got %d, want 2", got)
 	}
 
-	// Check TIFF header
-	if len(imageData) >= 4 && (imageData[0] != 'I' || imageData[1] != 'I') {
-		t.Errorf("Expected TIFF header, got: %v", imageData[:4])
+	// A separate auth server
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+	client, err = NewTestClient(server.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+	if err := client.VerifyCredentials(context.Background()); err != nil {
+		t.Errorf("VerifyCredentials failed: %v", err)
+	}
+
+	var configErr *ConfigError
+	if _, err := NewTestClient("", ""); !errors.As(err, &configErr) {
+		t.Errorf("Expected ConfigError for empty base URL, got %v", err)
 	}
 }
 
-// Test legal and register endpoints
-func TestGetLegal(t *testing.T) {
+func TestClientStats(t *testing.T) {
+	biblio := loadTestData("biblio.xml")
+	notFound := []byte(`<error><code>SERVER.EntityNotFound</code><message>No results found</message></error>`)
+
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	server := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.URL.Path]++
+		attempt := attempts[r.URL.Path]
+		mu.Unlock()
+
+		switch {
+		case strings.Contains(r.URL.Path, "EP.2000000.B1"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(notFound)
+		case strings.Contains(r.URL.Path, "EP.3000000.B1") && attempt == 1:
+			// Retried without reading the body
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write(biblio)
+		}
+	})
+	defer server.Close()
+
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	client, err := NewTestClient(server.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if stats := client.Stats(); stats != (ClientStats{}) {
+		t.Errorf("Expected zero stats for a new client, got %+v", stats)
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("GetBiblioRaw failed: %v", err)
+	}
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.2000000.B1"); err == nil {
+		t.Fatal("Expected NotFoundError, got nil")
+	}
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.3000000.B1"); err != nil {
+		t.Fatalf("GetBiblioRaw with retry failed: %v", err)
+	}
+
+	// Concurrent calls are counted too
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+				t.Errorf("GetBiblioRaw failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := ClientStats{
+		BytesDownloaded: int64(7*len(biblio) + len(notFound)),
+		RequestCount:    9,
+		RetryCount:      1,
+	}
+	if stats := client.Stats(); stats != want {
+		t.Errorf("Stats: got %+v, want %+v", stats, want)
+	}
+}
+
+func TestSearchRegisterAll(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
 
+	// The register search matches 130 applications
+	const total = 130
+	var gotRanges []string
 	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.URL.Path, "/legal") {
+		if !strings.Contains(r.URL.Path, "/register/search") {
 			t.Errorf("Unexpected path: %s", r.URL.Path)
-			w.WriteHeader(http.StatusNotFound)
-			return
 		}
+		rangeParam := r.URL.Query().Get("Range")
+		gotRanges = append(gotRanges, rangeParam)
+
+		var begin, end int
+		_, _ = fmt.Sscanf(rangeParam, "%d-%d", &begin, &end)
+		end = min(end, total)
+		var docs strings.Builder
+		for i := begin; i <= end; i++ {
+			fmt.Fprintf(&docs, `<reg:register-document><reg:bibliographic-data>`+
+				`<reg:publication-reference><reg:document-id><reg:country>EP</reg:country><reg:doc-number>%d</reg:doc-number></reg:document-id></reg:publication-reference>`+
+				`<reg:invention-title lang="en">Title %d</reg:invention-title>`+
+				`</reg:bibliographic-data></reg:register-document>`, 4000000+i, i)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w, `<ops:world-patent-data xmlns:ops="http://ops.epo.org" xmlns:reg="http://www.epo.org/register">`+
+			`<ops:register-search total-result-count="%d"><ops:range begin="%d" end="%d"/>`+
+			`<reg:register-documents>%s</reg:register-documents></ops:register-search></ops:world-patent-data>`,
+			total, begin, end, docs.String())
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	results, err := client.SearchRegisterAll(ctx, "ti=battery", 0)
+	if err != nil {
+		t.Fatalf("SearchRegisterAll failed: %v", err)
+	}
+	if len(results) != total {
+		t.Fatalf("Results: got %d, want %d", len(results), total)
+	}
+	// The results continue across the page boundary
+	for _, i := range []int{99, 100} {
+		if want := fmt.Sprint(4000001 + i); results[i].PublicationNumber != want || results[i].Title != fmt.Sprintf("Title %d", i+1) {
+			t.Errorf("Result %d: got %+v, want publication %s", i, results[i], want)
+		}
+	}
+	if want := []string{"1-100", "101-130"}; !reflect.DeepEqual(gotRanges, want) {
+		t.Errorf("Ranges: got %v, want %v", gotRanges, want)
+	}
+
+	gotRanges = nil
+	results, err = client.SearchRegisterAll(ctx, "ti=battery", 120)
+	if err != nil {
+		t.Fatalf("SearchRegisterAll failed: %v", err)
+	}
+	if len(results) != 120 {
+		t.Errorf("Results: got %d, want 120", len(results))
+	}
+	if want := []string{"1-100", "101-120"}; !reflect.DeepEqual(gotRanges, want) {
+		t.Errorf("Ranges: got %v, want %v", gotRanges, want)
+	}
 
+	if _, err := client.SearchRegisterAll(ctx, "", 0); err == nil {
+		t.Error("Expected error for empty query, got nil")
+	}
+}
+
+func TestSearchRangeOption(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var gotRanges []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotRanges = append(gotRanges, r.URL.Query().Get("Range"))
 		w.Header().Set("Content-Type", "application/xml")
-		_, _ = w.Write(loadTestData("legal.xml"))
+		_, _ = w.Write(loadTestData("search.xml"))
 	})
 	defer opsServer.Close()
 
@@ -466,29 +833,1505 @@ func TestGetLegal(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	legal, err := client.GetLegal(ctx, "publication", "docdb", "EP.1000000.B1")
+	r, err := NewSearchRange(26, 50)
 	if err != nil {
-		t.Fatalf("GetLegal failed: %v", err)
+		t.Fatalf("NewSearchRange failed: %v", err)
 	}
 
-	if legal == nil {
-		t.Fatal("Expected parsed legal data, got nil")
+	// WithRange overrides the range string; an empty string uses the default
+	if _, err := client.Search(ctx, "ti=battery", "1-5", WithRange(r)); err != nil {
+		t.Fatalf("Search failed: %v", err)
 	}
-	if len(legal.LegalEvents) == 0 {
-		t.Errorf("Expected legal events in legal data")
+	if _, err := client.SearchRegister(ctx, "ti=battery", ""); err != nil {
+		t.Fatalf("SearchRegister failed: %v", err)
+	}
+	if _, err := client.SearchRegisterWithConstituent(ctx, "events", "ti=battery", "", WithRange(r)); err != nil {
+		t.Fatalf("SearchRegisterWithConstituent failed: %v", err)
+	}
+	want := []string{"26-50", "1-25", "26-50"}
+	if !reflect.DeepEqual(gotRanges, want) {
+		t.Errorf("Range parameters: got %v, want %v", gotRanges, want)
+	}
+
+	// Invalid ranges are rejected before any request is sent
+	gotRanges = nil
+	invalid := []func() error{
+		func() error { _, err := client.Search(ctx, "ti=battery", "1-101"); return err },
+		func() error {
+			_, err := client.SearchWithConstituentRaw(ctx, "biblio", "ti=battery", "50-26")
+			return err
+		},
+		func() error { _, err := client.SearchRegister(ctx, "ti=battery", "0-10"); return err },
+		func() error {
+			_, err := client.SearchRaw(ctx, "ti=battery", "", WithRange(SearchRange{Begin: 1, End: 500}))
+			return err
+		},
+	}
+	for i, call := range invalid {
+		var validationErr *ValidationError
+		if err := call(); !errors.As(err, &validationErr) {
+			t.Errorf("Call %d: expected ValidationError, got %v", i, err)
+		}
+	}
+	if len(gotRanges) != 0 {
+		t.Errorf("Expected no requests for invalid ranges, got %d", len(gotRanges))
 	}
 }
 
-// Test error handling
-func TestErrorHandling(t *testing.T) {
+func TestSearchSortOption(t *testing.T) {
 	authServer := newMockAuthServer(t)
 	defer authServer.Close()
 
-	t.Run("404 Not Found", func(t *testing.T) {
-		opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusNotFound)
-			_, _ = w.Write(loadTestData("error_404.xml"))
-		})
+	var gotQueries []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotQueries = append(gotQueries, r.URL.Query().Get("q"))
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("search.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+
+	ctx := context.Background()
+	newest := WithSort(SearchSort{Field: "pd", Descending: true})
+	if _, err := client.Search(ctx, "ti=battery", "1-100", newest); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if _, err := client.SearchWithConstituentRaw(ctx, "biblio", "ti=battery", "", WithSort(SearchSort{Field: "pn"})); err != nil {
+		t.Fatalf("SearchWithConstituentRaw failed: %v", err)
+	}
+	if _, err := client.SearchAll(ctx, "ti=battery", 1, newest); err != nil {
+		t.Fatalf("SearchAll failed: %v", err)
+	}
+	if _, err := client.Search(ctx, "ti=battery", ""); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	want := []string{
+		"ti=battery sortby pd/sort.descending",
+		"ti=battery sortby pn/sort.ascending",
+		"ti=battery sortby pd/sort.descending",
+		"ti=battery",
+	}
+	if !reflect.DeepEqual(gotQueries, want) {
+		t.Errorf("q parameters:\ngot  %q\nwant %q", gotQueries, want)
+	}
+
+	// Fields the published-data search does not accept are rejected before the request
+	gotQueries = nil
+	for _, field := range []string{"", "xyz", "re"} {
+		var validationErr *ValidationError
+		_, err := client.Search(ctx, "ti=battery", "", WithSort(SearchSort{Field: field}))
+		if !errors.As(err, &validationErr) || validationErr.Field != "sort" {
+			t.Errorf("Sort field %q: expected ValidationError, got %v", field, err)
+		}
+	}
+	if len(gotQueries) != 0 {
+		t.Errorf("Expected no requests for invalid sort fields, got %d", len(gotQueries))
+	}
+
+	// The sortby clause counts towards the query length limit
+	query := "ti=" + strings.Repeat("a", cql.MaxQueryLength-len("ti="))
+	var validationErr *ValidationError
+	if _, err := client.Search(ctx, query, "", newest); !errors.As(err, &validationErr) || validationErr.Field != "query" {
+		t.Errorf("Expected query length ValidationError with sort, got %v", err)
+	}
+	if len(gotQueries) != 0 {
+		t.Errorf("Expected no request for a query too long with its sortby clause, got %d", len(gotQueries))
+	}
+}
+
+func TestSearchQueryLength(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var requests int
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("search.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+
+	ctx := context.Background()
+	tooLong := "pn=EP1000000" + strings.Repeat(" OR pn=EP1000000", 200)
+	calls := map[string]func(query string) error{
+		"Search": func(query string) error { _, err := client.Search(ctx, query, ""); return err },
+		"SearchWithConstituentRaw": func(query string) error {
+			_, err := client.SearchWithConstituentRaw(ctx, "biblio", query, "")
+			return err
+		},
+		"SearchRegister": func(query string) error { _, err := client.SearchRegister(ctx, query, ""); return err },
+		"SearchRegisterWithConstituent": func(query string) error {
+			_, err := client.SearchRegisterWithConstituent(ctx, "biblio", query, "")
+			return err
+		},
+	}
+	for name, call := range calls {
+		var validationErr *ValidationError
+		err := call(tooLong)
+		if !errors.As(err, &validationErr) || validationErr.Field != "query" {
+			t.Errorf("%s: expected ValidationError for query, got %v", name, err)
+			continue
+		}
+		if !strings.Contains(validationErr.Message, "2000") || len(validationErr.Value) > 60 {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests for over-length queries, got %d", requests)
+	}
+
+	// A query of exactly the limit is sent
+	atLimit := "ti=" + strings.Repeat("a", cql.MaxQueryLength-3)
+	if _, err := client.Search(ctx, atLimit, ""); err != nil {
+		t.Errorf("Search with a query of %d characters failed: %v", len(atLimit), err)
+	}
+}
+
+func TestSearchByNumbers(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// The mock matches each "pn=" term with one A1 publication and honors the range
+	var gotQueries []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		if !slices.Contains(gotQueries, query) {
+			gotQueries = append(gotQueries, query)
+		}
+		var docs []string
+		for _, term := range strings.Split(query, " OR ") {
+			docs = append(docs, strings.TrimSuffix(strings.TrimPrefix(term, "pn=EP"), "A1"))
+		}
+		var begin, end int
+		_, _ = fmt.Sscanf(r.URL.Query().Get("Range"), "%d-%d", &begin, &end)
+		end = min(end, len(docs))
+
+		var b strings.Builder
+		fmt.Fprintf(&b, `<ops:world-patent-data xmlns:ops="http://ops.epo.org" xmlns="http://www.epo.org/exchange">`+
+			`<ops:biblio-search total-result-count="%d"><ops:range begin="%d" end="%d"/><exchange-documents>`, len(docs), begin, end)
+		for _, doc := range docs[begin-1 : end] {
+			fmt.Fprintf(&b, `<exchange-document system="ops.epo.org" country="EP" doc-number="%s" kind="A1"/>`, doc)
+		}
+		b.WriteString(`</exchange-documents></ops:biblio-search></ops:world-patent-data>`)
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(b.String()))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+
+	// 300 numbers, a repeated and a blank one, and a number matching the first result again
+	var numbers []string
+	for i := range 300 {
+		numbers = append(numbers, fmt.Sprintf("EP%d", 1000000+i))
+	}
+	numbers = append(numbers, "EP1000000", " ", "EP1000000A1")
+
+	data, err := client.SearchByNumbers(context.Background(), numbers)
+	if err != nil {
+		t.Fatalf("SearchByNumbers failed: %v", err)
+	}
+
+	// "pn=EP1000000" takes 12 characters and each further " OR pn=EP1000001" 16,
+	// so 125 numbers fit into one query
+	if len(gotQueries) != 3 {
+		t.Fatalf("Queries: got %d, want 3", len(gotQueries))
+	}
+	for i, wantTerms := range []int{125, 125, 51} {
+		query := gotQueries[i]
+		if terms := strings.Count(query, "pn="); terms != wantTerms {
+			t.Errorf("Query %d: got %d terms, want %d", i+1, terms, wantTerms)
+		}
+		if len(query) > cql.MaxQueryLength {
+			t.Errorf("Query %d: %d characters exceed the limit", i+1, len(query))
+		}
+	}
+	if !strings.HasPrefix(gotQueries[0], "pn=EP1000000 OR pn=EP1000001 OR ") {
+		t.Errorf("First query: got %q", gotQueries[0][:40])
+	}
+	if !strings.HasSuffix(gotQueries[2], " OR pn=EP1000299 OR pn=EP1000000A1") {
+		t.Errorf("Last query: got %q", gotQueries[2][len(gotQueries[2])-40:])
+	}
+
+	if data.TotalCount != 300 || len(data.Results) != 300 || data.RangeBegin != 1 || data.RangeEnd != 300 {
+		t.Fatalf("Merged results: got count %d, %d results, range %d-%d", data.TotalCount, len(data.Results), data.RangeBegin, data.RangeEnd)
+	}
+	for i, result := range data.Results {
+		if want := fmt.Sprint(1000000 + i); result.DocNumber != want {
+			t.Fatalf("Result %d: got %s, want %s", i, result.DocNumber, want)
+		}
+	}
+	if data.Query != strings.Join(gotQueries, " OR ") {
+		t.Error("Query does not combine the sent queries")
+	}
+
+	// Invalid input fails before any request
+	gotQueries = nil
+	for _, invalid := range [][]string{nil, {" "}, {"EP1000000", "EP 1000001"}, {"pn=EP1000000"}} {
+		var validationErr *ValidationError
+		if _, err := client.SearchByNumbers(context.Background(), invalid); !errors.As(err, &validationErr) {
+			t.Errorf("SearchByNumbers(%q): expected ValidationError, got %v", invalid, err)
+		}
+	}
+	if len(gotQueries) != 0 {
+		t.Errorf("Expected no requests for invalid numbers, got %d", len(gotQueries))
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// "ti=battery" matches 230 documents, "ti=zzqxv" none and "ti=gone" gets a 404
+	const total = 230
+	var gotRanges []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		rangeParam := r.URL.Query().Get("Range")
+		gotRanges = append(gotRanges, rangeParam)
+		w.Header().Set("Content-Type", "application/xml")
+
+		switch r.URL.Query().Get("q") {
+		case "ti=zzqxv":
+			_, _ = w.Write(loadTestData("search_empty.xml"))
+			return
+		case "ti=gone":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<error><code>SERVER.EntityNotFound</code><message>No results found</message></error>`))
+			return
+		}
+
+		var begin, end int
+		_, _ = fmt.Sscanf(rangeParam, "%d-%d", &begin, &end)
+		end = min(end, total)
+		var docs strings.Builder
+		for i := begin; i <= end; i++ {
+			fmt.Fprintf(&docs, `<exchange-document country="EP" doc-number="%d" kind="A1"/>`, i)
+		}
+		fmt.Fprintf(w, `<ops:world-patent-data xmlns:ops="http://ops.epo.org" xmlns="http://www.epo.org/exchange">`+
+			`<ops:biblio-search total-result-count="%d"><ops:range begin="%d" end="%d"/>`+
+			`<exchange-documents>%s</exchange-documents></ops:biblio-search></ops:world-patent-data>`,
+			total, begin, end, docs.String())
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		query      string
+		max        int
+		wantCount  int
+		wantRanges []string
+	}{
+		{"All pages", "ti=battery", 0, total, []string{"1-100", "101-200", "201-230"}},
+		{"Limited", "ti=battery", 150, 150, []string{"1-100", "101-150"}},
+		{"No results", "ti=zzqxv", 0, 0, []string{"1-100"}},
+		{"Not found", "ti=gone", 0, 0, []string{"1-100"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRanges = nil
+			results, err := client.SearchAll(ctx, tt.query, tt.max)
+			if err != nil {
+				t.Fatalf("SearchAll failed: %v", err)
+			}
+			if results == nil {
+				t.Error("Expected empty slice, got nil")
+			}
+			if len(results) != tt.wantCount {
+				t.Errorf("Results: got %d, want %d", len(results), tt.wantCount)
+			}
+			if len(results) > 0 && results[len(results)-1].DocNumber != fmt.Sprint(tt.wantCount) {
+				t.Errorf("Last result: got %s, want %d", results[len(results)-1].DocNumber, tt.wantCount)
+			}
+			if !reflect.DeepEqual(gotRanges, tt.wantRanges) {
+				t.Errorf("Ranges: got %v, want %v", gotRanges, tt.wantRanges)
+			}
+		})
+	}
+}
+
+func TestGetFamilyWithLegalParsed(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/family/publication/docdb/EP.2400812.A1/legal") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("family_legal.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	family, err := client.GetFamilyWithLegalParsed(ctx, RefTypePublication, FormatDocDB, "EP.2400812.A1")
+	if err != nil {
+		t.Fatalf("GetFamilyWithLegalParsed failed: %v", err)
+	}
+	if len(family.Members) != 2 {
+		t.Fatalf("Expected 2 members, got %d", len(family.Members))
+	}
+	if len(family.Members[0].LegalEvents) != 3 || len(family.Members[1].LegalEvents) != 1 {
+		t.Errorf("Expected 3 and 1 legal events, got %d and %d",
+			len(family.Members[0].LegalEvents), len(family.Members[1].LegalEvents))
+	}
+}
+
+func TestGetFullCycle(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/published-data/publication/docdb/EP.2400812.A1/full-cycle") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("full_cycle.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	cycle, err := client.GetFullCycle(ctx, RefTypePublication, FormatDocDB, "EP.2400812.A1")
+	if err != nil {
+		t.Fatalf("GetFullCycle failed: %v", err)
+	}
+	if len(cycle.Stages) != 2 || cycle.Stages[0].Kind != "A1" || cycle.Stages[1].Kind != "B1" {
+		t.Errorf("Expected A1 -> B1 stages, got %+v", cycle.Stages)
+	}
+}
+
+func TestGetConstituents(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var paths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get("Accept") != "application/exchange+xml" {
+			t.Errorf("Expected exchange Accept header, got %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL + "/3.2/rest-services",
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	data, err := client.GetConstituents(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1",
+		ConstituentBiblio, ConstituentAbstract)
+	if err != nil {
+		t.Fatalf("GetConstituents failed: %v", err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/3.2/rest-services/published-data/publication/docdb/EP.1000000.B1/biblio,abstract" {
+		t.Errorf("Expected one biblio,abstract request, got %v", paths)
+	}
+	if data.Biblio == nil || data.Biblio.PatentNumber == "" {
+		t.Errorf("Expected parsed biblio, got %+v", data.Biblio)
+	}
+	if data.Abstract == nil || data.Abstract.Text == "" {
+		t.Errorf("Expected parsed abstract, got %+v", data.Abstract)
+	}
+	if data.FullCycle != nil {
+		t.Error("FullCycle was not requested and should be nil")
+	}
+
+	// Invalid constituent lists are rejected before any request
+	invalid := [][]string{
+		nil,
+		{ConstituentBiblio, "claims"},
+		{ConstituentBiblio, ConstituentBiblio},
+	}
+	for _, constituents := range invalid {
+		var valErr *ValidationError
+		if _, err := client.GetConstituentsRaw(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1", constituents...); !errors.As(err, &valErr) || valErr.Field != "constituents" {
+			t.Errorf("%v: expected constituents ValidationError, got %v", constituents, err)
+		}
+	}
+	if len(paths) != 1 {
+		t.Errorf("Expected no further requests, got %v", paths[1:])
+	}
+}
+
+// Test family endpoints
+func TestGetFamily(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/family") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("family.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	family, err := client.GetFamily(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetFamily failed: %v", err)
+	}
+
+	if family == nil {
+		t.Fatal("Expected parsed family data, got nil")
+	}
+	if len(family.Members) == 0 {
+		t.Errorf("Expected family members in family data")
+	}
+	// FamilyID is optional in the API response, so we don't assert on it
+}
+
+func TestGetFamilyForCountries(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("family.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// The family has EP, US and WO members
+	family, err := client.GetFamilyForCountries(ctx, RefTypePublication, FormatDocDB, "EP.2400812.A1", "us", " WO")
+	if err != nil {
+		t.Fatalf("GetFamilyForCountries failed: %v", err)
+	}
+	var countries []string
+	for _, member := range family.Members {
+		countries = append(countries, member.Country)
+	}
+	if !reflect.DeepEqual(countries, []string{"US", "WO"}) {
+		t.Errorf("Member countries: got %v, want [US WO]", countries)
+	}
+
+	if members := family.MembersForCountries("EP"); len(members) != 0 {
+		t.Errorf("Expected no EP members after filtering, got %d", len(members))
+	}
+	if members := family.MembersForCountries(); members != nil {
+		t.Errorf("Expected no members without countries, got %d", len(members))
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.GetFamilyForCountries(ctx, RefTypePublication, FormatDocDB, "EP.2400812.A1"); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError without countries, got %v", err)
+	}
+}
+
+func TestGetFamilyWithRepresentative(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var biblioPaths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if strings.HasSuffix(r.URL.Path, "/biblio") {
+			biblioPaths = append(biblioPaths, r.URL.Path)
+			_, _ = w.Write(loadTestData("biblio.xml"))
+			return
+		}
+		_, _ = w.Write(loadTestData("family_priorities.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// The US, EP and CN members share the earliest priority; the EP A1 was published first
+	family, biblio, err := client.GetFamilyWithRepresentative(context.Background(), RefTypePublication, FormatDocDB, "EP.2400812.B1")
+	if err != nil {
+		t.Fatalf("GetFamilyWithRepresentative failed: %v", err)
+	}
+	if len(family.Members) != 6 {
+		t.Errorf("Expected 6 family members, got %d", len(family.Members))
+	}
+	if len(biblioPaths) != 1 || !strings.HasSuffix(biblioPaths[0], "/publication/docdb/EP.2400812.A1/biblio") {
+		t.Errorf("Expected one biblio request for EP.2400812.A1, got %v", biblioPaths)
+	}
+	if biblio == nil || biblio.Country != "EP" || biblio.DocNumber != "2400812" {
+		t.Errorf("Unexpected representative biblio: %+v", biblio)
+	}
+}
+
+func TestGetFamilyByType(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var lastPath string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/equivalents"):
+			_, _ = w.Write(loadTestData("equivalents.xml"))
+		case strings.HasPrefix(r.URL.Path, "/family/"):
+			_, _ = w.Write(loadTestData("family.xml"))
+		default:
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	t.Run("Simple family", func(t *testing.T) {
+		family, err := client.GetFamilyByType(ctx, RefTypePublication, FormatEPODOC, "EP2400812", FamilySimple)
+		if err != nil {
+			t.Fatalf("GetFamilyByType failed: %v", err)
+		}
+		if lastPath != "/published-data/publication/epodoc/EP2400812/equivalents" {
+			t.Errorf("Expected equivalents request, got %s", lastPath)
+		}
+		if family.Type != FamilySimple || family.PatentNumber != "EP2400812" {
+			t.Errorf("Unexpected family: type %q, patent %q", family.Type, family.PatentNumber)
+		}
+		if len(family.Members) != 3 || family.TotalCount != 3 {
+			t.Fatalf("Expected 3 members, got %d (TotalCount %d)", len(family.Members), family.TotalCount)
+		}
+		member := family.Members[1]
+		if member.Country != "US" || member.DocNumber != "2012057518" {
+			t.Errorf("Member 2: got %s %s, want US 2012057518", member.Country, member.DocNumber)
+		}
+		if len(member.Publications) != 1 || member.Publications[0].Type != FormatEPODOC {
+			t.Errorf("Member 2: unexpected publications %+v", member.Publications)
+		}
+	})
+
+	t.Run("Extended family", func(t *testing.T) {
+		family, err := client.GetFamilyByType(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1", FamilyExtended)
+		if err != nil {
+			t.Fatalf("GetFamilyByType failed: %v", err)
+		}
+		if !strings.HasPrefix(lastPath, "/family/publication/docdb/") {
+			t.Errorf("Expected INPADOC family request, got %s", lastPath)
+		}
+		if family.Type != FamilyExtended || len(family.Members) == 0 {
+			t.Errorf("Expected extended family with members, got type %q and %d members", family.Type, len(family.Members))
+		}
+	})
+
+	t.Run("Invalid type", func(t *testing.T) {
+		lastPath = ""
+		_, err := client.GetFamilyByType(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1", "inpadoc")
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.Field != "familyType" {
+			t.Errorf("Expected familyType ValidationError, got: %v", err)
+		}
+		if lastPath != "" {
+			t.Errorf("Expected no request, got %s", lastPath)
+		}
+	})
+}
+
+// Test image endpoints
+func TestGetImage(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// Mock TIFF header (little-endian)
+	mockTIFF := []byte{0x49, 0x49, 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00}
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/images/") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/tiff")
+		_, _ = w.Write(mockTIFF)
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	imageData, err := client.GetImage(ctx, "EP", "1000000", "B1", "Drawing", 1)
+	if err != nil {
+		t.Fatalf("GetImage failed: %v", err)
+	}
+
+	if len(imageData) == 0 {
+		t.Error("Expected image data, got empty response")
+	}
+
+	// Check TIFF header
+	if len(imageData) >= 4 && (imageData[0] != 'I' || imageData[1] != 'I') {
+		t.Errorf("Expected TIFF header, got: %v", imageData[:4])
+	}
+}
+
+func TestGetFirstPageImage(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	mockTIFF := []byte{0x49, 0x49, 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00}
+	available := true
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// The PA kind is always requested, never the document's own kind code
+		if !strings.HasSuffix(r.URL.Path, "/images/EP/2400812/PA/firstpage") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !available {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(loadTestData("error_404.xml"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/tiff")
+		_, _ = w.Write(mockTIFF)
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	data, err := client.GetFirstPageImage(ctx, "EP", "2400812")
+	if err != nil {
+		t.Fatalf("GetFirstPageImage failed: %v", err)
+	}
+	if len(data) != len(mockTIFF) {
+		t.Errorf("Expected %d bytes, got %d", len(mockTIFF), len(data))
+	}
+
+	// Documents without a first-page clipping yield a descriptive NotFoundError
+	available = false
+	_, err = client.GetFirstPageImage(ctx, "EP", "2400812")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected NotFoundError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "first page clipping EP2400812") {
+		t.Errorf("Expected error to name the document, got: %v", err)
+	}
+}
+
+func TestGetImageThumbnail(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// Mock PNG signature followed by IHDR chunk start
+	mockPNG := []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A, 0x00, 0x00, 0x00, 0x0D, 'I', 'H', 'D', 'R'}
+	response := mockPNG
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/images/EP/2400812/A1/thumbnail") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("Range") != "1" {
+			t.Errorf("Expected Range=1, got: %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(response)
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	thumb, err := client.GetImageThumbnail(ctx, "EP", "2400812", "A1")
+	if err != nil {
+		t.Fatalf("GetImageThumbnail failed: %v", err)
+	}
+	if len(thumb) != len(mockPNG) {
+		t.Errorf("Expected %d bytes, got %d", len(mockPNG), len(thumb))
+	}
+
+	// Non-image responses are rejected
+	response = []byte(`<html>not an image</html>`)
+	_, err = client.GetImageThumbnail(ctx, "EP", "2400812", "A1")
+	var dataErr *DataValidationError
+	if !errors.As(err, &dataErr) {
+		t.Errorf("Expected DataValidationError for non-image response, got: %v", err)
+	}
+}
+
+func TestGetImageInquiry(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/published-data/publication/docdb/EP.1000000.B1/images") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("image-inquiry.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	inquiry, err := client.GetImageInquiry(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetImageInquiry failed: %v", err)
+	}
+
+	want := []struct {
+		docType string
+		pages   int
+	}{
+		{"Drawing", 8},
+		{"FullDocument", 15},
+		{"FirstPageClipping", 1},
+	}
+	if len(inquiry.DocumentInstances) != len(want) {
+		t.Fatalf("Expected %d document instances, got %d", len(want), len(inquiry.DocumentInstances))
+	}
+	for i, w := range want {
+		instance := inquiry.DocumentInstances[i]
+		if instance.DocType != w.docType {
+			t.Errorf("Instance %d: expected doc type %q, got %q", i, w.docType, instance.DocType)
+		}
+		if instance.NumberOfPages != w.pages {
+			t.Errorf("Instance %d: expected %d pages, got %d", i, w.pages, instance.NumberOfPages)
+		}
+	}
+
+	// Invalid parameters are rejected before any request is made
+	if _, err := client.GetImageInquiry(ctx, "invalid", FormatDocDB, "EP.1000000.B1"); err == nil {
+		t.Error("Expected error for invalid reference type")
+	}
+}
+
+func TestGetImageByLink(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var requests atomic.Int32
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/3.2/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("Range") != "2" {
+			t.Errorf("Expected Range=2, got: %q", r.URL.RawQuery)
+		}
+		if accept := r.Header.Get("Accept"); accept != "application/pdf" {
+			t.Errorf("Expected Accept application/pdf, got: %q", accept)
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.4 mock"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL + "/3.2/rest-services",
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	validLinks := []string{
+		"/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage",
+		"/3.2/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage",
+		opsServer.URL + "/3.2/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage",
+	}
+	for _, link := range validLinks {
+		data, err := client.GetImageByLink(ctx, link, 2, "application/pdf")
+		if err != nil {
+			t.Fatalf("GetImageByLink(%q) failed: %v", link, err)
+		}
+		if string(data) != "%PDF-1.4 mock" {
+			t.Errorf("Unexpected data: %q", data)
+		}
+	}
+
+	// Links outside the images service of the base URL are rejected without a request
+	invalidLinks := []string{
+		"https://attacker.example/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage",
+		"//attacker.example/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage",
+		opsServer.URL + "/other/published-data/images/EP/1000000/B1/Drawing/fullimage",
+		"/rest-services/published-data/publication/docdb/EP.1000000.B1/biblio",
+		"/rest-services/published-data/images/../../auth/accesstoken",
+		"/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage?Range=5",
+		"",
+	}
+	for _, link := range invalidLinks {
+		_, err := client.GetImageByLink(ctx, link, 1, "")
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Errorf("GetImageByLink(%q): expected ValidationError, got: %v", link, err)
+		}
+	}
+	if _, err := client.GetImageByLink(ctx, validLinks[0], 0, ""); err == nil {
+		t.Error("Expected error for page 0")
+	}
+	if got := requests.Load(); got != int32(len(validLinks)) {
+		t.Errorf("Expected %d requests, got %d", len(validLinks), got)
+	}
+}
+
+func TestDownloadAllPages(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var accepts []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "image/tiff")
+		_, _ = w.Write([]byte("page " + r.URL.Query().Get("Range")))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL + "/3.2/rest-services",
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	instance := DocumentInstance{
+		Description:   "Drawing",
+		Link:          "/rest-services/published-data/images/EP/1000000/B1/Drawing/fullimage",
+		NumberOfPages: 3,
+		Formats:       []string{"image/tiff"},
+	}
+
+	// A format the instance does not offer fails before any request
+	_, err = client.DownloadAllPages(ctx, instance, "application/pdf")
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) || valErr.Field != "format" || !strings.Contains(valErr.Message, "image/tiff") {
+		t.Errorf("Expected format ValidationError listing image/tiff, got: %v", err)
+	}
+	if len(accepts) != 0 {
+		t.Fatalf("Expected no requests for unavailable format, got %d", len(accepts))
+	}
+
+	// Short names are mapped to the offered MIME type
+	pages, err := client.DownloadAllPages(ctx, instance, "tiff")
+	if err != nil {
+		t.Fatalf("DownloadAllPages failed: %v", err)
+	}
+	if len(pages) != 3 || string(pages[0]) != "page 1" || string(pages[2]) != "page 3" {
+		t.Errorf("Unexpected pages: %q", pages)
+	}
+	for _, accept := range accepts {
+		if accept != "image/tiff" {
+			t.Errorf("Expected Accept image/tiff, got %q", accept)
+		}
+	}
+
+	// Instances without format information are requested as is
+	instance.Formats = nil
+	instance.NumberOfPages = 1
+	if _, err := client.DownloadAllPages(ctx, instance, "application/pdf"); err != nil {
+		t.Errorf("DownloadAllPages without formats failed: %v", err)
+	}
+
+	instance.NumberOfPages = 0
+	if _, err := client.DownloadAllPages(ctx, instance, ""); !errors.As(err, &valErr) {
+		t.Errorf("Expected ValidationError for instance without pages, got: %v", err)
+	}
+}
+
+func TestGetImageRange(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var paths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/published-data/publication/docdb/EP.1000000.B1/images") {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<ops:world-patent-data xmlns:ops="http://ops.epo.org"><ops:document-inquiry><ops:inquiry-result>
+				<ops:document-instance desc="FullDocument" number-of-pages="5" doc-type="FullDocument">
+					<ops:document-instance-link href="/rest-services/published-data/images/EP/1000000/B1/FullDocument/fullimage"/>
+				</ops:document-instance>
+			</ops:inquiry-result></ops:document-inquiry></ops:world-patent-data>`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/tiff")
+		_, _ = w.Write([]byte("page " + r.URL.Query().Get("Range")))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL+"/3.2/rest-services", authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+
+	ctx := context.Background()
+	pages, err := client.GetImageRange(ctx, "EP", "1000000", "B1", "fulldocument", 2, 4)
+	if err != nil {
+		t.Fatalf("GetImageRange failed: %v", err)
+	}
+	var got []string
+	for _, page := range pages {
+		got = append(got, string(page))
+	}
+	if want := []string{"page 2", "page 3", "page 4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pages: got %q, want %q", got, want)
+	}
+	if len(paths) != 4 || !strings.HasSuffix(paths[1], "/published-data/images/EP/1000000/B1/FullDocument/fullimage") {
+		t.Errorf("Requests: got %v, want the inquiry and 3 pages", paths)
+	}
+
+	tests := []struct {
+		name     string
+		docType  string
+		from, to int
+		field    string
+		requests int
+	}{
+		{name: "Inverted range", docType: "FullDocument", from: 4, to: 2, field: "pages"},
+		{name: "Page 0", docType: "FullDocument", from: 0, to: 2, field: "pages"},
+		{name: "Beyond the document", docType: "FullDocument", from: 3, to: 6, field: "pages", requests: 1},
+		{name: "Unknown document type", docType: "Drawing", from: 1, to: 1, field: "docType", requests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			_, err := client.GetImageRange(ctx, "EP", "1000000", "B1", tt.docType, tt.from, tt.to)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Expected ValidationError for %s, got %v", tt.field, err)
+			}
+			// Only the inquiry is requested, no pages
+			if len(paths) != tt.requests {
+				t.Errorf("Requests: got %v, want %d", paths, tt.requests)
+			}
+		})
+	}
+}
+
+func TestGetImageValidateBeforeFetch(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var paths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/images") {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<ops:world-patent-data xmlns:ops="http://ops.epo.org"><ops:document-inquiry><ops:inquiry-result>
+				<ops:document-instance desc="Drawing" number-of-pages="3" doc-type="Drawing">
+					<ops:document-instance-link href="/rest-services/published-data/images/EP/1000000/B1/Drawing/thumbnail"/>
+				</ops:document-instance>
+			</ops:inquiry-result></ops:document-inquiry></ops:world-patent-data>`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/tiff")
+		_, _ = w.Write([]byte("page " + r.URL.Query().Get("Range")))
+	})
+	defer opsServer.Close()
+
+	client, err := NewClient(&Config{
+		ConsumerKey:               "test",
+		ConsumerSecret:            "test",
+		BaseURL:                   opsServer.URL + "/3.2/rest-services",
+		AuthURL:                   authServer.URL + "/auth/accesstoken",
+		ValidateImagesBeforeFetch: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	_, err = client.GetImage(ctx, "EP", "1000000", "B1", ImageTypeThumbnail, 10)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "page" {
+		t.Fatalf("Expected ValidationError for page, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Drawing of EP.1000000.B1 has 3 pages") {
+		t.Errorf("Error should name the document and its page count, got %q", err.Error())
+	}
+	if len(paths) != 1 {
+		t.Errorf("Requests: got %v, want only the inquiry", paths)
+	}
+
+	// The inquiry is cached, so valid pages cost one request each
+	paths = nil
+	data, err := client.GetImage(ctx, "EP", "1000000", "B1", ImageTypeThumbnail, 3)
+	if err != nil {
+		t.Fatalf("GetImage failed: %v", err)
+	}
+	if string(data) != "page 3" {
+		t.Errorf("Image: got %q, want %q", data, "page 3")
+	}
+	if len(paths) != 1 || strings.HasSuffix(paths[0], "/images") {
+		t.Errorf("Requests: got %v, want only the image", paths)
+	}
+
+	paths = nil
+	_, err = client.GetImage(ctx, "EP", "1000000", "B1", ImageTypeFullImage, 1)
+	if !errors.As(err, &validationErr) || validationErr.Field != "docType" {
+		t.Errorf("Expected ValidationError for docType, got %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("Requests: got %v, want none", paths)
+	}
+}
+
+func TestGetImageInquiriesBulkPacing(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var mu sync.Mutex
+	var arrivals []time.Time
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+
+		w.Header().Set("X-Throttling-Control", "idle (images=green:60, inpadoc=green:60, other=green:1000, retrieval=green:200, search=green:30)")
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("image-inquiry.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+
+	numbers := []string{"EP.1000000.B1", "EP.1000001.B1", "EP.1000002.B1"}
+	inquiries, errs := client.GetImageInquiriesBulk(context.Background(), numbers, nil)
+	if len(inquiries) != 3 || len(errs) != 0 {
+		t.Fatalf("Expected 3 inquiries, got %d inquiries and errors %v", len(inquiries), errs)
+	}
+	if quota := client.LastQuotaFor(EndpointImages); quota == nil {
+		t.Fatal("Expected the inquiry to record the images quota")
+	}
+
+	// 60 requests per minute allow one inquiry per second once the allowance is known
+	if len(arrivals) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(arrivals))
+	}
+	if gap := arrivals[2].Sub(arrivals[1]); gap < 900*time.Millisecond {
+		t.Errorf("Expected inquiries to be spaced by about 1s, got %v", gap)
+	}
+}
+
+func TestGetImageInquiriesBulk(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var mu sync.Mutex
+	var paths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		if strings.Contains(r.URL.Path, "EP.9999999.A1") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(loadTestData("error_404.xml"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("image-inquiry.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	var progress []int
+	opts := &BulkOptions{
+		MaxConcurrent: 3,
+		OnProgress: func(completed, total int) {
+			if total != 3 {
+				t.Errorf("OnProgress total: got %d, want 3", total)
+			}
+			progress = append(progress, completed)
+		},
+	}
+	numbers := []string{"EP.1000000.B1", "EP.9999999.A1", "EP2000000A1", "EP.1000000.B1"}
+	inquiries, errs := client.GetImageInquiriesBulk(ctx, numbers, opts)
+
+	if len(inquiries) != 2 || inquiries["EP.1000000.B1"] == nil || inquiries["EP2000000A1"] == nil {
+		t.Errorf("Expected inquiries for EP.1000000.B1 and EP2000000A1, got %v", inquiries)
+	}
+	if got := len(inquiries["EP.1000000.B1"].DocumentInstances); got != 3 {
+		t.Errorf("Expected 3 document instances, got %d", got)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %v", errs)
+	}
+	var notFound *NotFoundError
+	if !errors.As(errs["EP.9999999.A1"], &notFound) {
+		t.Errorf("Expected NotFoundError for EP.9999999.A1, got %v", errs["EP.9999999.A1"])
+	}
+	if len(progress) != 3 || progress[2] != 3 {
+		t.Errorf("Expected progress 1..3, got %v", progress)
+	}
+
+	// Duplicates are requested once; the number format follows the number
+	if len(paths) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(paths))
+	}
+	for _, p := range paths {
+		if strings.Contains(p, "EP2000000A1") && !strings.Contains(p, "/epodoc/") {
+			t.Errorf("Expected epodoc format for EP2000000A1, got %s", p)
+		}
+	}
+
+	t.Run("Canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		inquiries, errs := client.GetImageInquiriesBulk(canceled, []string{"EP.1000000.B1", "EP2000000A1"}, nil)
+		if len(inquiries) != 0 || len(errs) != 2 {
+			t.Fatalf("Expected 2 errors, got %d inquiries and %d errors", len(inquiries), len(errs))
+		}
+		for number, err := range errs {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: expected context.Canceled, got %v", number, err)
+			}
+		}
+	})
+}
+
+// Test legal and register endpoints
+func TestGetLegal(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/legal") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("legal.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	legal, err := client.GetLegal(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetLegal failed: %v", err)
+	}
+
+	if legal == nil {
+		t.Fatal("Expected parsed legal data, got nil")
+	}
+	if len(legal.LegalEvents) == 0 {
+		t.Errorf("Expected legal events in legal data")
+	}
+}
+
+func TestGetLegalSince(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("legal_events.xml"))
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+
+	// Events of the cutoff day are included, whatever the time of day
+	since := time.Date(2015, time.July, 31, 18, 30, 0, 0, time.UTC)
+	legal, err := client.GetLegalSince(context.Background(), RefTypePublication, FormatDocDB, "EP.2400812.A1", since)
+	if err != nil {
+		t.Fatalf("GetLegalSince failed: %v", err)
+	}
+	if legal.PatentNumber == "" {
+		t.Error("Expected patent number to be kept")
+	}
+	var got []string
+	for _, event := range legal.LegalEvents {
+		got = append(got, event.DateMigr)
+	}
+	if want := []string{"20170630", "20160331", "20150731"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Events: got %v, want %v", got, want)
+	}
+}
+
+func TestRefTypeConvenienceMethods(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var gotPath string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+
+		fixture := "biblio.xml"
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/claims"):
+			fixture = "claims.xml"
+		case strings.HasPrefix(r.URL.Path, "/family/"):
+			fixture = "family.xml"
+		case strings.HasPrefix(r.URL.Path, "/legal/"):
+			fixture = "legal.xml"
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData(fixture))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	discard := func(_ any, err error) error { return err }
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantPath string
+	}{
+		{"GetBiblioByPublication", func() error {
+			return discard(client.GetBiblioByPublication(ctx, FormatEPODOC, "EP1000000B1"))
+		}, "/published-data/publication/epodoc/EP1000000B1/biblio"},
+		{"GetBiblioByApplication", func() error {
+			return discard(client.GetBiblioByApplication(ctx, FormatEPODOC, "EP99203729"))
+		}, "/published-data/application/epodoc/EP99203729/biblio"},
+		{"GetBiblioByPriority", func() error {
+			return discard(client.GetBiblioByPriority(ctx, FormatEPODOC, "US19990465270"))
+		}, "/published-data/priority/epodoc/US19990465270/biblio"},
+		{"GetClaimsByPublication", func() error {
+			return discard(client.GetClaimsByPublication(ctx, FormatEPODOC, "EP1000000B1"))
+		}, "/published-data/publication/epodoc/EP1000000B1/claims"},
+		{"GetClaimsByApplication", func() error {
+			return discard(client.GetClaimsByApplication(ctx, FormatEPODOC, "EP99203729"))
+		}, "/published-data/application/epodoc/EP99203729/claims"},
+		{"GetClaimsByPriority", func() error {
+			return discard(client.GetClaimsByPriority(ctx, FormatEPODOC, "US19990465270"))
+		}, "/published-data/priority/epodoc/US19990465270/claims"},
+		{"GetFamilyByPublication", func() error {
+			return discard(client.GetFamilyByPublication(ctx, FormatEPODOC, "EP1000000B1"))
+		}, "/family/publication/epodoc/EP1000000B1"},
+		{"GetFamilyByApplication", func() error {
+			return discard(client.GetFamilyByApplication(ctx, FormatEPODOC, "EP99203729"))
+		}, "/family/application/epodoc/EP99203729"},
+		{"GetFamilyByPriority", func() error {
+			return discard(client.GetFamilyByPriority(ctx, FormatEPODOC, "US19990465270"))
+		}, "/family/priority/epodoc/US19990465270"},
+		{"GetLegalByPublication", func() error {
+			return discard(client.GetLegalByPublication(ctx, FormatEPODOC, "EP1000000B1"))
+		}, "/legal/publication/epodoc/EP1000000B1"},
+		{"GetLegalByApplication", func() error {
+			return discard(client.GetLegalByApplication(ctx, FormatEPODOC, "EP99203729"))
+		}, "/legal/application/epodoc/EP99203729"},
+		{"GetLegalByPriority", func() error {
+			return discard(client.GetLegalByPriority(ctx, FormatEPODOC, "US19990465270"))
+		}, "/legal/priority/epodoc/US19990465270"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath = ""
+			if err := tt.call(); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("Request path: got %q, want %q", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+// Test error handling
+func TestErrorHandling(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	t.Run("404 Not Found", func(t *testing.T) {
+		opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(loadTestData("error_404.xml"))
+		})
 		defer opsServer.Close()
 
 		config := &Config{
@@ -571,8 +2414,17 @@ func TestErrorHandling(t *testing.T) {
 			t.Error("Expected error for 429 response")
 		}
 
-		if _, ok := err.(*QuotaExceededError); !ok {
-			t.Errorf("Expected QuotaExceededError, got: %T", err)
+		quotaErr, ok := err.(*QuotaExceededError)
+		if !ok {
+			t.Fatalf("Expected QuotaExceededError, got: %T", err)
+		}
+
+		// A blocked quota is recorded and not worth retrying
+		if quotaErr.Status != "black" {
+			t.Errorf("Expected status 'black', got '%s'", quotaErr.Status)
+		}
+		if IsRetryable(err) {
+			t.Error("Expected blocked quota error to be non-retryable")
 		}
 	})
 }
@@ -590,7 +2442,7 @@ func TestQuotaTracking(t *testing.T) {
 		w.Header().Set("Content-Type", "application/xml")
 		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
 <ops:world-patent-data xmlns:ops="http://ops.epo.org">
-  <exchange-document></exchange-document>
+  <exchange-documents><exchange-document></exchange-document></exchange-documents>
 </ops:world-patent-data>`))
 	})
 	defer opsServer.Close()
@@ -647,6 +2499,72 @@ func TestQuotaTracking(t *testing.T) {
 	}
 }
 
+func TestLastQuotaFor_Concurrent(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if strings.Contains(r.URL.Path, "/search") {
+			w.Header().Set("X-IndividualQuota", "used=2000,quota=4000000000")
+			_, _ = w.Write(loadTestData("search.xml"))
+			return
+		}
+		w.Header().Set("X-IndividualQuota", "used=1000,quota=4000000000")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if client.LastQuotaFor(EndpointBiblio) != nil {
+		t.Error("Expected nil quota before first request")
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+				t.Errorf("GetBiblio failed: %v", err)
+			}
+			if quota := client.LastQuotaFor(EndpointBiblio); quota == nil || quota.Individual.Used != 1000 {
+				t.Errorf("Expected biblio quota used=1000, got %+v", quota)
+			}
+			_ = client.GetLastQuota()
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := client.Search(ctx, "ti=battery", "1-5"); err != nil {
+				t.Errorf("Search failed: %v", err)
+			}
+			if quota := client.LastQuotaFor(EndpointSearch); quota == nil || quota.Individual.Used != 2000 {
+				t.Errorf("Expected search quota used=2000, got %+v", quota)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if last := client.GetLastQuota(); last == nil || (last.Individual.Used != 1000 && last.Individual.Used != 2000) {
+		t.Errorf("Unexpected last quota: %+v", last)
+	}
+	if client.LastQuotaFor(EndpointImages) != nil {
+		t.Error("Expected nil quota for endpoint without requests")
+	}
+}
+
 // Test GetUsageStats
 func TestGetUsageStats(t *testing.T) {
 	authServer := newMockAuthServer(t)
@@ -680,60 +2598,322 @@ func TestGetUsageStats(t *testing.T) {
 	client, _ := NewClient(config)
 	ctx := context.Background()
 
-	stats, err := client.GetUsageStats(ctx, "01/01/2022")
+	stats, err := client.GetUsageStats(ctx, "01/01/2022")
+	if err != nil {
+		t.Fatalf("GetUsageStats failed: %v", err)
+	}
+
+	if stats == nil {
+		t.Fatal("Expected usage stats, got nil")
+	}
+
+	if len(stats.Entries) != 2 {
+		t.Errorf("Expected 2 usage entries, got: %d", len(stats.Entries))
+	}
+
+	if stats.Entries[0].TotalResponseSize != 1048576 {
+		t.Errorf("Expected first entry size 1048576, got: %d", stats.Entries[0].TotalResponseSize)
+	}
+}
+
+func TestGetUsageStatsRange(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var timeRanges []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		timeRanges = append(timeRanges, r.URL.Query().Get("timeRange"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": []}`))
+	})
+	defer opsServer.Close()
+
+	config := DefaultConfig()
+	config.ConsumerKey = "test"
+	config.ConsumerSecret = "test"
+	config.BaseURL = opsServer.URL
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, _ := NewClient(config)
+	ctx := context.Background()
+
+	from := time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.February, 9, 0, 0, 0, 0, time.UTC)
+	if _, err := client.GetUsageStatsRange(ctx, from, to); err != nil {
+		t.Fatalf("GetUsageStatsRange failed: %v", err)
+	}
+	if _, err := client.GetUsageStatsDay(ctx, from); err != nil {
+		t.Fatalf("GetUsageStatsDay failed: %v", err)
+	}
+
+	want := []string{"03/02/2024~09/02/2024", "03/02/2024"}
+	if !reflect.DeepEqual(timeRanges, want) {
+		t.Errorf("timeRange: got %q, want %q", timeRanges, want)
+	}
+
+	// Reversed range is rejected before a request is made
+	if _, err := client.GetUsageStatsRange(ctx, to, from); err == nil {
+		t.Error("Expected error for reversed range")
+	}
+	if len(timeRanges) != 2 {
+		t.Errorf("Expected no request for reversed range, got %d requests", len(timeRanges))
+	}
+}
+
+// Test context cancellation
+func TestContextCancellation(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// Create a slow server
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`<data></data>`))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		Timeout:        10 * time.Millisecond, // Very short timeout
+		MaxRetries:     0,                     // No retries
+		RetryDelay:     1 * time.Nanosecond,   // Fast failure
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	_, err = client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err == nil {
+		t.Error("Expected timeout error")
+	}
+
+	// Verify it's a timeout-related error
+	if err != nil && !strings.Contains(err.Error(), "timeout") && !strings.Contains(err.Error(), "context deadline exceeded") {
+		t.Logf("Got error (acceptable): %v", err)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	body := loadTestData("biblio.xml")
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Query().Get("chunked") != "" {
+			// Flushing before the end sends the body without a Content-Length
+			_, _ = w.Write(body[:10])
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(body[10:])
+			return
+		}
+		_, _ = w.Write(body)
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+	if client.config.MaxResponseBytes != 0 {
+		t.Errorf("MaxResponseBytes of a Config without it: got %d, want 0 (unlimited)", client.config.MaxResponseBytes)
+	}
+	if got := DefaultConfig().MaxResponseBytes; got != defaultMaxResponseBytes {
+		t.Errorf("DefaultConfig MaxResponseBytes: got %d, want %d", got, defaultMaxResponseBytes)
+	}
+
+	size := int64(len(body))
+	for _, chunked := range []bool{false, true} {
+		url := opsServer.URL + "/published-data/publication/docdb/EP.1000000.B1/biblio"
+		if chunked {
+			url += "?chunked=1"
+		}
+		get := func(limit int64) (*RawResponse, error) {
+			client.config.MaxResponseBytes = limit
+			return client.DoRaw(context.Background(), func(ctx context.Context, httpClient *http.Client) (*http.Response, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+				if err != nil {
+					return nil, err
+				}
+				return httpClient.Do(req)
+			})
+		}
+
+		_, err := get(size - 1)
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("chunked=%v: expected ResponseTooLargeError, got %v", chunked, err)
+		}
+		if tooLarge.Limit != size-1 {
+			t.Errorf("chunked=%v: Limit: got %d, want %d", chunked, tooLarge.Limit, size-1)
+		}
+
+		// A body of exactly the limit and an unlimited client read the whole body
+		for _, limit := range []int64{size, 0, -1} {
+			raw, err := get(limit)
+			if err != nil {
+				t.Fatalf("chunked=%v, limit %d: unexpected error: %v", chunked, limit, err)
+			}
+			if !bytes.Equal(raw.Body, body) {
+				t.Errorf("chunked=%v, limit %d: body was not read completely", chunked, limit)
+			}
+		}
+	}
+
+	client.config.MaxResponseBytes = size - 1
+	if _, err := client.GetBiblioRaw(context.Background(), "publication", "docdb", "EP.1000000.B1"); !errors.As(err, new(*ResponseTooLargeError)) {
+		t.Errorf("GetBiblioRaw: expected ResponseTooLargeError, got %v", err)
+	}
+}
+
+// Test Close releases idle keep-alive connections
+func TestClientClose(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var mu sync.Mutex
+	idle, closed := 0, 0
+	opsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	}))
+	opsServer.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch state {
+		case http.StateIdle:
+			idle++
+		case http.StateClosed:
+			closed++
+		}
+	}
+	opsServer.Start()
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		MaxRetries:     0,
+	}
+
+	client, err := NewClient(config)
 	if err != nil {
-		t.Fatalf("GetUsageStats failed: %v", err)
+		t.Fatalf("Failed to create client: %v", err)
 	}
 
-	if stats == nil {
-		t.Fatal("Expected usage stats, got nil")
+	ctx := context.Background()
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	// The keep-alive connection stays open until the client is closed
+	waitFor := func(cond func() bool) bool {
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			mu.Lock()
+			ok := cond()
+			mu.Unlock()
+			if ok {
+				return true
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return false
 	}
+	if !waitFor(func() bool { return idle == 1 }) {
+		t.Fatal("Expected an idle keep-alive connection after the request")
+	}
+	mu.Lock()
+	if closed != 0 {
+		t.Errorf("Connection closed before Close: %d", closed)
+	}
+	mu.Unlock()
 
-	if len(stats.Entries) != 2 {
-		t.Errorf("Expected 2 usage entries, got: %d", len(stats.Entries))
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !waitFor(func() bool { return closed == 1 }) {
+		t.Error("Expected idle connection to be closed by Close")
 	}
 
-	if stats.Entries[0].TotalResponseSize != 1048576 {
-		t.Errorf("Expected first entry size 1048576, got: %d", stats.Entries[0].TotalResponseSize)
+	// Close is idempotent and a closed client rejects further calls
+	if err := client.Close(); err != nil {
+		t.Errorf("Second Close failed: %v", err)
+	}
+	_, err = client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1")
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Errorf("Expected ConfigError after Close, got: %v", err)
 	}
 }
 
-// Test context cancellation
-func TestContextCancellation(t *testing.T) {
-	authServer := newMockAuthServer(t)
+func TestVerifyCredentials(t *testing.T) {
+	var authCalls atomic.Int32
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authCalls.Add(1)
+		user, _, _ := r.BasicAuth()
+		if user != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test_token_12345","expires_in":"3600"}`))
+	}))
 	defer authServer.Close()
 
-	// Create a slow server
+	// No data request may be sent
 	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
-		time.Sleep(200 * time.Millisecond)
-		_, _ = w.Write([]byte(`<data></data>`))
+		t.Errorf("Unexpected data request: %s", r.URL.Path)
 	})
 	defer opsServer.Close()
 
-	config := &Config{
-		ConsumerKey:    "test",
-		ConsumerSecret: "test",
-		BaseURL:        opsServer.URL,
-		Timeout:        10 * time.Millisecond, // Very short timeout
-		MaxRetries:     0,                     // No retries
-		RetryDelay:     1 * time.Nanosecond,   // Fast failure
+	newClient := func(key string) *Client {
+		client, err := NewClient(&Config{
+			ConsumerKey:    key,
+			ConsumerSecret: "secret",
+			BaseURL:        opsServer.URL,
+			AuthURL:        authServer.URL,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		return client
 	}
-	config.AuthURL = authServer.URL + "/auth/accesstoken"
 
-	client, err := NewClient(config)
-	if err != nil {
-		t.Fatalf("Failed to create client: %v", err)
+	ctx := context.Background()
+	client := newClient("good")
+	if err := client.VerifyCredentials(ctx); err != nil {
+		t.Fatalf("VerifyCredentials failed: %v", err)
+	}
+	// The token is reused
+	if err := client.VerifyCredentials(ctx); err != nil {
+		t.Fatalf("VerifyCredentials failed: %v", err)
+	}
+	if got := authCalls.Load(); got != 1 {
+		t.Errorf("Token requests: got %d, want 1", got)
 	}
 
-	ctx := context.Background()
-	_, err = client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1")
-	if err == nil {
-		t.Error("Expected timeout error")
+	err := newClient("bad").VerifyCredentials(ctx)
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("Expected AuthError, got %T: %v", err, err)
+	}
+	if authErr.Kind != KindInvalidCredentials || authErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Got kind %q status %d, want %q 401", authErr.Kind, authErr.StatusCode, KindInvalidCredentials)
 	}
 
-	// Verify it's a timeout-related error
-	if err != nil && !strings.Contains(err.Error(), "timeout") && !strings.Contains(err.Error(), "context deadline exceeded") {
-		t.Logf("Got error (acceptable): %v", err)
+	_ = client.Close()
+	var configErr *ConfigError
+	if err := client.VerifyCredentials(ctx); !errors.As(err, &configErr) {
+		t.Errorf("Expected ConfigError on closed client, got %v", err)
 	}
 }
 
@@ -792,6 +2972,83 @@ func TestTokenRefreshOn401(t *testing.T) {
 	}
 }
 
+// Test proactive token refresh before expiry
+func TestTokenPreRefresh(t *testing.T) {
+	var mu sync.Mutex
+	issued := map[string]time.Time{}
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		token := fmt.Sprintf("token_%d", len(issued)+1)
+		issued[token] = time.Now()
+		mu.Unlock()
+
+		// Short-lived token: expires after 2 seconds
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"expires_in":"2"}`, token)
+	}))
+	defer authServer.Close()
+
+	unauthorized := 0
+	opsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		issuedAt, ok := issued[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		expired := !ok || time.Since(issuedAt) > 2*time.Second
+		if expired {
+			unauthorized++
+		}
+		mu.Unlock()
+
+		if expired {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	}))
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:        "test",
+		ConsumerSecret:     "test",
+		BaseURL:            opsServer.URL,
+		AuthURL:            authServer.URL + "/auth/accesstoken",
+		TokenRefreshBuffer: 1500 * time.Millisecond,
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+			t.Fatalf("Request %d failed: %v", i+1, err)
+		}
+	}
+
+	// Still outside the refresh buffer: token is reused
+	mu.Lock()
+	if len(issued) != 1 {
+		t.Errorf("Expected 1 token before buffer, got: %d", len(issued))
+	}
+	mu.Unlock()
+
+	// Enter the refresh buffer while the token is still valid on the server
+	time.Sleep(700 * time.Millisecond)
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("Request after buffer failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(issued) != 2 {
+		t.Errorf("Expected token to be refreshed before expiry (2 tokens), got: %d", len(issued))
+	}
+	if unauthorized != 0 {
+		t.Errorf("Expected no 401 responses, got: %d", unauthorized)
+	}
+}
+
 // Benchmark tests
 func BenchmarkGetBiblio(b *testing.B) {
 	authServer := newMockAuthServer(&testing.T{})
@@ -858,11 +3115,14 @@ func TestGetEndpointFromPath(t *testing.T) {
 		{"/rest-services/published-data/publication/epodoc/EP1000000/claims", EndpointClaims},
 		{"/rest-services/published-data/publication/epodoc/EP1000000/description", EndpointDescription},
 		{"/rest-services/published-data/publication/epodoc/EP1000000/fulltext", EndpointFulltext},
+		{"/rest-services/published-data/publication/docdb/EP.1000000.B1/biblio,abstract", EndpointBiblio},
 		{"/rest-services/family/publication/docdb/EP.1000000.B1/biblio", EndpointFamily},
 		{"/rest-services/legal/publication/docdb/EP.1000000.B1", EndpointLegal},
 		{"/rest-services/register/publication/epodoc/EP1000000", EndpointRegister},
 		{"/rest-services/published-data/search?q=test", EndpointSearch},
 		{"/rest-services/published-data/images/EP/1000000/A1/fullimage", EndpointImages},
+		{"/rest-services/published-data/publication/docdb/EP.1000000.B1/images", EndpointImages},
+		{"/rest-services/published-data/application/epodoc/EP99203729/images", EndpointImages},
 		{"/unknown/path", ""},
 	}
 
@@ -1015,6 +3275,207 @@ func TestGetBiblioMultiple(t *testing.T) {
 	}
 }
 
+func TestGetBibliosBulkDetailed(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	// 250 numbers: batches of 100, 100 and 50
+	numbers := make([]string, 250)
+	for i := range numbers {
+		numbers[i] = fmt.Sprintf("EP.%d.A1", 1000000+i)
+	}
+
+	var batchSizes []int
+	var mu sync.Mutex
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lines := strings.Split(string(body), "\n")
+		mu.Lock()
+		batchSizes = append(batchSizes, len(lines))
+		mu.Unlock()
+
+		// Fail the second batch
+		if lines[0] == numbers[100] {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(loadTestData("error_404.xml"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	var progress []int
+	results, err := client.GetBibliosBulkDetailed(ctx, RefTypePublication, FormatDocDB, numbers, &BulkOptions{
+		OnProgress: func(current, total int) {
+			if total != 3 {
+				t.Errorf("Expected 3 batches, got %d", total)
+			}
+			progress = append(progress, current)
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetBibliosBulkDetailed failed: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, result := range results {
+		if result.Batch != i+1 {
+			t.Errorf("Result %d: expected batch %d, got %d", i, i+1, result.Batch)
+		}
+	}
+	if results[0].Err != nil || results[0].Data == "" {
+		t.Errorf("Batch 1: expected data, got error %v", results[0].Err)
+	}
+	var notFound *NotFoundError
+	if !errors.As(results[1].Err, &notFound) || results[1].Data != "" {
+		t.Errorf("Batch 2: expected NotFoundError, got %v", results[1].Err)
+	}
+	if len(results[1].Numbers) != 100 || results[1].Numbers[0] != numbers[100] {
+		t.Errorf("Batch 2: unexpected numbers %v...", results[1].Numbers[:1])
+	}
+	if results[2].Err != nil || len(results[2].Numbers) != 50 {
+		t.Errorf("Batch 3: expected 50 numbers without error, got %d (%v)", len(results[2].Numbers), results[2].Err)
+	}
+	if !reflect.DeepEqual(batchSizes, []int{100, 100, 50}) {
+		t.Errorf("Unexpected batch sizes: %v", batchSizes)
+	}
+	if !reflect.DeepEqual(progress, []int{1, 2, 3}) {
+		t.Errorf("Unexpected progress callbacks: %v", progress)
+	}
+
+	// GetBibliosBulk stops at the failing batch
+	batchSizes = nil
+	if _, err := client.GetBibliosBulk(ctx, RefTypePublication, FormatDocDB, numbers, nil); !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError from GetBibliosBulk, got %v", err)
+	} else if !strings.Contains(err.Error(), "batch 2") {
+		t.Errorf("Expected error to name batch 2, got %v", err)
+	}
+	if len(batchSizes) != 2 {
+		t.Errorf("Expected GetBibliosBulk to stop after 2 batches, got %d", len(batchSizes))
+	}
+
+	// Only cancellation is a top-level error
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.GetBibliosBulkDetailed(canceled, RefTypePublication, FormatDocDB, numbers, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestBulkMethods_Batching(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var mu sync.Mutex
+	var paths []string
+	var batchSizes []int
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		batchSizes = append(batchSizes, len(strings.Split(string(body), "\n")))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("family.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	type bulkFunc func(ctx context.Context, refType, format string, numbers []string, opts *BulkOptions) ([]string, error)
+	methods := []struct {
+		name string
+		fn   bulkFunc
+		path string
+	}{
+		{"GetLegalBulk", client.GetLegalBulk, "/legal/publication/docdb"},
+		{"GetFamilyBulk", client.GetFamilyBulk, "/family/publication/docdb"},
+		{"GetFamilyWithBiblioBulk", client.GetFamilyWithBiblioBulk, "/family/publication/docdb/biblio"},
+	}
+	counts := []struct {
+		numbers int
+		batches []int
+	}{
+		{150, []int{100, 50}},
+		{250, []int{100, 100, 50}},
+	}
+
+	ctx := context.Background()
+	for _, m := range methods {
+		for _, count := range counts {
+			t.Run(fmt.Sprintf("%s/%d", m.name, count.numbers), func(t *testing.T) {
+				numbers := make([]string, count.numbers)
+				for i := range numbers {
+					numbers[i] = fmt.Sprintf("EP.%d.A1", 1000000+i)
+				}
+				paths, batchSizes = nil, nil
+
+				var progress int
+				data, err := m.fn(ctx, RefTypePublication, FormatDocDB, numbers, &BulkOptions{
+					OnProgress: func(current, total int) {
+						progress = current
+						if total != len(count.batches) {
+							t.Errorf("Expected %d total batches, got %d", len(count.batches), total)
+						}
+					},
+				})
+				if err != nil {
+					t.Fatalf("%s failed: %v", m.name, err)
+				}
+
+				if len(data) != len(count.batches) {
+					t.Errorf("Expected %d batch responses, got %d", len(count.batches), len(data))
+				}
+				if !reflect.DeepEqual(batchSizes, count.batches) {
+					t.Errorf("Expected batch sizes %v, got %v", count.batches, batchSizes)
+				}
+				if progress != len(count.batches) {
+					t.Errorf("Expected last progress callback %d, got %d", len(count.batches), progress)
+				}
+				for _, path := range paths {
+					if !strings.HasSuffix(path, m.path) {
+						t.Errorf("Expected path ending in %s, got %s", m.path, path)
+					}
+				}
+			})
+		}
+	}
+
+	if _, err := client.GetFamilyBulk(ctx, RefTypePublication, FormatDocDB, nil, nil); err == nil {
+		t.Error("Expected error for empty number list")
+	}
+}
+
 // TestGetBiblioMultiple_Validation tests validation for bulk operations
 func TestGetBiblioMultiple_Validation(t *testing.T) {
 	config := DefaultConfig()
@@ -1203,3 +3664,158 @@ func TestGetFulltextMultiple(t *testing.T) {
 		t.Error("Expected claims in fulltext data")
 	}
 }
+
+func TestClientInterceptors(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var serverHits atomic.Int32
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		serverHits.Add(1)
+		if got := r.Header.Get("X-Request-Id"); got != "req-1" {
+			t.Errorf("X-Request-Id: got %q, want %q", got, "req-1")
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	var order []string
+	var seenAuth, seenAccept string
+	record := func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+		order = append(order, "record")
+		seenAuth = req.Header.Get("Authorization")
+		seenAccept = req.Header.Get("Accept")
+		req.Header.Set("X-Request-Id", "req-1")
+		return next(req)
+	}
+	canned := func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+		order = append(order, "canned")
+		if strings.Contains(req.URL.Path, "/claims") {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/xml"}},
+				Body:       io.NopCloser(bytes.NewReader(loadTestData("claims.xml"))),
+			}, nil
+		}
+		return next(req)
+	}
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		Interceptors:   []RequestInterceptor{record, canned},
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("GetBiblioRaw failed: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"record", "canned"}) {
+		t.Errorf("Interceptor order: got %v", order)
+	}
+	if !strings.HasPrefix(seenAuth, "Bearer ") {
+		t.Errorf("Interceptor saw Authorization %q, want bearer token", seenAuth)
+	}
+	if seenAccept != "application/exchange+xml" {
+		t.Errorf("Interceptor saw Accept %q, want application/exchange+xml", seenAccept)
+	}
+	if serverHits.Load() != 1 {
+		t.Fatalf("Expected 1 server request, got %d", serverHits.Load())
+	}
+
+	// Canned response short-circuits the chain before the server
+	claims, err := client.GetClaims(ctx, "publication", "docdb", "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetClaims failed: %v", err)
+	}
+	if len(claims.Claims) == 0 {
+		t.Error("Expected claims from canned response")
+	}
+	if serverHits.Load() != 1 {
+		t.Errorf("Expected canned response without server request, got %d requests", serverHits.Load())
+	}
+}
+
+func TestConvertToDocdb(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	conversion := func(outputFormat, documentID string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?>
+<ops:world-patent-data xmlns="http://www.epo.org/exchange" xmlns:ops="http://ops.epo.org">
+  <ops:meta name="status" value="BRE028"/>
+  <ops:standardization inputFormat="original" outputFormat="` + outputFormat + `">
+    <ops:input>
+      <ops:application-reference>
+        <document-id document-id-type="original"><country>JP</country><doc-number>(2006-147056)</doc-number><kind>A</kind></document-id>
+      </ops:application-reference>
+    </ops:input>
+    <ops:output>
+      <ops:application-reference>
+        <document-id document-id-type="` + outputFormat + `">` + documentID + `<date>20060526</date></document-id>
+      </ops:application-reference>
+    </ops:output>
+  </ops:standardization>
+</ops:world-patent-data>`
+	}
+
+	var paths []string
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/docdb"):
+			_, _ = w.Write([]byte(conversion("docdb", `<country>JP</country><doc-number>2006147056</doc-number><kind>A</kind>`)))
+		case strings.HasSuffix(r.URL.Path, "/epodoc"):
+			_, _ = w.Write([]byte(conversion("epodoc", `<doc-number>JP20060147056</doc-number>`)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer opsServer.Close()
+
+	client, err := NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	if err != nil {
+		t.Fatalf("NewTestClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	docdb, err := client.ConvertToDocdb(ctx, RefTypeApplication, "JP.(2006-147056).A")
+	if err != nil {
+		t.Fatalf("ConvertToDocdb failed: %v", err)
+	}
+	if docdb != "JP.2006147056.A" {
+		t.Errorf("ConvertToDocdb: got %q, want JP.2006147056.A", docdb)
+	}
+
+	epodoc, err := client.ConvertToEpodoc(ctx, RefTypeApplication, "JP.(2006-147056).A")
+	if err != nil {
+		t.Fatalf("ConvertToEpodoc failed: %v", err)
+	}
+	if epodoc != "JP20060147056" {
+		t.Errorf("ConvertToEpodoc: got %q, want JP20060147056", epodoc)
+	}
+
+	if len(paths) != 2 || !strings.Contains(paths[0], "/number-service/application/original/") {
+		t.Errorf("Requests: got %v, want two original-format number-service requests", paths)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.ConvertToDocdb(ctx, "invalid", "JP.(2006-147056).A"); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError for invalid refType, got %v", err)
+	}
+
+	var dataErr *DataValidationError
+	_, err = ParseNumberConversion(`<ops:world-patent-data xmlns:ops="http://ops.epo.org"><ops:meta name="status" value="BRE028"/></ops:world-patent-data>`)
+	if !errors.As(err, &dataErr) {
+		t.Errorf("Expected DataValidationError for response without output, got %v", err)
+	}
+}
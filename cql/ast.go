@@ -0,0 +1,259 @@
+package cql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CQLNode is a node in the expression tree of a CQL query.
+//
+// Leaf nodes are search clauses: Op is the comparison operator ("=", ">=", ...)
+// with Field and Value set (e.g., ti=bluetooth), or Op, Field and Value are
+// empty except for Value on a bare search term (e.g., bluetooth).
+//
+// Boolean nodes have Op set to an uppercase boolean or proximity operator
+// ("AND", "OR", "NOT", "PROX", ...) and two or more Children. AND and OR
+// nodes are n-ary (a AND b AND c has three children); the other operators
+// are binary and left-associative.
+type CQLNode struct {
+	Op       string
+	Field    string
+	Value    string
+	Children []*CQLNode
+}
+
+// Operator precedence, from loosest to tightest binding
+const (
+	precOR = iota + 1
+	precAND
+	precNOT
+	precProximity
+)
+
+// operatorPrecedence returns the binding strength of a boolean operator (NOT > AND > OR),
+// regardless of case. Proximity operators bind tightest.
+func operatorPrecedence(op string) int {
+	switch strings.ToUpper(op) {
+	case "OR":
+		return precOR
+	case "AND":
+		return precAND
+	case "NOT":
+		return precNOT
+	default:
+		return precProximity
+	}
+}
+
+// IsLeaf reports whether the node is a search clause or bare search term.
+func (n *CQLNode) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// String renders the node back to a CQL query string. Boolean operators are written
+// in uppercase, and parentheses are only emitted where precedence requires them.
+func (n *CQLNode) String() string {
+	if n.IsLeaf() {
+		if n.Field == "" {
+			return quoteValue(n.Value)
+		}
+		return n.Field + n.Op + quoteValue(n.Value)
+	}
+
+	op := strings.ToUpper(n.Op)
+	prec := operatorPrecedence(op)
+	parts := make([]string, len(n.Children))
+	for i, child := range n.Children {
+		parts[i] = child.String()
+		if child.IsLeaf() {
+			continue
+		}
+
+		// Looser children always need parentheses. Equal precedence is fine on the
+		// left (left-associative); on the right, only a chain of the same AND or OR
+		// operator can go without, as a NOT (b NOT c) or a PROX (b PROX c) differ
+		// from their flattened form.
+		childOp := strings.ToUpper(child.Op)
+		childPrec := operatorPrecedence(childOp)
+		associative := childOp == op && (op == "AND" || op == "OR")
+		if childPrec < prec || (childPrec == prec && i > 0 && !associative) {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " "+op+" ")
+}
+
+// quoteValue wraps values containing whitespace, CQL syntax characters or
+// operator words in double quotes. Double quotes and backslashes inside the
+// value are escaped with a backslash, so the value cannot end the quoted term.
+func quoteValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\n()=<>\"\\") || IsValidOperator(strings.ToUpper(value)) || IsProximityOperator(value) {
+		return `"` + quoteEscaper.Replace(value) + `"`
+	}
+	return value
+}
+
+// quoteEscaper escapes values for a quoted term; quoteUnescaper reverses it.
+var (
+	quoteEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	quoteUnescaper = strings.NewReplacer(`\\`, `\`, `\"`, `"`)
+)
+
+// AST builds the expression tree of the query.
+//
+// Boolean operators follow the precedence NOT > AND > OR, and parentheses group
+// sub-expressions. Returns an error if the query failed validation or cannot be
+// arranged into a tree (e.g., two search clauses without an operator between them).
+//
+// Example:
+//
+//	q, _ := cql.ParseCQL("ti=5g AND (pa=apple OR pa=samsung)")
+//	root, err := q.AST()
+//	// root.Op == "AND", root.Children[1].Op == "OR"
+//	fmt.Println(root) // ti=5g AND (pa=apple OR pa=samsung)
+func (q *CQLQuery) AST() (*CQLNode, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &astParser{tokens: q.Tokens}
+	node, err := p.parseExpression(precOR)
+	if err != nil {
+		return nil, err
+	}
+	if token, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", token.Value, token.Pos)
+	}
+	return node, nil
+}
+
+// astParser is a precedence-climbing parser over the token stream of a CQLQuery.
+type astParser struct {
+	tokens []CQLToken
+	pos    int
+}
+
+func (p *astParser) peek() (CQLToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return CQLToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *astParser) next() (CQLToken, bool) {
+	token, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return token, ok
+}
+
+// parseExpression parses operands joined by operators binding at least as tight as minPrec.
+func (p *astParser) parseExpression(minPrec int) (*CQLNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token, ok := p.peek()
+		if !ok || token.Type == TokenRParen {
+			return left, nil
+		}
+		if token.Type != TokenOperator {
+			return nil, fmt.Errorf("expected boolean operator before '%s' at position %d", token.Value, token.Pos)
+		}
+
+		op := strings.ToUpper(token.Value)
+		prec := operatorPrecedence(op)
+		if prec < minPrec {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseExpression(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		// Flatten chains of the same associative operator into one n-ary node
+		if (op == "AND" || op == "OR") && left.Op == op && !left.IsLeaf() {
+			left.Children = append(left.Children, right)
+		} else {
+			left = &CQLNode{Op: op, Children: []*CQLNode{left, right}}
+		}
+	}
+}
+
+// parseOperand parses a parenthesized expression, a search clause or a bare search term.
+func (p *astParser) parseOperand() (*CQLNode, error) {
+	token, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	switch token.Type {
+	case TokenLParen:
+		node, err := p.parseExpression(precOR)
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.next(); !ok || closing.Type != TokenRParen {
+			return nil, fmt.Errorf("missing closing parenthesis for '(' at position %d", token.Pos)
+		}
+		return node, nil
+
+	case TokenQuote:
+		p.pos--
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return &CQLNode{Value: value}, nil
+
+	case TokenField, TokenValue:
+		relation, ok := p.peek()
+		if !ok || relation.Type != TokenEquals {
+			return &CQLNode{Value: token.Value}, nil
+		}
+		p.pos++
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return &CQLNode{Op: relation.Value, Field: token.Value, Value: value}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected '%s' at position %d", token.Value, token.Pos)
+	}
+}
+
+// parseValue parses a plain or quoted value.
+func (p *astParser) parseValue() (string, error) {
+	token, ok := p.next()
+	if !ok {
+		return "", fmt.Errorf("missing value at end of query")
+	}
+
+	switch token.Type {
+	case TokenValue:
+		return token.Value, nil
+
+	case TokenQuote:
+		var value strings.Builder
+		for {
+			inner, ok := p.next()
+			if !ok {
+				return "", fmt.Errorf("unclosed quote at position %d", token.Pos)
+			}
+			if inner.Type == TokenQuote {
+				return quoteUnescaper.Replace(value.String()), nil
+			}
+			value.WriteString(inner.Value)
+		}
+
+	default:
+		return "", fmt.Errorf("expected value, got '%s' at position %d", token.Value, token.Pos)
+	}
+}
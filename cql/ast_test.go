@@ -0,0 +1,258 @@
+package cql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCQLQuery_AST(t *testing.T) {
+	leaf := func(field, value string) *CQLNode {
+		return &CQLNode{Op: "=", Field: field, Value: value}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  *CQLNode
+	}{
+		{
+			name:  "Single clause",
+			query: "ti=bluetooth",
+			want:  leaf("ti", "bluetooth"),
+		},
+		{
+			name:  "Comparison operator",
+			query: "pd>=20200101",
+			want:  &CQLNode{Op: ">=", Field: "pd", Value: "20200101"},
+		},
+		{
+			name:  "Quoted value",
+			query: `pa="Apple Inc"`,
+			want:  leaf("pa", "Apple Inc"),
+		},
+		{
+			name:  "AND binds tighter than OR",
+			query: "ti=a OR ti=b AND pa=c",
+			want: &CQLNode{Op: "OR", Children: []*CQLNode{
+				leaf("ti", "a"),
+				{Op: "AND", Children: []*CQLNode{leaf("ti", "b"), leaf("pa", "c")}},
+			}},
+		},
+		{
+			name:  "NOT binds tighter than AND",
+			query: "ti=a AND ti=b NOT pa=c",
+			want: &CQLNode{Op: "AND", Children: []*CQLNode{
+				leaf("ti", "a"),
+				{Op: "NOT", Children: []*CQLNode{leaf("ti", "b"), leaf("pa", "c")}},
+			}},
+		},
+		{
+			name:  "Parentheses override precedence",
+			query: "(ti=5g OR ab=5g) AND pa=apple",
+			want: &CQLNode{Op: "AND", Children: []*CQLNode{
+				{Op: "OR", Children: []*CQLNode{leaf("ti", "5g"), leaf("ab", "5g")}},
+				leaf("pa", "apple"),
+			}},
+		},
+		{
+			name:  "Same operator chains are flattened",
+			query: "ti=a and ti=b AND ti=c",
+			want: &CQLNode{Op: "AND", Children: []*CQLNode{
+				leaf("ti", "a"), leaf("ti", "b"), leaf("ti", "c"),
+			}},
+		},
+		{
+			name:  "Proximity operator with distance",
+			query: "ab=wireless prox/5 antenna AND pa=nokia",
+			want: &CQLNode{Op: "AND", Children: []*CQLNode{
+				{Op: "PROX/5", Children: []*CQLNode{leaf("ab", "wireless"), {Value: "antenna"}}},
+				leaf("pa", "nokia"),
+			}},
+		},
+		{
+			name:  "Nested groups",
+			query: "((ti=wireless OR ti=radio) AND pa=qualcomm) OR (ic=H04B AND in=smith)",
+			want: &CQLNode{Op: "OR", Children: []*CQLNode{
+				{Op: "AND", Children: []*CQLNode{
+					{Op: "OR", Children: []*CQLNode{leaf("ti", "wireless"), leaf("ti", "radio")}},
+					leaf("pa", "qualcomm"),
+				}},
+				{Op: "AND", Children: []*CQLNode{leaf("ic", "H04B"), leaf("in", "smith")}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseCQL(tt.query)
+			if err != nil {
+				t.Fatalf("ParseCQL() error = %v", err)
+			}
+
+			got, err := q.AST()
+			if err != nil {
+				t.Fatalf("AST() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AST() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCQLNode_StringRoundTrip(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"ti=bluetooth", "ti=bluetooth"},
+		{`pa="Apple Inc" AND pd<=20201231`, `pa="Apple Inc" AND pd<=20201231`},
+		{"(ti=5g OR ab=5g) AND (pa=apple OR pa=samsung)", "(ti=5g OR ab=5g) AND (pa=apple OR pa=samsung)"},
+		{"ti=a OR (ti=b AND pa=c)", "ti=a OR ti=b AND pa=c"},
+		{"(ti=a AND ti=b) AND ti=c", "ti=a AND ti=b AND ti=c"},
+		{"ti=a NOT (ti=b NOT ti=c)", "ti=a NOT (ti=b NOT ti=c)"},
+		{"((ti=wireless OR ti=radio) AND pa=qualcomm) OR ic=H04B", "(ti=wireless OR ti=radio) AND pa=qualcomm OR ic=H04B"},
+		{`ta="mobile phone" NEAR/3 battery`, `ta="mobile phone" NEAR/3 battery`},
+		{`ti="the \"smart\" phone" OR ti="C:\\path"`, `ti="the \"smart\" phone" OR ti="C:\\path"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseCQL(tt.query)
+			if err != nil {
+				t.Fatalf("ParseCQL() error = %v", err)
+			}
+			root, err := q.AST()
+			if err != nil {
+				t.Fatalf("AST() error = %v", err)
+			}
+
+			rendered := root.String()
+			if rendered != tt.want {
+				t.Errorf("String() = %q, want %q", rendered, tt.want)
+			}
+
+			// The rendered query parses back to the same tree
+			q2, err := ParseCQL(rendered)
+			if err != nil {
+				t.Fatalf("ParseCQL(rendered) error = %v", err)
+			}
+			root2, err := q2.AST()
+			if err != nil {
+				t.Fatalf("AST() of rendered query error = %v", err)
+			}
+			if !reflect.DeepEqual(root, root2) {
+				t.Errorf("Round trip changed the tree: %s -> %s", root, root2)
+			}
+		})
+	}
+}
+
+func TestCQLNode_Transform(t *testing.T) {
+	q, err := ParseCQL("ti=bluetooth OR ab=bluetooth")
+	if err != nil {
+		t.Fatalf("ParseCQL() error = %v", err)
+	}
+	root, err := q.AST()
+	if err != nil {
+		t.Fatalf("AST() error = %v", err)
+	}
+
+	// Inject a date filter around the whole query
+	filtered := &CQLNode{Op: "AND", Children: []*CQLNode{
+		root,
+		{Op: ">=", Field: "pd", Value: "20200101"},
+	}}
+
+	want := "(ti=bluetooth OR ab=bluetooth) AND pd>=20200101"
+	if got := filtered.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCQLNode_StringBuiltTrees(t *testing.T) {
+	leaf := func(value string) *CQLNode { return &CQLNode{Op: "=", Field: "ti", Value: value} }
+
+	tests := []struct {
+		name string
+		node *CQLNode
+		want string
+	}{
+		{
+			name: "Non-associative right operand",
+			node: &CQLNode{Op: "NOT", Children: []*CQLNode{leaf("a"), {Op: "NOT", Children: []*CQLNode{leaf("b"), leaf("c")}}}},
+			want: "ti=a NOT (ti=b NOT ti=c)",
+		},
+		{
+			name: "Proximity right operand",
+			node: &CQLNode{Op: "PROX", Children: []*CQLNode{leaf("a"), {Op: "PROX", Children: []*CQLNode{leaf("b"), leaf("c")}}}},
+			want: "ti=a PROX (ti=b PROX ti=c)",
+		},
+		{
+			name: "Associative right operand",
+			node: &CQLNode{Op: "OR", Children: []*CQLNode{leaf("a"), {Op: "OR", Children: []*CQLNode{leaf("b"), leaf("c")}}}},
+			want: "ti=a OR ti=b OR ti=c",
+		},
+		{
+			name: "Lowercase operators",
+			node: &CQLNode{Op: "and", Children: []*CQLNode{leaf("a"), {Op: "or", Children: []*CQLNode{leaf("b"), leaf("c")}}}},
+			want: "ti=a AND (ti=b OR ti=c)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// A quote inside a value is escaped and cannot end the quoted term
+	value := `x" OR ti="y`
+	node := &CQLNode{Op: "AND", Children: []*CQLNode{leaf(value), leaf("z")}}
+	want := `ti="x\" OR ti=\"y" AND ti=z`
+	rendered := node.String()
+	if rendered != want {
+		t.Fatalf("String() = %q, want %q", rendered, want)
+	}
+	q, err := ParseCQL(rendered)
+	if err != nil {
+		t.Fatalf("ParseCQL() error = %v", err)
+	}
+	root, err := q.AST()
+	if err != nil {
+		t.Fatalf("AST() error = %v", err)
+	}
+	if !reflect.DeepEqual(root, node) {
+		t.Errorf("Round trip changed the tree: %s -> %s", node, root)
+	}
+}
+
+func TestCQLQuery_ASTErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantError string
+	}{
+		{"Invalid query", "invalidfield=value", "invalid field"},
+		{"Missing operator", "ti=a pa=b", "expected boolean operator"},
+		{"Trailing operator", "ti=a AND", "unexpected end of query"},
+		{"Leading operator", "AND ti=a", "unexpected 'AND'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseCQL(tt.query)
+			if err != nil {
+				t.Fatalf("ParseCQL() error = %v", err)
+			}
+
+			_, err = q.AST()
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("AST() error = %v, want error containing %q", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -1,7 +1,23 @@
 package cql
 
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Search endpoints with their own CQL field sets. The values match
+// ops.EndpointSearch and ops.EndpointRegister.
+const (
+	EndpointSearch   = "search"   // published-data search
+	EndpointRegister = "register" // EP Register search
+)
+
 // validFields maps CQL field names to their descriptions.
-// These are the official EPO OPS search fields as documented in the API specification.
+// These are the official EPO OPS search fields of the published-data search
+// as documented in the API specification.
 //
 // See: https://www.epo.org/searching-for-patents/data/web-services/ops.html
 var validFields = map[string]string{
@@ -43,6 +59,56 @@ var validFields = map[string]string{
 	"pc": "PCT contracting states",
 }
 
+// registerFields maps the CQL fields of the register search to their descriptions.
+// The register search uses the identifiers of the European Patent Register smart
+// search, which differ from the published-data fields (e.g. no abstract or full
+// text, but representative and opponent).
+//
+// See: https://register.epo.org/help?lng=en&topic=smartsearch
+var registerFields = map[string]string{
+	// Text fields
+	"ti": "title",
+
+	// Parties
+	"pa": "applicant name",
+	"in": "inventor name",
+	"re": "representative name",
+	"op": "opponent name",
+
+	// Numbers
+	"pn":  "publication number",
+	"ap":  "application number",
+	"pr":  "priority number",
+	"num": "any number (pn, ap, or pr)",
+
+	// Dates
+	"pd":  "publication date",
+	"ad":  "application date",
+	"prd": "priority date",
+
+	// Classifications
+	"ic": "IPC classification",
+}
+
+// endpointFields maps each search endpoint to its CQL fields.
+var endpointFields = map[string]map[string]string{
+	EndpointSearch:   validFields,
+	EndpointRegister: registerFields,
+}
+
+// endpointNames holds the names of the search endpoints used in error messages.
+var endpointNames = map[string]string{
+	EndpointSearch:   "published-data search",
+	EndpointRegister: "register search",
+}
+
+// dateFields lists the CQL fields whose values are dates in YYYYMMDD format.
+var dateFields = map[string]bool{
+	"pd":  true,
+	"ad":  true,
+	"prd": true,
+}
+
 // validOperators maps CQL operators to whether they're valid.
 // EPO OPS supports standard boolean operators and proximity operators.
 var validOperators = map[string]bool{
@@ -67,28 +133,206 @@ var validOperators = map[string]bool{
 	"with": true,
 }
 
-// IsValidField checks if a field name is valid in EPO CQL.
+// operators lists the CQL operators with their descriptions, boolean operators first.
+// validOperators accepts each in upper and lower case.
+var operators = []struct {
+	name        string
+	description string
+}{
+	{"AND", "both operands match"},
+	{"OR", "either operand matches"},
+	{"NOT", "the left operand matches and the right one does not"},
+	{"PROX", "proximity, within N words (PROX/N)"},
+	{"ADJ", "proximity, adjacent in the given order"},
+	{"NEAR", "proximity, order-independent"},
+	{"WITH", "proximity, within the same field"},
+}
+
+// IsValidField checks if a field name is valid in EPO CQL for any search endpoint.
+// Use ValidateForEndpoint to check a query against the fields of one endpoint.
 func IsValidField(field string) bool {
-	_, ok := validFields[field]
-	return ok
+	for _, fields := range endpointFields {
+		if _, ok := fields[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// classificationFields lists the CQL fields whose values are IPC or CPC symbols.
+var classificationFields = map[string]bool{
+	"ic":  true,
+	"cpc": true,
+}
+
+// classificationPattern matches IPC/CPC symbols down to any level, so truncated symbols
+// for broad searches are accepted: section (H), class (H04), subclass (H04W), main group
+// (H04W84) or group (H04W84/18, "H04W 84/18" when quoted), optionally ending in a "*"
+// wildcard.
+var classificationPattern = regexp.MustCompile(`(?i)^[A-HY](\d{2}([A-Z](\s*\d{1,4}(\s*/\s*\d{1,6})?)?)?)?\*?$`)
+
+// IsClassificationField checks if a field takes an IPC or CPC symbol (ic, cpc).
+func IsClassificationField(field string) bool {
+	return classificationFields[field]
+}
+
+// IsDateField checks if a field takes a YYYYMMDD date value (pd, ad, prd).
+func IsDateField(field string) bool {
+	return dateFields[field]
+}
+
+// proximityOperators are the operators that take an optional /N distance modifier
+// (e.g., NEAR/3: at most 3 words apart).
+var proximityOperators = map[string]bool{
+	"PROX": true,
+	"ADJ":  true,
+	"NEAR": true,
+	"WITH": true,
 }
 
 // IsValidOperator checks if an operator is valid in EPO CQL.
+// Proximity operators are also valid with a distance modifier (e.g., "PROX/5").
 func IsValidOperator(op string) bool {
-	return validOperators[op]
+	if validOperators[op] {
+		return true
+	}
+	if _, _, hasModifier := strings.Cut(op, "/"); hasModifier && IsProximityOperator(op) {
+		_, err := ProximityDistance(op)
+		return err == nil
+	}
+	return false
+}
+
+// IsProximityOperator checks if op is a proximity operator (PROX, ADJ, NEAR, WITH,
+// case-insensitive), with or without a distance modifier. The modifier itself is
+// not checked; use ProximityDistance for that.
+func IsProximityOperator(op string) bool {
+	name, _, _ := strings.Cut(op, "/")
+	return proximityOperators[strings.ToUpper(name)]
+}
+
+// ProximityDistance returns the distance N of a proximity operator written as OP/N
+// (e.g., 5 for "PROX/5"), or 0 if it has no modifier. N must be a positive integer.
+func ProximityDistance(op string) (int, error) {
+	_, modifier, hasModifier := strings.Cut(op, "/")
+	if !hasModifier {
+		return 0, nil
+	}
+	if modifier == "" {
+		return 0, fmt.Errorf("missing distance after '/'")
+	}
+	distance, err := strconv.Atoi(modifier)
+	if err != nil || distance < 1 || strings.HasPrefix(modifier, "+") {
+		return 0, fmt.Errorf("distance must be a positive integer, got '%s'", modifier)
+	}
+	return distance, nil
 }
 
 // GetFieldDescription returns the description of a CQL field.
+// Published-data descriptions take precedence for fields shared with the register search.
 // Returns empty string if the field is not valid.
 func GetFieldDescription(field string) string {
-	return validFields[field]
+	if description, ok := validFields[field]; ok {
+		return description
+	}
+	return registerFields[field]
 }
 
-// GetValidFields returns a slice of all valid field names.
+// GetOperatorDescription returns the description of a CQL operator, matched
+// case-insensitively and ignoring a distance modifier ("near/3" is described as NEAR).
+// Returns empty string if the operator is not valid.
+func GetOperatorDescription(op string) string {
+	name, _, _ := strings.Cut(op, "/")
+	for _, operator := range operators {
+		if strings.EqualFold(operator.name, name) {
+			return operator.description
+		}
+	}
+	return ""
+}
+
+// GetValidOperators returns the uppercase names of all CQL operators: the boolean
+// operators AND, OR and NOT, followed by the proximity operators.
+func GetValidOperators() []string {
+	names := make([]string, len(operators))
+	for i, operator := range operators {
+		names[i] = operator.name
+	}
+	return names
+}
+
+// GetValidFields returns a slice of all valid field names across all search endpoints.
 func GetValidFields() []string {
-	fields := make([]string, 0, len(validFields))
-	for field := range validFields {
+	seen := make(map[string]bool)
+	fields := make([]string, 0, len(validFields)+len(registerFields))
+	for _, endpoint := range endpointFields {
+		for field := range endpoint {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// FieldsForEndpoint returns the sorted CQL field names accepted by a search endpoint
+// (EndpointSearch or EndpointRegister). Returns nil for unknown endpoints.
+func FieldsForEndpoint(endpoint string) []string {
+	endpointMap, ok := endpointFields[endpoint]
+	if !ok {
+		return nil
+	}
+	fields := make([]string, 0, len(endpointMap))
+	for field := range endpointMap {
 		fields = append(fields, field)
 	}
+	sort.Strings(fields)
 	return fields
 }
+
+// ValidateForEndpoint parses and validates a CQL query and checks that every field
+// it uses is accepted by the given search endpoint (EndpointSearch or EndpointRegister).
+//
+// The published-data and register searches accept different fields, and EPO answers
+// a field of the other endpoint with an unspecific CQL error. For example, "re"
+// (representative) is valid for the register search only:
+//
+//	err := cql.ValidateForEndpoint("re=smith", cql.EndpointSearch) // error
+//	err = cql.ValidateForEndpoint("re=smith", cql.EndpointRegister) // nil
+func ValidateForEndpoint(query string, endpoint string) error {
+	endpointMap, ok := endpointFields[endpoint]
+	if !ok {
+		return fmt.Errorf("unknown CQL search endpoint '%s' (valid endpoints: %s, %s)",
+			endpoint, EndpointSearch, EndpointRegister)
+	}
+
+	q, err := ParseCQL(query)
+	if err != nil {
+		return err
+	}
+	if err := q.Validate(); err != nil {
+		return err
+	}
+
+	var errs []string
+	for i, token := range q.Tokens {
+		if i+1 < len(q.Tokens) && q.Tokens[i+1].Type == TokenEquals {
+			if _, ok := endpointMap[token.Value]; !ok {
+				errs = append(errs, fmt.Sprintf(
+					"field '%s' at position %d is not valid for %s (valid fields: %s)",
+					token.Value, token.Pos, endpointNames[endpoint], strings.Join(FieldsForEndpoint(endpoint), ", "),
+				))
+			}
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("CQL validation error: %s", errs[0])
+	default:
+		return fmt.Errorf("CQL validation errors: %s", strings.Join(errs, "; "))
+	}
+}
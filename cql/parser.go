@@ -5,6 +5,9 @@ import (
 	"net/url"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/patent-dev/epo-ops/internal/dateutil"
 )
 
 // CQLQuery represents a parsed CQL query.
@@ -68,6 +71,11 @@ func (t TokenType) String() string {
 	}
 }
 
+// MaxQueryLength is the longest CQL query, in characters, that EPO accepts. Longer
+// queries, typically generated ones such as OR-ing hundreds of publication numbers,
+// are rejected by ParseCQL instead of failing at EPO with an unspecific error.
+const MaxQueryLength = 2000
+
 // ParseCQL parses a CQL query string and returns a CQLQuery object.
 //
 // Example queries:
@@ -78,11 +86,14 @@ func (t TokenType) String() string {
 //
 // Returns:
 //   - *CQLQuery: The parsed query with tokens and validation status
-//   - error: An error if the query is completely invalid or empty
+//   - error: An error if the query is completely invalid, empty or longer than MaxQueryLength
 func ParseCQL(query string) (*CQLQuery, error) {
 	if strings.TrimSpace(query) == "" {
 		return nil, fmt.Errorf("CQL query cannot be empty")
 	}
+	if n := utf8.RuneCountInString(query); n > MaxQueryLength {
+		return nil, fmt.Errorf("CQL query is %d characters long, EPO accepts at most %d", n, MaxQueryLength)
+	}
 
 	q := &CQLQuery{
 		Raw:    query,
@@ -109,6 +120,15 @@ func tokenize(query string) []CQLToken {
 		ch := rune(query[i])
 
 		switch {
+		case ch == '\\' && inQuotes && i+1 < len(query) && (query[i+1] == '"' || query[i+1] == '\\'):
+			// Escaped quote or backslash inside a quoted term; kept as written
+			if current.Len() == 0 {
+				pos = i
+			}
+			current.WriteByte(query[i])
+			current.WriteByte(query[i+1])
+			i++
+
 		case ch == '"':
 			if current.Len() > 0 {
 				tokens = append(tokens, CQLToken{
@@ -203,8 +223,9 @@ func tokenize(query string) []CQLToken {
 
 // classifyToken determines the type of a token based on its value.
 func classifyToken(value string) TokenType {
-	// Check if it's an operator
-	if IsValidOperator(value) || IsValidOperator(strings.ToUpper(value)) {
+	// Check if it's an operator. Proximity operators with a malformed distance
+	// (e.g., NEAR/abc) are still operators, so that validation can report them.
+	if IsValidOperator(value) || IsValidOperator(strings.ToUpper(value)) || IsProximityOperator(value) {
 		return TokenOperator
 	}
 
@@ -216,6 +237,7 @@ func classifyToken(value string) TokenType {
 func (q *CQLQuery) validate() {
 	q.checkBracketMatching()
 	q.checkFieldNames()
+	q.checkProximityOperators()
 	q.checkQueryStructure()
 
 	if len(q.Errors) > 0 {
@@ -243,7 +265,10 @@ func (q *CQLQuery) checkBracketMatching() {
 	}
 }
 
-// checkFieldNames validates that all field names are recognized EPO fields.
+// checkFieldNames validates that all field names are recognized EPO fields,
+// that date fields (pd, ad, prd) carry real calendar dates in YYYYMMDD format and
+// that classification fields (ic, cpc) carry IPC/CPC symbols.
+// Date fields accept all comparison operators (=, <, >, <=, >=).
 func (q *CQLQuery) checkFieldNames() {
 	for i, token := range q.Tokens {
 		// Check if this is a field name (token before '=')
@@ -255,11 +280,95 @@ func (q *CQLQuery) checkFieldNames() {
 					token.Pos,
 					strings.Join([]string{"ti", "ab", "pa", "in", "pn", "ic", "cpc", "pd", "ad"}, ", "),
 				))
+			} else if IsDateField(token.Value) {
+				q.checkDateValue(token.Value, i+2)
+			} else if IsClassificationField(token.Value) {
+				q.checkClassificationValue(token.Value, i+2)
 			}
 		}
 	}
 }
 
+// checkDateValue validates the value token of a date field comparison starting
+// at index i. Quoted values (pd="20200101") are unwrapped first.
+func (q *CQLQuery) checkDateValue(field string, i int) {
+	if i < len(q.Tokens) && q.Tokens[i].Type == TokenQuote {
+		i++
+	}
+	if i >= len(q.Tokens) || q.Tokens[i].Type != TokenValue {
+		q.Errors = append(q.Errors, fmt.Sprintf("missing date value for field '%s'", field))
+		return
+	}
+
+	value := q.Tokens[i]
+	if err := dateutil.CheckYYYYMMDD(value.Value); err != nil {
+		q.Errors = append(q.Errors, fmt.Sprintf(
+			"invalid date '%s' for field '%s' at position %d: %v (expected YYYYMMDD, e.g. 20200101)",
+			value.Value, field, value.Pos, err,
+		))
+	}
+}
+
+// checkProximityOperators validates the distance modifiers of proximity operators
+// (e.g., PROX/5) and that each proximity operator sits between two values, as in
+// ab=wireless PROX/5 antenna.
+func (q *CQLQuery) checkProximityOperators() {
+	for i, token := range q.Tokens {
+		if token.Type == TokenValue && strings.HasPrefix(token.Value, "/") {
+			q.Errors = append(q.Errors, fmt.Sprintf(
+				"distance modifier '%s' at position %d must directly follow a proximity operator (e.g., NEAR%s)",
+				token.Value, token.Pos, token.Value,
+			))
+			continue
+		}
+		if token.Type != TokenOperator || !IsProximityOperator(token.Value) {
+			continue
+		}
+
+		if _, err := ProximityDistance(token.Value); err != nil {
+			q.Errors = append(q.Errors, fmt.Sprintf(
+				"invalid proximity operator '%s' at position %d: %v (e.g., NEAR/3)",
+				token.Value, token.Pos, err,
+			))
+		}
+		if !q.isValueOperand(i-1) || !q.isValueOperand(i+1) {
+			q.Errors = append(q.Errors, fmt.Sprintf(
+				"proximity operator '%s' at position %d must be between two values (e.g., ab=wireless %s antenna)",
+				token.Value, token.Pos, token.Value,
+			))
+		}
+	}
+}
+
+// isValueOperand reports whether the token at index i is a plain value or the quote
+// of a quoted value.
+func (q *CQLQuery) isValueOperand(i int) bool {
+	if i < 0 || i >= len(q.Tokens) {
+		return false
+	}
+	return q.Tokens[i].Type == TokenValue || q.Tokens[i].Type == TokenQuote
+}
+
+// checkClassificationValue validates the value token of a classification field
+// starting at index i. Quoted values (cpc="H04W 84/18") are unwrapped first; other
+// operands such as parenthesized lists are not checked.
+func (q *CQLQuery) checkClassificationValue(field string, i int) {
+	if i < len(q.Tokens) && q.Tokens[i].Type == TokenQuote {
+		i++
+	}
+	if i >= len(q.Tokens) || q.Tokens[i].Type != TokenValue {
+		return
+	}
+
+	value := q.Tokens[i]
+	if !classificationPattern.MatchString(value.Value) {
+		q.Errors = append(q.Errors, fmt.Sprintf(
+			"invalid classification symbol '%s' for field '%s' at position %d (expected e.g. H04W, H04W84 or H04W84/18)",
+			value.Value, field, value.Pos,
+		))
+	}
+}
+
 // checkQueryStructure validates the overall structure of the query.
 func (q *CQLQuery) checkQueryStructure() {
 	if len(q.Tokens) == 0 {
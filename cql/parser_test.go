@@ -1,6 +1,7 @@
 package cql
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -54,6 +55,24 @@ func TestParseCQL_ValidQueries(t *testing.T) {
 			wantValid:  true,
 			wantTokens: 3,
 		},
+		{
+			name:       "Date upper bound",
+			query:      "pd<=20201231",
+			wantValid:  true,
+			wantTokens: 3,
+		},
+		{
+			name:       "Quoted priority date",
+			query:      "prd>\"19991231\"",
+			wantValid:  true,
+			wantTokens: 5, // prd, >, ", 19991231, "
+		},
+		{
+			name:       "Leap day publication date",
+			query:      "pd=20240229",
+			wantValid:  true,
+			wantTokens: 3,
+		},
 		{
 			name:       "IPC classification",
 			query:      "ic=H04W",
@@ -66,6 +85,18 @@ func TestParseCQL_ValidQueries(t *testing.T) {
 			wantValid:  true,
 			wantTokens: 3,
 		},
+		{
+			name:       "Quoted CPC group with space",
+			query:      "cpc=\"H04W 84/18\"",
+			wantValid:  true,
+			wantTokens: 5,
+		},
+		{
+			name:       "Truncated classifications",
+			query:      "ic=H04 OR cpc=h04w84 OR cpc=Y02E10/5* OR ic=G",
+			wantValid:  true,
+			wantTokens: 15,
+		},
 		{
 			name:       "Nested parentheses",
 			query:      "((ti=5g OR ti=lte) AND (pa=apple OR pa=samsung))",
@@ -102,6 +133,24 @@ func TestParseCQL_ValidQueries(t *testing.T) {
 			wantValid:  true,
 			wantTokens: 1,
 		},
+		{
+			name:       "Proximity operator with distance",
+			query:      "ab=wireless PROX/5 antenna",
+			wantValid:  true,
+			wantTokens: 5, // ab, =, wireless, PROX/5, antenna
+		},
+		{
+			name:       "Lowercase proximity operator with quoted phrase",
+			query:      "ta=\"mobile phone\" near/3 battery",
+			wantValid:  true,
+			wantTokens: 7, // ta, =, ", mobile phone, ", near/3, battery
+		},
+		{
+			name:       "Proximity operator without distance",
+			query:      "ab=wireless ADJ antenna AND pd>=20200101",
+			wantValid:  true,
+			wantTokens: 9,
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +197,11 @@ func TestParseCQL_InvalidQueries(t *testing.T) {
 			query:     "   ",
 			wantError: "cannot be empty",
 		},
+		{
+			name:      "Over-length query",
+			query:     "pn=EP1000000" + strings.Repeat(" OR pn=EP1000000", 200),
+			wantError: "EPO accepts at most 2000",
+		},
 		{
 			name:      "Invalid field name",
 			query:     "invalidfield=value",
@@ -168,6 +222,86 @@ func TestParseCQL_InvalidQueries(t *testing.T) {
 			query:     "((ti=bluetooth",
 			wantError: "unclosed parentheses",
 		},
+		{
+			name:      "Leap day in non-leap year",
+			query:     "ti=bluetooth AND pd=20230229",
+			wantError: "day must be between 01 and 28",
+		},
+		{
+			name:      "Invalid application date month",
+			query:     "ad>=20231301",
+			wantError: "month must be between 01 and 12",
+		},
+		{
+			name:      "Bare year date value",
+			query:     "pd>=2020",
+			wantError: "invalid date '2020' for field 'pd'",
+		},
+		{
+			name:      "Bare year in quoted priority date",
+			query:     "prd<=\"2020\"",
+			wantError: "invalid date '2020' for field 'prd'",
+		},
+		{
+			name:      "Missing date value",
+			query:     "pd=",
+			wantError: "missing date value for field 'pd'",
+		},
+		{
+			name:      "Malformed CPC symbol",
+			query:     "ti=wireless AND cpc=H04WXYZ",
+			wantError: "invalid classification symbol 'H04WXYZ' for field 'cpc' at position 20",
+		},
+		{
+			name:      "IPC symbol without class digits",
+			query:     "ic=HXX",
+			wantError: "invalid classification symbol 'HXX' for field 'ic'",
+		},
+		{
+			name:      "CPC group with empty subgroup",
+			query:     "cpc=\"H04W 84/\"",
+			wantError: "invalid classification symbol 'H04W 84/'",
+		},
+		{
+			name:      "Non-numeric proximity distance",
+			query:     "ab=wireless NEAR/abc antenna",
+			wantError: "invalid proximity operator 'NEAR/abc' at position 12: distance must be a positive integer, got 'abc'",
+		},
+		{
+			name:      "Zero proximity distance",
+			query:     "ab=wireless PROX/0 antenna",
+			wantError: "distance must be a positive integer, got '0'",
+		},
+		{
+			name:      "Negative proximity distance",
+			query:     "ab=wireless prox/-2 antenna",
+			wantError: "distance must be a positive integer, got '-2'",
+		},
+		{
+			name:      "Missing proximity distance",
+			query:     "ab=wireless NEAR/ antenna",
+			wantError: "missing distance after '/'",
+		},
+		{
+			name:      "Bare distance modifier",
+			query:     "ab=wireless NEAR /3 antenna",
+			wantError: "distance modifier '/3' at position 17 must directly follow a proximity operator",
+		},
+		{
+			name:      "Proximity operator before a search clause",
+			query:     "ti=wireless PROX/3 ab=antenna",
+			wantError: "proximity operator 'PROX/3' at position 12 must be between two values",
+		},
+		{
+			name:      "Proximity operator at end of query",
+			query:     "ab=wireless ADJ/2",
+			wantError: "must be between two values",
+		},
+		{
+			name:      "Proximity operator after parenthesis",
+			query:     "(ab=wireless OR ab=radio) NEAR antenna",
+			wantError: "proximity operator 'NEAR' at position 26 must be between two values",
+		},
 	}
 
 	for _, tt := range tests {
@@ -236,6 +370,19 @@ func TestIsValidField(t *testing.T) {
 	}
 }
 
+func TestIsDateField(t *testing.T) {
+	for _, field := range []string{"pd", "ad", "prd"} {
+		if !IsDateField(field) {
+			t.Errorf("IsDateField(%q) = false, want true", field)
+		}
+	}
+	for _, field := range []string{"ti", "pn", "invalid"} {
+		if IsDateField(field) {
+			t.Errorf("IsDateField(%q) = true, want false", field)
+		}
+	}
+}
+
 func TestIsValidOperator(t *testing.T) {
 	tests := []struct {
 		op   string
@@ -252,6 +399,11 @@ func TestIsValidOperator(t *testing.T) {
 		{"ADJ", true},
 		{"NEAR", true},
 		{"WITH", true},
+		{"PROX/5", true},
+		{"near/3", true},
+		{"NEAR/abc", false},
+		{"NEAR/", false},
+		{"AND/3", false},
 		{"INVALID", false},
 		{"", false},
 	}
@@ -479,6 +631,50 @@ func TestGetValidFields(t *testing.T) {
 	}
 }
 
+func TestGetOperatorDescription(t *testing.T) {
+	tests := []struct {
+		op   string
+		want string
+	}{
+		{"AND", "both operands match"},
+		{"or", "either operand matches"},
+		{"NOT", "the left operand matches and the right one does not"},
+		{"NEAR", "proximity, order-independent"},
+		{"near/3", "proximity, order-independent"},
+		{"PROX", "proximity, within N words (PROX/N)"},
+		{"XOR", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			got := GetOperatorDescription(tt.op)
+			if got != tt.want {
+				t.Errorf("GetOperatorDescription(%q) = %q, want %q", tt.op, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetValidOperators(t *testing.T) {
+	operators := GetValidOperators()
+
+	want := []string{"AND", "OR", "NOT", "PROX", "ADJ", "NEAR", "WITH"}
+	if !reflect.DeepEqual(operators, want) {
+		t.Errorf("GetValidOperators() = %v, want %v", operators, want)
+	}
+
+	// Every listed operator is valid and described
+	for _, op := range operators {
+		if !IsValidOperator(op) || !IsValidOperator(strings.ToLower(op)) {
+			t.Errorf("GetValidOperators() lists %q, which IsValidOperator rejects", op)
+		}
+		if GetOperatorDescription(op) == "" {
+			t.Errorf("GetValidOperators() lists %q without description", op)
+		}
+	}
+}
+
 func TestComplexQueries(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -528,3 +724,73 @@ func TestComplexQueries(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateForEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		endpoint  string
+		wantError string
+	}{
+		{name: "Published-data field", query: "ti=battery AND txt=lithium", endpoint: EndpointSearch},
+		{name: "Register field", query: "re=smith AND pa=siemens", endpoint: EndpointRegister},
+		{name: "Shared date field", query: "pd>=20200101", endpoint: EndpointRegister},
+		{name: "Register field in published-data search", query: "ti=battery AND re=smith", endpoint: EndpointSearch, wantError: "'re' at position 15 is not valid for published-data search"},
+		{name: "Full text in register search", query: "txt=lithium", endpoint: EndpointRegister, wantError: "not valid for register search"},
+		{name: "Unknown field", query: "foo=bar", endpoint: EndpointRegister, wantError: "invalid field"},
+		{name: "Unknown endpoint", query: "ti=battery", endpoint: "images", wantError: "unknown CQL search endpoint"},
+		{name: "Empty query", query: "", endpoint: EndpointSearch, wantError: "cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateForEndpoint(tt.query, tt.endpoint)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("ValidateForEndpoint(%q, %q) error = %v", tt.query, tt.endpoint, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("ValidateForEndpoint(%q, %q) error = %v, want error containing %q", tt.query, tt.endpoint, err, tt.wantError)
+			}
+		})
+	}
+
+	// A register-only field is still valid CQL in general
+	if !IsValidField("re") {
+		t.Error("IsValidField(\"re\") = false, want true")
+	}
+}
+
+func TestFieldsForEndpoint(t *testing.T) {
+	search := FieldsForEndpoint(EndpointSearch)
+	register := FieldsForEndpoint(EndpointRegister)
+	if len(search) == 0 || len(register) == 0 {
+		t.Fatalf("FieldsForEndpoint returned empty lists: search=%v register=%v", search, register)
+	}
+
+	contains := func(fields []string, field string) bool {
+		for _, f := range fields {
+			if f == field {
+				return true
+			}
+		}
+		return false
+	}
+	if !contains(search, "txt") || contains(search, "re") {
+		t.Errorf("Published-data fields: %v", search)
+	}
+	if !contains(register, "re") || contains(register, "txt") {
+		t.Errorf("Register fields: %v", register)
+	}
+	for i := 1; i < len(register); i++ {
+		if register[i-1] > register[i] {
+			t.Errorf("FieldsForEndpoint not sorted: %v", register)
+			break
+		}
+	}
+	if fields := FieldsForEndpoint("unknown"); fields != nil {
+		t.Errorf("FieldsForEndpoint(unknown) = %v, want nil", fields)
+	}
+}
@@ -46,11 +46,11 @@ func demoFamily(demo *DemoContext) {
 		}))
 
 	// 2. GetFamilyWithBiblio (family + bibliographic data)
-	// Note: Using Raw version for demo. Parsed version returns *FamilyData
+	// Note: Using Raw version for demo. GetFamilyWithBiblioParsed returns *FamilyBiblioData
 	runEndpoint(demo, "get_family_with_biblio", "GetFamilyWithBiblio",
 		func() ([]byte, error) {
-			// For parsed data: demo.Client.GetFamilyWithBiblio() returns *FamilyData
-			result, err := demo.Client.GetFamilyRaw(demo.Ctx, ops.RefTypePublication, ops.FormatDocDB, demo.Patent)
+			// For parsed data: demo.Client.GetFamilyWithBiblioParsed() returns *FamilyBiblioData
+			result, err := demo.Client.GetFamilyWithBiblioRaw(demo.Ctx, ops.RefTypePublication, ops.FormatDocDB, demo.Patent)
 			return []byte(result), err
 		},
 		FormatRequestDescription("GetFamilyWithBiblio", map[string]string{
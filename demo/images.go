@@ -20,17 +20,15 @@ func demoImages(demo *DemoContext) {
 		return
 	}
 
-	// 1. GetImage - thumbnail (drawings, page 1)
-	runEndpoint(demo, "get_image_thumbnail", "GetImage (thumbnail, page 1)",
+	// 1. GetImageThumbnail - drawings thumbnail (page 1)
+	runEndpoint(demo, "get_image_thumbnail", "GetImageThumbnail",
 		func() ([]byte, error) {
-			return demo.Client.GetImage(demo.Ctx, parts.Country, parts.Number, parts.Kind, ops.ImageTypeThumbnail, 1)
+			return demo.Client.GetImageThumbnail(demo.Ctx, parts.Country, parts.Number, parts.Kind)
 		},
-		FormatRequestDescription("GetImage", map[string]string{
-			"country":   parts.Country,
-			"number":    parts.Number,
-			"kind":      parts.Kind,
-			"imageType": ops.ImageTypeThumbnail,
-			"page":      "1",
+		FormatRequestDescription("GetImageThumbnail", map[string]string{
+			"country": parts.Country,
+			"number":  parts.Number,
+			"kind":    parts.Kind,
 		}))
 
 	// 2. GetImagePOST (POST method - keeps identifier encrypted in body)
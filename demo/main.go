@@ -252,11 +252,11 @@ func runEndpoint(demo *DemoContext, name, description string, fn func() ([]byte,
 	var validationErr error
 	switch format {
 	case FormatXML:
-		validationErr = ValidateXML(data)
+		validationErr = ops.ValidateXML(data)
 	case FormatJSON:
-		validationErr = ValidateJSON(data)
+		validationErr = ops.ValidateJSON(data)
 	case FormatTIFF:
-		validationErr = ValidateTIFF(data)
+		validationErr = ops.ValidateTIFF(data)
 	}
 
 	if validationErr != nil {
@@ -1,11 +1,12 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	ops "github.com/patent-dev/epo-ops"
 )
 
 // FileFormat represents the format of a file
@@ -57,29 +58,21 @@ func (s *ExampleSaver) SaveExample(endpointName string, requestDesc string, resp
 	return nil
 }
 
-// DetectFormat detects the format of data
+// DetectFormat detects the format of data.
+// XML, JSON and TIFF detection is delegated to ops.DetectFormat; image formats
+// not covered by the library (PNG, JPEG, GIF) are recognized for file naming.
 func DetectFormat(data []byte) FileFormat {
-	if len(data) < 4 {
-		return FormatBinary
-	}
-
-	// Check for XML
-	if strings.HasPrefix(string(data), "<?xml") || strings.HasPrefix(string(data), "<") {
+	switch ops.DetectFormat(data) {
+	case ops.PayloadXML:
 		return FormatXML
+	case ops.PayloadJSON:
+		return FormatJSON
+	case ops.PayloadTIFF:
+		return FormatTIFF
 	}
 
-	// Check for JSON
-	trimmed := strings.TrimSpace(string(data))
-	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
-		if json.Valid(data) {
-			return FormatJSON
-		}
-	}
-
-	// Check for TIFF (little-endian or big-endian)
-	if (data[0] == 'I' && data[1] == 'I' && data[2] == 42 && data[3] == 0) ||
-		(data[0] == 'M' && data[1] == 'M' && data[2] == 0 && data[3] == 42) {
-		return FormatTIFF
+	if len(data) < 4 {
+		return FormatBinary
 	}
 
 	// Check for PNG
@@ -110,35 +103,3 @@ func FormatRequestDescription(method string, params map[string]string) string {
 	}
 	return sb.String()
 }
-
-// ValidateXML performs basic XML validation
-func ValidateXML(data []byte) error {
-	str := string(data)
-	if !strings.Contains(str, "<") || !strings.Contains(str, ">") {
-		return fmt.Errorf("data does not appear to be XML")
-	}
-	return nil
-}
-
-// ValidateJSON performs basic JSON validation
-func ValidateJSON(data []byte) error {
-	if !json.Valid(data) {
-		return fmt.Errorf("invalid JSON data")
-	}
-	return nil
-}
-
-// ValidateTIFF performs basic TIFF validation
-func ValidateTIFF(data []byte) error {
-	if len(data) < 4 {
-		return fmt.Errorf("data too short to be TIFF")
-	}
-
-	// Check TIFF magic numbers
-	if !((data[0] == 'I' && data[1] == 'I' && data[2] == 42 && data[3] == 0) ||
-		(data[0] == 'M' && data[1] == 'M' && data[2] == 0 && data[3] == 42)) {
-		return fmt.Errorf("invalid TIFF magic number")
-	}
-
-	return nil
-}
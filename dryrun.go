@@ -0,0 +1,85 @@
+package epo_ops
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// PreparedRequest describes an API request as the client would send it.
+type PreparedRequest struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    string
+}
+
+// dryRunAuthorization replaces the bearer token in described requests, so no token is requested.
+const dryRunAuthorization = "Bearer <access-token>"
+
+// errDryRun aborts a described request in place of the network round trip.
+var errDryRun = errors.New("dry run: request not sent")
+
+// dryRunKey is the context key under which DescribeRequest passes its capture to authTransport.
+type dryRunKey struct{}
+
+// dryRunCapture receives the first request issued during DescribeRequest.
+type dryRunCapture struct {
+	request *PreparedRequest
+}
+
+// DescribeRequest runs call in dry-run mode and returns the request it would have sent,
+// without contacting EPO, fetching an access token or using quota.
+//
+// call receives a context that must be passed to the client method to describe. The
+// request is captured after the Accept header, per-call options and Config.Interceptors
+// have been applied, so it shows exactly what would go over the wire; the access token
+// is replaced by a placeholder. Validation errors of the method are returned as is.
+// Config.Cache is bypassed. For methods issuing several requests (e.g., bulk methods),
+// only the first one is described.
+//
+// Example:
+//
+//	req, err := client.DescribeRequest(ctx, func(ctx context.Context) error {
+//	    _, err := client.Search(ctx, `ti="wireless charging" and pa=samsung`, "1-25")
+//	    return err
+//	})
+//	fmt.Println(req.Method, req.URL, req.Headers.Get("Accept"))
+func (c *Client) DescribeRequest(ctx context.Context, call func(ctx context.Context) error) (*PreparedRequest, error) {
+	capture := &dryRunCapture{}
+	err := call(context.WithValue(ctx, dryRunKey{}, capture))
+	if capture.request != nil {
+		return capture.request, nil
+	}
+	if err == nil {
+		err = &ConfigError{Message: "dry run: call did not issue a request"}
+	}
+	return nil, err
+}
+
+// dryRunFromContext returns the dry-run capture stored in ctx (nil outside DescribeRequest).
+func dryRunFromContext(ctx context.Context) *dryRunCapture {
+	capture, _ := ctx.Value(dryRunKey{}).(*dryRunCapture)
+	return capture
+}
+
+// roundTrip records req instead of sending it and aborts the call with errDryRun.
+func (d *dryRunCapture) roundTrip(req *http.Request) (*http.Response, error) {
+	if d.request == nil {
+		prepared := &PreparedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			prepared.Body = string(body)
+		}
+		d.request = prepared
+	}
+	return nil, errDryRun
+}
@@ -0,0 +1,125 @@
+package epo_ops
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDescribeRequest(t *testing.T) {
+	// Neither the auth nor the API server may be contacted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request in dry run: %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        server.URL + "/3.2/rest-services",
+		AuthURL:        server.URL + "/auth/accesstoken",
+		Cache:          NewLRUCache(10),
+		Interceptors: []RequestInterceptor{
+			func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+				req.Header.Set("X-Trace", "on")
+				return next(req)
+			},
+		},
+	}
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("Biblio", func(t *testing.T) {
+		req, err := client.DescribeRequest(ctx, func(ctx context.Context) error {
+			_, err := client.GetBiblio(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1")
+			return err
+		})
+		if err != nil {
+			t.Fatalf("DescribeRequest failed: %v", err)
+		}
+
+		wantURL := server.URL + "/3.2/rest-services/published-data/publication/docdb/EP.1000000.B1/biblio"
+		if req.Method != http.MethodGet || req.URL != wantURL {
+			t.Errorf("Request: got %s %s, want GET %s", req.Method, req.URL, wantURL)
+		}
+		if got := req.Headers.Get("Accept"); got != "application/exchange+xml" {
+			t.Errorf("Accept: got %q, want %q", got, "application/exchange+xml")
+		}
+		if got := req.Headers.Get("Authorization"); got != dryRunAuthorization {
+			t.Errorf("Authorization: got %q, want placeholder", got)
+		}
+		if got := req.Headers.Get("X-Trace"); got != "on" {
+			t.Errorf("Interceptor header: got %q", got)
+		}
+		if client.config.Cache.(*LRUCache).Len() != 0 {
+			t.Error("Dry run must not populate the cache")
+		}
+	})
+
+	t.Run("Search query encoding and Accept override", func(t *testing.T) {
+		query := `ti="wireless charging" and pa=samsung`
+		req, err := client.DescribeRequest(ctx, func(ctx context.Context) error {
+			_, err := client.SearchRaw(ctx, query, "1-25")
+			return err
+		})
+		if err != nil {
+			t.Fatalf("DescribeRequest failed: %v", err)
+		}
+		parsed, err := url.Parse(req.URL)
+		if err != nil {
+			t.Fatalf("Invalid URL %q: %v", req.URL, err)
+		}
+		if got := parsed.Query().Get("q"); got != query {
+			t.Errorf("Query: got %q, want %q", got, query)
+		}
+		if got := req.Headers.Get("Accept"); got != "application/ops+xml" {
+			t.Errorf("Accept: got %q", got)
+		}
+
+		req, err = client.DescribeRequest(ctx, func(ctx context.Context) error {
+			_, err := client.GetClassificationStatisticsRaw(ctx, "H04W", WithAcceptOverride("application/json"))
+			return err
+		})
+		if err != nil {
+			t.Fatalf("DescribeRequest failed: %v", err)
+		}
+		if got := req.Headers.Get("Accept"); got != "application/json" {
+			t.Errorf("Accept override: got %q", got)
+		}
+	})
+
+	t.Run("Bulk POST body", func(t *testing.T) {
+		req, err := client.DescribeRequest(ctx, func(ctx context.Context) error {
+			_, err := client.GetBiblioMultiple(ctx, RefTypePublication, FormatDocDB,
+				[]string{"EP.1000000.B1", "EP.1000001.A1"})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("DescribeRequest failed: %v", err)
+		}
+		if req.Method != http.MethodPost {
+			t.Errorf("Method: got %s, want POST", req.Method)
+		}
+		if !strings.Contains(req.Body, "EP.1000000.B1") || !strings.Contains(req.Body, "EP.1000001.A1") {
+			t.Errorf("Body: got %q", req.Body)
+		}
+	})
+
+	t.Run("Validation errors are returned", func(t *testing.T) {
+		_, err := client.DescribeRequest(ctx, func(ctx context.Context) error {
+			_, err := client.GetBiblio(ctx, "invalid", FormatDocDB, "EP.1000000.B1")
+			return err
+		})
+		if err == nil || errors.Is(err, errDryRun) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
@@ -2,13 +2,64 @@ package epo_ops
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsRetryable reports whether retrying the failed operation may succeed.
+//
+// The wrap chain is searched for the first error with a Retryable() bool method
+// (all typed errors of this package); its answer is returned. Errors without one,
+// such as network errors, are classified like the client's internal retry logic:
+// timeouts and connection failures are retryable, everything else is not.
+//
+// Example:
+//
+//	biblio, err := client.GetBiblio(ctx, "publication", "docdb", "EP1000000B1")
+//	if err != nil && ops.IsRetryable(err) {
+//	    // Requeue the request for later
+//	}
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	return isRetryableError(err)
+}
+
+// AuthErrorKind tells what kind of authentication problem an AuthError reports.
+type AuthErrorKind string
+
+// Authentication error kinds
+const (
+	KindExpiredToken       AuthErrorKind = "expired-token"       // Access token expired or revoked; a fresh token may fix it
+	KindMissingToken       AuthErrorKind = "missing-token"       // Request carried no access token
+	KindInvalidCredentials AuthErrorKind = "invalid-credentials" // Token endpoint rejected the consumer key or secret
 )
 
 // AuthError represents an authentication error.
+//
+// Kind separates problems a token refresh may fix (KindExpiredToken, KindMissingToken)
+// from rejected consumer credentials (KindInvalidCredentials), which fail again on
+// every refresh until the key is fixed. Kind is empty when the cause is unknown,
+// e.g. for an empty token response.
 type AuthError struct {
 	StatusCode int
 	Message    string
+	Cause      *OPSError // Parsed EPO error response, if any
+	Kind       AuthErrorKind
+
+	// InvalidToken is true when the API rejected the access token (expired or revoked)
+	// rather than the token endpoint rejecting the consumer credentials.
+	InvalidToken bool
 }
 
 func (e *AuthError) Error() string {
@@ -18,10 +69,33 @@ func (e *AuthError) Error() string {
 	return fmt.Sprintf("auth error: %s", e.Message)
 }
 
+// Unwrap returns the parsed EPO error response, if any.
+func (e *AuthError) Unwrap() error {
+	return unwrapOPSError(e.Cause)
+}
+
+// Retryable reports true only for rejected access tokens, which a fresh token
+// may fix. Bad consumer credentials are never retryable.
+func (e *AuthError) Retryable() bool {
+	return e.InvalidToken
+}
+
+// authErrorKindForCode maps an EPO error code to the kind of authentication error.
+func authErrorKindForCode(code string) AuthErrorKind {
+	switch code {
+	case "CLIENT.MissingAccessToken":
+		return KindMissingToken
+	case "CLIENT.InvalidAccessToken", "HTTP.401":
+		return KindExpiredToken
+	}
+	return ""
+}
+
 // NotFoundError represents a 404 error (document doesn't exist).
 type NotFoundError struct {
 	Resource string
 	Message  string
+	Cause    *OPSError // Parsed EPO error response, if any
 }
 
 func (e *NotFoundError) Error() string {
@@ -31,15 +105,45 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("not found: %s", e.Message)
 }
 
+// Unwrap returns the parsed EPO error response, if any.
+func (e *NotFoundError) Unwrap() error {
+	return unwrapOPSError(e.Cause)
+}
+
+// Retryable always reports false: the resource will not appear on retry.
+func (e *NotFoundError) Retryable() bool {
+	return false
+}
+
 // QuotaExceededError represents a fair use quota limit error.
 type QuotaExceededError struct {
 	Message string
+	Status  string    // X-Throttling-Control status of the response (e.g., "red", "black"), if any
+	Cause   *OPSError // Parsed EPO error response, if any
 }
 
 func (e *QuotaExceededError) Error() string {
 	return fmt.Sprintf("quota exceeded: %s", e.Message)
 }
 
+// Unwrap returns the parsed EPO error response, if any.
+func (e *QuotaExceededError) Unwrap() error {
+	return unwrapOPSError(e.Cause)
+}
+
+// Retryable reports whether the quota error is temporary throttling.
+// A "black" throttling status (access blocked) and an exhausted weekly quota
+// are not retryable until the quota resets.
+func (e *QuotaExceededError) Retryable() bool {
+	if strings.Contains(e.Status, "black") {
+		return false
+	}
+	if e.Cause != nil && e.Cause.Code == "SERVER.QuotaPerWeekExceeded" {
+		return false
+	}
+	return true
+}
+
 // AmbiguousPatentError represents a situation where a patent number
 // has multiple kind codes available (A1, B1, etc.) and the user must
 // choose a specific one.
@@ -62,7 +166,8 @@ func (e *AmbiguousPatentError) Error() string {
 type ServiceUnavailableError struct {
 	StatusCode int
 	Message    string
-	RetryAfter string // Optional Retry-After header value
+	RetryAfter string    // Optional Retry-After header value
+	Cause      *OPSError // Parsed EPO error response, if any
 }
 
 func (e *ServiceUnavailableError) Error() string {
@@ -73,13 +178,163 @@ func (e *ServiceUnavailableError) Error() string {
 	return fmt.Sprintf("service unavailable (status %d): %s", e.StatusCode, e.Message)
 }
 
+// Unwrap returns the parsed EPO error response, if any.
+func (e *ServiceUnavailableError) Unwrap() error {
+	return unwrapOPSError(e.Cause)
+}
+
+// Retryable always reports true: service outages are temporary.
+func (e *ServiceUnavailableError) Retryable() bool {
+	return true
+}
+
+// CQLError is returned when EPO rejects a search query, mapped from the CLIENT.CQL
+// family of error codes. Query builders can use Position and Token to point at the
+// offending part of the query.
+//
+//	var cqlErr *ops.CQLError
+//	if errors.As(err, &cqlErr) && cqlErr.Position >= 0 {
+//	    fmt.Printf("%s\n%*s^ %s\n", cqlErr.Query, cqlErr.Position, "", cqlErr.Detail)
+//	}
+type CQLError struct {
+	Query    string    // Query as sent to EPO (set by the search methods)
+	Message  string    // EPO error message
+	Detail   string    // Part of the message describing the problem, e.g. "mismatched input 'xyz'"
+	Position int       // Character offset in Query as EPO reports it (0-based in "line 1:12" messages), -1 if none
+	Token    string    // Offending token that EPO quotes, if any
+	Cause    *OPSError // Parsed EPO error response
+}
+
+func (e *CQLError) Error() string {
+	if e.Position >= 0 {
+		return fmt.Sprintf("invalid CQL query at position %d: %s", e.Position, e.Message)
+	}
+	return fmt.Sprintf("invalid CQL query: %s", e.Message)
+}
+
+// Unwrap returns the parsed EPO error response.
+func (e *CQLError) Unwrap() error {
+	return unwrapOPSError(e.Cause)
+}
+
+// Retryable always reports false: the same query is rejected again.
+func (e *CQLError) Retryable() bool {
+	return false
+}
+
+var (
+	// cqlErrorPosition matches the position of a CQL error, e.g. "line 1:12" or "position 12"
+	cqlErrorPosition = regexp.MustCompile(`(?i)\b(?:line \d+:|(?:position|offset|column|char(?:acter)?)\s+)(\d+)`)
+	// cqlErrorToken matches the quoted token of a CQL error, e.g. "input 'xyz'"
+	cqlErrorToken = regexp.MustCompile(`'([^']*)'|"([^"]*)"`)
+)
+
+// newCQLError builds a CQLError from an EPO CLIENT.CQL error response.
+func newCQLError(opsErr *OPSError) *CQLError {
+	detail := opsErr.Message
+	if opsErr.Detail != "" {
+		detail = opsErr.Detail
+	}
+	e := &CQLError{
+		Message:  opsErr.Message,
+		Detail:   detail,
+		Position: -1,
+		Cause:    opsErr,
+	}
+
+	if m := cqlErrorPosition.FindStringSubmatchIndex(detail); m != nil {
+		e.Position, _ = strconv.Atoi(detail[m[2]:m[3]])
+		// ANTLR-style messages start with the position, e.g. "line 1:12 mismatched input ..."
+		if m[0] == 0 {
+			e.Detail = strings.TrimSpace(detail[m[1]:])
+		}
+	}
+	if m := cqlErrorToken.FindStringSubmatch(detail); m != nil {
+		e.Token = m[1] + m[2]
+	}
+	return e
+}
+
+// withCQLQuery records query in a CQLError returned by a search method.
+func withCQLQuery(err error, query string) error {
+	var cqlErr *CQLError
+	if errors.As(err, &cqlErr) {
+		cqlErr.Query = query
+	}
+	return err
+}
+
+// CircuitOpenError is returned without sending a request while the circuit breaker
+// is open (see Config.CircuitBreakerThreshold).
+type CircuitOpenError struct {
+	Failures   int           // Consecutive ServiceUnavailableErrors that opened the circuit
+	RetryAfter time.Duration // Time until a probe request is let through
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open after %d consecutive service unavailable errors, retry after %v",
+		e.Failures, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Retryable always reports true: the circuit lets a request through after the cooldown.
+func (e *CircuitOpenError) Retryable() bool {
+	return true
+}
+
+// RetryBudgetExceededError is returned when Config.MaxRetryBudget stopped the retries
+// of a request. Err is the error of the last attempt, e.g. a ServiceUnavailableError.
+type RetryBudgetExceededError struct {
+	Attempts int   // HTTP attempts made by the call so far
+	Err      error // Error of the last attempt
+}
+
+func (e *RetryBudgetExceededError) Error() string {
+	return fmt.Sprintf("retry budget exhausted after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryBudgetExceededError) Unwrap() error {
+	return e.Err
+}
+
+// ResponseTooLargeError is returned when a response body exceeds Config.MaxResponseBytes.
+// The body is not read past the limit.
+type ResponseTooLargeError struct {
+	Limit         int64 // Config.MaxResponseBytes
+	ContentLength int64 // Content-Length of the response, -1 if unknown
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	if e.ContentLength > 0 {
+		return fmt.Sprintf("response body of %d bytes exceeds the limit of %d bytes (Config.MaxResponseBytes)", e.ContentLength, e.Limit)
+	}
+	return fmt.Sprintf("response body exceeds the limit of %d bytes (Config.MaxResponseBytes)", e.Limit)
+}
+
 // OPSError represents a structured error response from EPO OPS API.
 // The EPO OPS API returns errors in XML format with a code, message, and optional moreInfo URL.
+//
+// Typed errors (NotFoundError, AuthError, QuotaExceededError, ServiceUnavailableError)
+// keep the parsed OPSError as their Cause, so the original EPO code remains available:
+//
+//	var opsErr *ops.OPSError
+//	if errors.As(err, &opsErr) && opsErr.Code == "CLIENT.InvalidReference" {
+//	    // Malformed reference rather than a missing document
+//	}
 type OPSError struct {
 	HTTPStatus int    // HTTP status code
 	Code       string // EPO error code (e.g., "CLIENT.InvalidReference", "SERVER.EntityNotFound")
 	Message    string // Human-readable error message
 	MoreInfo   string // Optional URL with more information
+	Detail     string // Optional <message> of a <fault> response whose <description> is used as Message
+}
+
+// unwrapOPSError converts a possibly nil *OPSError into an error without
+// producing a non-nil interface holding a nil pointer.
+func unwrapOPSError(e *OPSError) error {
+	if e == nil {
+		return nil
+	}
+	return e
 }
 
 func (e *OPSError) Error() string {
@@ -89,6 +344,12 @@ func (e *OPSError) Error() string {
 	return fmt.Sprintf("[%d] %s: %s", e.HTTPStatus, e.Code, e.Message)
 }
 
+// Retryable reports whether the HTTP status of the error response is retryable
+// (408, 429 and 5xx server errors).
+func (e *OPSError) Retryable() bool {
+	return isRetryableStatusCode(e.HTTPStatus)
+}
+
 // XMLParseError represents an error during XML parsing.
 // This error provides context about what failed during XML unmarshaling
 // including the parser name, problematic element, and a sample of the XML.
@@ -184,8 +445,10 @@ func parseErrorXML(body []byte, statusCode int) (*OPSError, error) {
 	if err := xml.Unmarshal(body, &faultResp); err == nil && faultResp.Code != "" {
 		// Use description as message if available, otherwise use message
 		message := faultResp.Message
+		detail := ""
 		if faultResp.Description != "" {
 			message = faultResp.Description
+			detail = faultResp.Message
 		}
 
 		return &OPSError{
@@ -193,6 +456,7 @@ func parseErrorXML(body []byte, statusCode int) (*OPSError, error) {
 			Code:       "HTTP." + faultResp.Code, // Prefix numeric codes with "HTTP."
 			Message:    message,
 			MoreInfo:   "",
+			Detail:     detail,
 		}, nil
 	}
 
@@ -225,6 +489,11 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error: %s: %s - got: %q", e.Field, e.Message, e.Value)
 }
 
+// Retryable always reports false: the same input fails validation again.
+func (e *ValidationError) Retryable() bool {
+	return false
+}
+
 // NotImplementedError represents a not-yet-implemented feature.
 type NotImplementedError struct {
 	Message string
@@ -1,8 +1,13 @@
 package epo_ops
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 )
 
@@ -231,6 +236,102 @@ func TestHandleErrorResponse_AuthErrorMapping(t *testing.T) {
 	}
 }
 
+func TestAuthErrorKind(t *testing.T) {
+	client, _ := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want AuthErrorKind
+	}{
+		{"InvalidAccessToken", `<error><code>CLIENT.InvalidAccessToken</code><message>expired</message></error>`, KindExpiredToken},
+		{"MissingAccessToken", `<error><code>CLIENT.MissingAccessToken</code><message>missing</message></error>`, KindMissingToken},
+		{"HTTP 401 code", `<error><code>HTTP.401</code><message>unauthorized</message></error>`, KindExpiredToken},
+		{"Plain 401", "Unauthorized", KindExpiredToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authErr *AuthError
+			if err := client.handleErrorResponse(http.StatusUnauthorized, []byte(tt.body)); !errors.As(err, &authErr) {
+				t.Fatalf("Expected AuthError, got %T: %v", err, err)
+			}
+			if authErr.Kind != tt.want {
+				t.Errorf("Kind: got %q, want %q", authErr.Kind, tt.want)
+			}
+			if !authErr.Retryable() {
+				t.Error("Expected rejected access token to be retryable")
+			}
+		})
+	}
+
+	// The token endpoint rejecting the consumer key is a credentials problem
+	for _, tt := range []struct {
+		status int
+		body   string
+		want   AuthErrorKind
+	}{
+		{http.StatusUnauthorized, `{"error":"invalid_client"}`, KindInvalidCredentials},
+		{http.StatusBadRequest, `{"error":"invalid_client"}`, KindInvalidCredentials},
+		{http.StatusBadRequest, `{"error":"invalid_grant"}`, KindInvalidCredentials},
+		{http.StatusBadRequest, `{"error":"unsupported_grant_type"}`, ""},
+		{http.StatusUnauthorized, `Unauthorized`, ""},
+		{http.StatusInternalServerError, `{"error":"invalid_client"}`, ""},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte(tt.body))
+		}))
+		auth := NewAuthenticator("bad", "bad", nil)
+		auth.authURL = server.URL
+
+		_, err := auth.GetToken(context.Background())
+		server.Close()
+
+		var authErr *AuthError
+		if !errors.As(err, &authErr) {
+			t.Fatalf("Status %d: expected AuthError, got %T: %v", tt.status, err, err)
+		}
+		if authErr.Kind != tt.want {
+			t.Errorf("Status %d: Kind got %q, want %q", tt.status, authErr.Kind, tt.want)
+		}
+		if authErr.Retryable() {
+			t.Errorf("Status %d: expected token endpoint error not to be retryable", tt.status)
+		}
+	}
+
+	// Throttled or blocked token requests are quota errors, not rejected credentials
+	for _, status := range []int{http.StatusForbidden, http.StatusTooManyRequests} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Throttling-Control", "overloaded (retrieval=red:10)")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`<error><code>HTTP.` + strconv.Itoa(status) + `</code><message>Too many requests</message></error>`))
+		}))
+		auth := NewAuthenticator("key", "secret", nil)
+		auth.authURL = server.URL
+
+		_, err := auth.GetToken(context.Background())
+		server.Close()
+
+		var authErr *AuthError
+		if errors.As(err, &authErr) {
+			t.Errorf("Status %d: expected no AuthError, got %v", status, err)
+		}
+		var quotaErr *QuotaExceededError
+		if !errors.As(err, &quotaErr) {
+			t.Fatalf("Status %d: expected QuotaExceededError, got %T: %v", status, err, err)
+		}
+		if quotaErr.Status != "overloaded (retrieval=red:10)" || quotaErr.Cause == nil {
+			t.Errorf("Status %d: got status %q cause %v", status, quotaErr.Status, quotaErr.Cause)
+		}
+		if !quotaErr.Retryable() {
+			t.Errorf("Status %d: expected throttled token request to be retryable", status)
+		}
+	}
+}
+
 func TestHandleErrorResponse_QuotaErrorMapping(t *testing.T) {
 	client, _ := NewClient(&Config{
 		ConsumerKey:    "test",
@@ -258,6 +359,73 @@ func TestHandleErrorResponse_QuotaErrorMapping(t *testing.T) {
 	}
 }
 
+func TestHandleErrorResponse_PreservesOPSError(t *testing.T) {
+	client, _ := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+	})
+
+	tests := []struct {
+		name       string
+		statusCode int
+		xml        string
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "InvalidReference",
+			statusCode: http.StatusBadRequest,
+			xml:        `<error><code>CLIENT.InvalidReference</code><message>Invalid patent number</message></error>`,
+			wantCode:   "CLIENT.InvalidReference",
+		},
+		{
+			name:       "EntityNotFound",
+			statusCode: http.StatusNotFound,
+			xml:        `<error><code>SERVER.EntityNotFound</code><message>No results found</message></error>`,
+			wantCode:   "SERVER.EntityNotFound",
+		},
+		{
+			name:       "Fault",
+			statusCode: http.StatusNotFound,
+			xml:        string(loadTestData("error_404.xml")),
+			wantCode:   "HTTP.404",
+			wantDetail: "Document not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.handleErrorResponse(tt.statusCode, []byte(tt.xml))
+
+			var notFoundErr *NotFoundError
+			if !errors.As(err, &notFoundErr) {
+				t.Fatalf("Expected NotFoundError, got %T: %v", err, err)
+			}
+
+			var opsErr *OPSError
+			if !errors.As(err, &opsErr) {
+				t.Fatalf("Expected wrapped OPSError, got %T: %v", err, err)
+			}
+			if opsErr.Code != tt.wantCode {
+				t.Errorf("Expected code '%s', got '%s'", tt.wantCode, opsErr.Code)
+			}
+			if opsErr.HTTPStatus != tt.statusCode {
+				t.Errorf("Expected HTTPStatus %d, got %d", tt.statusCode, opsErr.HTTPStatus)
+			}
+			if opsErr.Detail != tt.wantDetail {
+				t.Errorf("Expected detail '%s', got '%s'", tt.wantDetail, opsErr.Detail)
+			}
+		})
+	}
+
+	// Errors built from plain-text bodies have no OPSError to unwrap
+	err := client.handleErrorResponse(http.StatusNotFound, []byte("Document not found"))
+	var opsErr *OPSError
+	if errors.As(err, &opsErr) {
+		t.Errorf("Expected no OPSError for plain-text body, got %+v", opsErr)
+	}
+}
+
 func TestOPSError_ErrorMethod(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -295,3 +463,116 @@ func TestOPSError_ErrorMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"ServiceUnavailable", &ServiceUnavailableError{StatusCode: 503}, true},
+		{"Quota throttled", &QuotaExceededError{Status: "red"}, true},
+		{"Quota without status", &QuotaExceededError{}, true},
+		{"Quota blocked", &QuotaExceededError{Status: "black"}, false},
+		{"Quota blocked in detailed header", &QuotaExceededError{Status: "overloaded (search=black:0)"}, false},
+		{"Weekly quota exhausted", &QuotaExceededError{Cause: &OPSError{Code: "SERVER.QuotaPerWeekExceeded"}}, false},
+		{"NotFound", &NotFoundError{Message: "missing"}, false},
+		{"Validation", &ValidationError{Field: "number"}, false},
+		{"Invalid token", &AuthError{StatusCode: 401, InvalidToken: true}, true},
+		{"Bad credentials", &AuthError{StatusCode: 401, Message: "invalid client"}, false},
+		{"OPSError 500", &OPSError{HTTPStatus: 500}, true},
+		{"OPSError 400", &OPSError{HTTPStatus: 400}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryable, ok := tt.err.(interface{ Retryable() bool })
+			if !ok {
+				t.Fatalf("%T has no Retryable method", tt.err)
+			}
+			if got := retryable.Retryable(); got != tt.want {
+				t.Errorf("Retryable() = %v, want %v", got, tt.want)
+			}
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	// Typed errors are found through the wrap chain
+	wrapped := fmt.Errorf("fetching EP1000000: %w", &ServiceUnavailableError{StatusCode: 503})
+	if !IsRetryable(wrapped) {
+		t.Error("Expected wrapped ServiceUnavailableError to be retryable")
+	}
+
+	// The outermost typed error decides, not the OPSError it wraps
+	notFound := &NotFoundError{Cause: &OPSError{HTTPStatus: 500, Code: "SERVER.EntityNotFound"}}
+	if IsRetryable(notFound) {
+		t.Error("Expected NotFoundError wrapping a 500 OPSError to be non-retryable")
+	}
+
+	// Errors without a Retryable method fall back to network error classification
+	if !IsRetryable(fmt.Errorf("request failed: %w", io.ErrUnexpectedEOF)) {
+		t.Error("Expected unexpected EOF to be retryable")
+	}
+	if IsRetryable(errors.New("unknown error")) {
+		t.Error("Expected unknown error to be non-retryable")
+	}
+	if IsRetryable(nil) {
+		t.Error("Expected nil error to be non-retryable")
+	}
+}
+
+func TestHandleErrorResponse_CQLError(t *testing.T) {
+	client, _ := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+	})
+
+	err := client.handleErrorResponse(http.StatusBadRequest, loadTestData("error_cql.xml"))
+
+	var cqlErr *CQLError
+	if !errors.As(err, &cqlErr) {
+		t.Fatalf("Expected CQLError, got %T: %v", err, err)
+	}
+	if cqlErr.Position != 11 {
+		t.Errorf("Position: got %d, want 11", cqlErr.Position)
+	}
+	if cqlErr.Token != "plastic" {
+		t.Errorf("Token: got %q, want %q", cqlErr.Token, "plastic")
+	}
+	if want := "mismatched input 'plastic' expecting {<EOF>, AND, OR, NOT, PROX}"; cqlErr.Detail != want {
+		t.Errorf("Detail: got %q, want %q", cqlErr.Detail, want)
+	}
+	var opsErr *OPSError
+	if !errors.As(err, &opsErr) || opsErr.Code != "CLIENT.CQL" {
+		t.Errorf("Expected wrapped OPSError with code CLIENT.CQL, got %v", opsErr)
+	}
+	if IsRetryable(err) {
+		t.Error("Expected CQLError to be non-retryable")
+	}
+
+	// Sub-codes map as well; messages without a position keep -1
+	err = client.handleErrorResponse(http.StatusBadRequest,
+		[]byte(`<error><code>CLIENT.CQL.UnknownIndex</code><message>Unknown index "xx"</message></error>`))
+	if !errors.As(err, &cqlErr) || cqlErr.Position != -1 || cqlErr.Token != "xx" {
+		t.Errorf("Expected CQLError without position, got %+v", cqlErr)
+	}
+
+	// Search methods record the query that was rejected
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(loadTestData("error_cql.xml"))
+	})
+	defer opsServer.Close()
+
+	client, _ = NewTestClient(opsServer.URL, authServer.URL+"/auth/accesstoken")
+	_, err = client.Search(context.Background(), "ti=battery plastic", "")
+	if !errors.As(err, &cqlErr) || cqlErr.Query != "ti=battery plastic" {
+		t.Errorf("Expected CQLError for the query, got %v", err)
+	}
+}
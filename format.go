@@ -0,0 +1,266 @@
+package epo_ops
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Payload formats returned by DetectFormat (unlike the number formats FormatDocDB,
+// FormatEPODOC and FormatOriginal, they describe the encoding of a response body)
+const (
+	PayloadXML     = "xml"
+	PayloadJSON    = "json"
+	PayloadTIFF    = "tiff"
+	PayloadUnknown = "unknown"
+)
+
+// DetectFormat detects the format of a raw API response.
+//
+// Detection only inspects the leading bytes: a TIFF magic number yields PayloadTIFF,
+// otherwise the first non-whitespace character '<' yields PayloadXML and '{' or '['
+// yields PayloadJSON. Everything else (including PNG, GIF or PDF images and data shorter
+// than 4 bytes) yields PayloadUnknown. Use the matching Validate* function to check
+// that the payload is complete and well-formed.
+func DetectFormat(data []byte) string {
+	if len(data) < 4 {
+		return PayloadUnknown
+	}
+
+	// Check for TIFF (little-endian or big-endian)
+	if isTIFFHeader(data) {
+		return PayloadTIFF
+	}
+
+	trimmed := bytes.TrimSpace(data)
+
+	// Check for XML
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return PayloadXML
+	}
+
+	// Check for JSON
+	if bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		return PayloadJSON
+	}
+
+	return PayloadUnknown
+}
+
+// ValidateXML checks that data is a complete, well-formed XML document.
+//
+// The whole document is tokenized, so truncated responses (unclosed elements) and
+// syntax errors are reported with the offending line. Returns a *ValidationError
+// describing the problem, or nil if the document is well-formed.
+func ValidateXML(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// Accept any declared encoding; only the structure is validated
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	elements := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return newFormatError(PayloadXML, data, fmt.Sprintf("malformed XML: %v", err))
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			elements++
+		}
+	}
+
+	if elements == 0 {
+		return newFormatError(PayloadXML, data, "no XML element found")
+	}
+	return nil
+}
+
+// ValidateJSON checks that data is a single, complete JSON value.
+//
+// Returns a *ValidationError with the byte offset of the first syntax error
+// (e.g., for truncated responses), or nil if the data is valid JSON.
+func ValidateJSON(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return newFormatError(PayloadJSON, data, "empty JSON document")
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return newFormatError(PayloadJSON, data,
+				fmt.Sprintf("malformed JSON at offset %d: %v", syntaxErr.Offset, syntaxErr))
+		}
+		return newFormatError(PayloadJSON, data, fmt.Sprintf("malformed JSON: %v", err))
+	}
+	return nil
+}
+
+// ValidateTIFF checks that data starts with a valid TIFF header and that the
+// first image file directory (IFD) lies within the data.
+//
+// Returns a *ValidationError for data that is too short, has a wrong magic
+// number, or is truncated before the end of the first IFD, or nil otherwise.
+func ValidateTIFF(data []byte) error {
+	if len(data) < 8 {
+		return newFormatError(PayloadTIFF, data,
+			fmt.Sprintf("data too short for TIFF header: %d bytes, need 8", len(data)))
+	}
+	if !isTIFFHeader(data) {
+		return newFormatError(PayloadTIFF, data, `invalid TIFF magic number, expected "II*" (little-endian) or "MM*" (big-endian)`)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] == 'M' {
+		order = binary.BigEndian
+	}
+
+	// Header: byte order (2), magic (2), offset of first IFD (4)
+	ifdOffset := int64(order.Uint32(data[4:8]))
+	if ifdOffset < 8 || ifdOffset+2 > int64(len(data)) {
+		return newFormatError(PayloadTIFF, data,
+			fmt.Sprintf("first IFD offset %d outside data of %d bytes", ifdOffset, len(data)))
+	}
+
+	// IFD: entry count (2), entries (12 each), offset of next IFD (4)
+	entries := int64(order.Uint16(data[ifdOffset : ifdOffset+2]))
+	if ifdEnd := ifdOffset + 2 + entries*12 + 4; ifdEnd > int64(len(data)) {
+		return newFormatError(PayloadTIFF, data,
+			fmt.Sprintf("truncated TIFF: first IFD with %d entries ends at byte %d, data has %d bytes",
+				entries, ifdEnd, len(data)))
+	}
+	return nil
+}
+
+// newFormatError builds the *ValidationError returned by the Validate* helpers
+func newFormatError(format string, data []byte, message string) *ValidationError {
+	sample := truncateXML(string(data), 40)
+	if format == PayloadTIFF {
+		sample = fmt.Sprintf("% x", data[:min(len(data), 8)])
+	}
+	return &ValidationError{
+		Field:   "data",
+		Format:  format,
+		Value:   sample,
+		Message: message,
+	}
+}
+
+// isTIFFHeader reports whether data starts with a little- or big-endian TIFF magic number
+func isTIFFHeader(data []byte) bool {
+	return len(data) >= 4 &&
+		((data[0] == 'I' && data[1] == 'I' && data[2] == 42 && data[3] == 0) ||
+			(data[0] == 'M' && data[1] == 'M' && data[2] == 0 && data[3] == 42))
+}
+
+// isImageData reports whether data starts with a GIF, PNG, JPEG or TIFF signature
+func isImageData(data []byte) bool {
+	return sniffImageType(data) != ""
+}
+
+// sniffImageType returns the MIME type of GIF, PNG, JPEG or TIFF data from its
+// signature, or "" for other data
+func sniffImageType(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+
+	switch {
+	case string(data[0:4]) == "GIF8":
+		return "image/gif"
+	case data[0] == 0x89 && data[1] == 0x50 && data[2] == 0x4E && data[3] == 0x47:
+		return "image/png"
+	case data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return "image/jpeg"
+	case isTIFFHeader(data):
+		return "image/tiff"
+	}
+	return ""
+}
+
+// SaveResponse writes a raw API response to disk for debugging or snapshotting.
+//
+// The format is detected with DetectFormat and the matching extension (.xml, .json,
+// .tiff) is appended to path; data of unknown format is saved with a .bin extension.
+// Known formats are validated before writing. The file is written atomically via a
+// temporary file in the same directory, so readers never observe a partial response.
+//
+// Parameters:
+//   - path: Destination path without extension (e.g., "snapshots/EP1000000-biblio")
+//   - data: Raw response bytes (e.g., from GetBiblioRaw or GetImage)
+//
+// Returns the detected format (one of the Format* constants).
+//
+// Example:
+//
+//	xmlData, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP1000000")
+//	format, err := ops.SaveResponse("snapshots/EP1000000-biblio", []byte(xmlData))
+//	// writes snapshots/EP1000000-biblio.xml, format == ops.PayloadXML
+func SaveResponse(path string, data []byte) (format string, err error) {
+	if path == "" {
+		return "", &ValidationError{Field: "path", Message: "path cannot be empty"}
+	}
+
+	format = DetectFormat(data)
+
+	var validationErr error
+	ext := ".bin"
+	switch format {
+	case PayloadXML:
+		validationErr = ValidateXML(data)
+		ext = ".xml"
+	case PayloadJSON:
+		validationErr = ValidateJSON(data)
+		ext = ".json"
+	case PayloadTIFF:
+		validationErr = ValidateTIFF(data)
+		ext = ".tiff"
+	}
+	if validationErr != nil {
+		return format, validationErr
+	}
+
+	if err := writeFileAtomic(path+ext, data); err != nil {
+		return format, err
+	}
+	return format, nil
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place
+func writeFileAtomic(filename string, data []byte) error {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	if err := os.Rename(tmpName, filename); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to save response: %w", err)
+	}
+	return nil
+}
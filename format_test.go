@@ -0,0 +1,186 @@
+package epo_ops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// minimalTIFF returns a TIFF header followed by a first IFD with one entry
+func minimalTIFF(bigEndian bool) []byte {
+	if bigEndian {
+		return []byte{
+			'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08, // header, IFD at offset 8
+			0x00, 0x01, // 1 entry
+			0x01, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, // ImageWidth = 1
+			0x00, 0x00, 0x00, 0x00, // no next IFD
+		}
+	}
+	return []byte{
+		'I', 'I', 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00, // header, IFD at offset 8
+		0x01, 0x00, // 1 entry
+		0x00, 0x01, 0x03, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, // ImageWidth = 1
+		0x00, 0x00, 0x00, 0x00, // no next IFD
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"XML declaration", []byte(`<?xml version="1.0"?><a/>`), PayloadXML},
+		{"XML with leading whitespace", []byte("\n  <world-patent-data/>"), PayloadXML},
+		{"JSON object", []byte(`{"a": 1}`), PayloadJSON},
+		{"JSON array", []byte(` [1, 2]`), PayloadJSON},
+		{"TIFF little-endian", minimalTIFF(false), PayloadTIFF},
+		{"TIFF big-endian", minimalTIFF(true), PayloadTIFF},
+		{"PNG", []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A}, PayloadUnknown},
+		{"Plain text", []byte("Unauthorized"), PayloadUnknown},
+		{"Too short", []byte("<a"), PayloadUnknown},
+		{"Empty", nil, PayloadUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat(tt.data); got != tt.want {
+				t.Errorf("DetectFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	goodXML := loadTestData("biblio.xml")
+	goodJSON := []byte(`{"ops:world-patent-data": {"ops:biblio-search": {"@total-result-count": "3"}}}`)
+	goodTIFF := minimalTIFF(false)
+
+	tests := []struct {
+		name      string
+		validate  func([]byte) error
+		data      []byte
+		wantError string
+	}{
+		{"XML good", ValidateXML, goodXML, ""},
+		{"XML truncated", ValidateXML, goodXML[:len(goodXML)/2], "malformed XML"},
+		{"XML mismatched tags", ValidateXML, []byte(`<a><b></a>`), "malformed XML"},
+		{"XML without elements", ValidateXML, []byte(`<?xml version="1.0"?>`), "no XML element"},
+		{"JSON good", ValidateJSON, goodJSON, ""},
+		{"JSON truncated", ValidateJSON, goodJSON[:len(goodJSON)-5], "malformed JSON"},
+		{"JSON trailing garbage", ValidateJSON, []byte(`{"a": 1} x`), "offset"},
+		{"JSON empty", ValidateJSON, []byte("  "), "empty JSON"},
+		{"TIFF good", ValidateTIFF, goodTIFF, ""},
+		{"TIFF big-endian good", ValidateTIFF, minimalTIFF(true), ""},
+		{"TIFF truncated IFD", ValidateTIFF, goodTIFF[:len(goodTIFF)-6], "truncated TIFF"},
+		{"TIFF header only", ValidateTIFF, goodTIFF[:8], "IFD offset"},
+		{"TIFF too short", ValidateTIFF, goodTIFF[:3], "too short"},
+		{"TIFF wrong magic", ValidateTIFF, []byte("GIF89a\x01\x00\x01\x00"), "magic number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.data)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got: %v", err)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantError) {
+				t.Errorf("Error %q does not contain %q", validationErr.Message, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSaveResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		wantFormat string
+		wantExt    string
+	}{
+		{
+			name:       "XML",
+			data:       []byte(`<?xml version="1.0"?><world-patent-data/>`),
+			wantFormat: PayloadXML,
+			wantExt:    ".xml",
+		},
+		{
+			name:       "JSON",
+			data:       []byte(`{"ops:world-patent-data": {}}`),
+			wantFormat: PayloadJSON,
+			wantExt:    ".json",
+		},
+		{
+			name:       "TIFF little-endian",
+			data:       minimalTIFF(false),
+			wantFormat: PayloadTIFF,
+			wantExt:    ".tiff",
+		},
+		{
+			name:       "TIFF big-endian",
+			data:       minimalTIFF(true),
+			wantFormat: PayloadTIFF,
+			wantExt:    ".tiff",
+		},
+		{
+			name:       "Unknown (PNG)",
+			data:       []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A},
+			wantFormat: PayloadUnknown,
+			wantExt:    ".bin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := filepath.Join(t.TempDir(), "snapshots", "response")
+
+			format, err := SaveResponse(base, tt.data)
+			if err != nil {
+				t.Fatalf("SaveResponse failed: %v", err)
+			}
+			if format != tt.wantFormat {
+				t.Errorf("Format: got %q, want %q", format, tt.wantFormat)
+			}
+
+			written, err := os.ReadFile(base + tt.wantExt)
+			if err != nil {
+				t.Fatalf("Expected file with extension %s: %v", tt.wantExt, err)
+			}
+			if string(written) != string(tt.data) {
+				t.Errorf("Written data mismatch")
+			}
+
+			// No temporary files are left behind
+			entries, err := os.ReadDir(filepath.Dir(base))
+			if err != nil {
+				t.Fatalf("ReadDir failed: %v", err)
+			}
+			if len(entries) != 1 {
+				t.Errorf("Expected exactly one file, got %d", len(entries))
+			}
+		})
+	}
+
+	t.Run("Invalid data is not written", func(t *testing.T) {
+		base := filepath.Join(t.TempDir(), "response")
+
+		_, err := SaveResponse(base, []byte(`{"truncated": `))
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected ValidationError, got: %v", err)
+		}
+		if _, err := os.Stat(base + ".json"); !os.IsNotExist(err) {
+			t.Errorf("Invalid response should not be written")
+		}
+	})
+}
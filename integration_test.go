@@ -134,9 +134,12 @@ func TestInvalidCredentialsIntegration(t *testing.T) {
 		t.Error("Expected empty token with invalid credentials")
 	}
 
-	// Should be an AuthError
-	if _, ok := err.(*AuthError); !ok {
+	// Should be an AuthError for the credentials, not the token
+	authErr, ok := err.(*AuthError)
+	if !ok {
 		t.Errorf("Expected AuthError, got: %T", err)
+	} else if authErr.Kind != KindInvalidCredentials {
+		t.Errorf("Expected kind %q, got %q", KindInvalidCredentials, authErr.Kind)
 	}
 
 	t.Logf("Correctly rejected invalid credentials: %v", err)
@@ -647,11 +650,11 @@ func TestAdditionalServicesIntegration(t *testing.T) {
 			return
 		}
 
-		if register == "" {
-			t.Error("Received empty register data")
+		if register.Status == "" {
+			t.Error("Received register data without status")
 		}
 
-		t.Logf("Successfully retrieved register biblio (length: %d bytes)", len(register))
+		t.Logf("Successfully retrieved register biblio (status: %s, %d designated states)", register.Status, len(register.DesignatedStates))
 	})
 
 	// Test: Register events retrieval
@@ -664,11 +667,11 @@ func TestAdditionalServicesIntegration(t *testing.T) {
 			return
 		}
 
-		if events == "" {
-			t.Error("Received empty events data")
+		if len(events.Events) == 0 {
+			t.Error("Received no register events")
 		}
 
-		t.Logf("Successfully retrieved register events (length: %d bytes)", len(events))
+		t.Logf("Successfully retrieved %d register events (status: %s)", len(events.Events), events.Status)
 	})
 
 	// Test: Number conversion
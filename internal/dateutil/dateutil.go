@@ -0,0 +1,102 @@
+// Package dateutil provides calendar date checks shared by the epo_ops and cql packages.
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MinYear is the earliest accepted year (first patents under the US Patent Act of 1790).
+const MinYear = 1790
+
+// MaxYear returns the latest accepted year: two years ahead of the current year,
+// allowing for scheduled publication dates.
+func MaxYear() int {
+	return time.Now().Year() + 2
+}
+
+// CheckDate verifies that year, month and day form a real calendar date
+// (leap years included) with the year between MinYear and MaxYear.
+func CheckDate(year, month, day int) error {
+	if err := checkCalendarDate(year, month, day); err != nil {
+		return err
+	}
+
+	if maxYear := MaxYear(); year < MinYear || year > maxYear {
+		return fmt.Errorf("year must be between %d and %d, got %d", MinYear, maxYear, year)
+	}
+
+	return nil
+}
+
+// checkCalendarDate verifies that year, month and day form a real calendar date,
+// without bounding the year.
+func checkCalendarDate(year, month, day int) error {
+	if month < 1 || month > 12 {
+		return fmt.Errorf("month must be between 01 and 12, got %02d", month)
+	}
+
+	if days := DaysIn(year, month); day < 1 || day > days {
+		return fmt.Errorf("day must be between 01 and %02d for %04d-%02d, got %02d", days, year, month, day)
+	}
+
+	return nil
+}
+
+// CheckYYYYMMDD verifies that date is an 8-digit YYYYMMDD string forming a real calendar date.
+func CheckYYYYMMDD(date string) error {
+	if len(date) != 8 {
+		return fmt.Errorf("date must be in YYYYMMDD format (8 digits), got %d characters", len(date))
+	}
+	for i := 0; i < len(date); i++ {
+		if date[i] < '0' || date[i] > '9' {
+			return fmt.Errorf("date must be in YYYYMMDD format (digits only)")
+		}
+	}
+
+	year, _ := strconv.Atoi(date[0:4])
+	month, _ := strconv.Atoi(date[4:6])
+	day, _ := strconv.Atoi(date[6:8])
+	return CheckDate(year, month, day)
+}
+
+// DaysIn returns the number of days in the given month (1-12) of year.
+func DaysIn(year, month int) int {
+	// Day 0 of the following month is the last day of this month
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// ParseYYYYMMDD parses a YYYYMMDD date as midnight UTC. Partial dates as EPO sometimes
+// emits them (YYYYMM, YYYY, or 00 for an unknown month or day) default to the first
+// month or day. Unlike CheckDate, which validates request parameters, the year is not
+// bounded, so placeholders such as 00010101 in response data still parse.
+func ParseYYYYMMDD(date string) (time.Time, error) {
+	switch len(date) {
+	case 4, 6, 8:
+	default:
+		return time.Time{}, fmt.Errorf("date must be in YYYYMMDD, YYYYMM or YYYY format, got %d characters", len(date))
+	}
+	for i := 0; i < len(date); i++ {
+		if date[i] < '0' || date[i] > '9' {
+			return time.Time{}, fmt.Errorf("date must be in YYYYMMDD format (digits only)")
+		}
+	}
+
+	year, _ := strconv.Atoi(date[0:4])
+	month, day := 1, 1
+	if len(date) >= 6 {
+		if m, _ := strconv.Atoi(date[4:6]); m != 0 {
+			month = m
+		}
+	}
+	if len(date) == 8 {
+		if d, _ := strconv.Atoi(date[6:8]); d != 0 {
+			day = d
+		}
+	}
+	if err := checkCalendarDate(year, month, day); err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
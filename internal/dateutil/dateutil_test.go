@@ -0,0 +1,88 @@
+package dateutil
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckYYYYMMDD(t *testing.T) {
+	nextYear := strconv.Itoa(MaxYear() + 1)
+
+	tests := []struct {
+		name    string
+		date    string
+		wantErr string
+	}{
+		{"Valid date", "20231015", ""},
+		{"Feb 29 in leap year", "20240229", ""},
+		{"Feb 29 in century leap year", "20000229", ""},
+		{"Feb 29 in non-leap year", "20230229", "day must be between 01 and 28"},
+		{"Feb 29 in non-leap century", "19000229", "day must be between 01 and 28"},
+		{"Day 31 in 30-day month", "20230431", "day must be between 01 and 30"},
+		{"Day zero", "20230100", "day must be between 01 and 31"},
+		{"Month 13", "20231301", "month must be between 01 and 12"},
+		{"Month zero", "20230001", "month must be between 01 and 12"},
+		{"Earliest year", "17900731", ""},
+		{"Year too early", "17891231", "year must be between 1790"},
+		{"Year too late", nextYear + "0101", "year must be between 1790"},
+		{"Too short", "2023101", "8 digits"},
+		{"Non-digits", "2023-1-1", "digits only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckYYYYMMDD(tt.date)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckYYYYMMDD(%q) unexpected error: %v", tt.date, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckYYYYMMDD(%q) error = %v, want error containing %q", tt.date, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseYYYYMMDD(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		want    time.Time
+		wantErr string
+	}{
+		{"Full date", "20111228", time.Date(2011, 12, 28, 0, 0, 0, 0, time.UTC), ""},
+		{"Year and month", "201112", time.Date(2011, 12, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Year only", "2011", time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Unknown day", "20111200", time.Date(2011, 12, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Unknown month and day", "20110000", time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Placeholder date", "00010101", time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Year outside request range", "17000101", time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Invalid day", "20110230", time.Time{}, "day must be between 01 and 28"},
+		{"Invalid month", "201113", time.Time{}, "month must be between 01 and 12"},
+		{"Empty", "", time.Time{}, "0 characters"},
+		{"Wrong length", "2011122", time.Time{}, "7 characters"},
+		{"Non-digits", "2011-12-", time.Time{}, "digits only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseYYYYMMDD(tt.date)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseYYYYMMDD(%q) error = %v, want error containing %q", tt.date, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseYYYYMMDD(%q) unexpected error: %v", tt.date, err)
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("ParseYYYYMMDD(%q) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package epo_ops
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// RequestOption configures a single API call.
+//
+// Request options are accepted by the heavy endpoints (the *Multiple bulk methods and
+// the classification services), the search methods and the *Raw and *WithMeta
+// retrieval methods as a trailing variadic argument, so existing calls keep compiling
+// unchanged:
+//
+//	schema, err := client.GetClassificationSchemaMultipleRaw(ctx, classes,
+//	    ops.WithRequestTimeout(5*time.Minute))
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the per-call settings collected from RequestOption values.
+type requestOptions struct {
+	// timeout replaces Config.Timeout for this call when > 0
+	timeout time.Duration
+
+	// accept replaces the endpoint-derived Accept header when non-empty
+	accept string
+
+	// searchRange replaces the range argument of the search methods when set
+	searchRange *SearchRange
+
+	// searchSort orders the results of the published-data search methods when set
+	searchSort *SearchSort
+}
+
+// requestOptionsKey is the context key under which per-call options are stored
+// so that authTransport can apply them to the outgoing request.
+type requestOptionsKey struct{}
+
+// WithRequestTimeout sets a deadline for a single call, replacing Config.Timeout.
+//
+// The timeout covers the whole call including retries and reading the response body.
+// It can be shorter than Config.Timeout (fail fast on quick lookups) or longer
+// (slow endpoints such as GetClassificationSchemaMultipleRaw). A deadline already
+// present on the caller's context still applies if it is earlier.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// WithAcceptOverride sets the Accept header for a single call, replacing the value
+// the client would normally choose for the endpoint (see getAcceptHeader).
+//
+// This is intended for advanced callers, e.g. to request "application/json"
+// instead of XML, or plain "application/xml" instead of the exchange or fulltext
+// variant the endpoint would get. Parsed methods expect XML and will fail on other
+// formats, so combine this option with the *Raw methods. Cached responses are kept
+// per Accept header.
+func WithAcceptOverride(accept string) RequestOption {
+	return func(o *requestOptions) {
+		o.accept = accept
+	}
+}
+
+// WithRange sets the result window of a search method, replacing its range string
+// argument. Use NewSearchRange to build a validated range:
+//
+//	r, err := ops.NewSearchRange(26, 50)
+//	results, err := client.Search(ctx, "ti=battery", "", ops.WithRange(r))
+//
+// The option only applies to the search methods (Search*, SearchRegister*).
+func WithRange(r SearchRange) RequestOption {
+	return func(o *requestOptions) {
+		o.searchRange = &r
+	}
+}
+
+// WithSort orders the results of a published-data search by one field, so that e.g.
+// the newest publications come first across all pages rather than within one page:
+//
+//	sort := ops.SearchSort{Field: "pd", Descending: true}
+//	results, err := client.Search(ctx, "ti=battery", "1-100", ops.WithSort(sort))
+//
+// The sort is sent as a CQL sortby clause of the query ("ti=battery sortby
+// pd/sort.descending"), which counts towards cql.MaxQueryLength. Fields that the
+// published-data search does not accept are rejected with a ValidationError before
+// the request. The option only applies to
+// Search, SearchAll and SearchWithConstituent*.
+func WithSort(s SearchSort) RequestOption {
+	return func(o *requestOptions) {
+		o.searchSort = &s
+	}
+}
+
+// applyRequestOptions derives a child context carrying the per-call options.
+// The returned cancel function must always be called once the call has completed.
+func applyRequestOptions(ctx context.Context, opts []RequestOption) (context.Context, context.CancelFunc) {
+	if len(opts) == 0 {
+		return ctx, func() {}
+	}
+
+	o := &requestOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	}
+
+	return context.WithValue(ctx, requestOptionsKey{}, o), cancel
+}
+
+// searchRangeParam resolves the range sent by a search method: the WithRange option
+// stored in ctx if present, otherwise rangeStr (DefaultSearchRange when empty).
+// The range is validated either way, so invalid ranges fail before the request.
+func searchRangeParam(ctx context.Context, rangeStr string) (string, error) {
+	if o := requestOptionsFromContext(ctx); o != nil && o.searchRange != nil {
+		if err := o.searchRange.Validate(); err != nil {
+			return "", err
+		}
+		return o.searchRange.String(), nil
+	}
+
+	r, err := ParseSearchRange(rangeStr)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// searchQueryParam returns the q parameter sent by a published-data search method:
+// query followed by the sortby clause of the WithSort option stored in ctx, if any.
+// The sortby clause counts towards cql.MaxQueryLength, so the final query is checked.
+func searchQueryParam(ctx context.Context, query string) (string, error) {
+	o := requestOptionsFromContext(ctx)
+	if o == nil || o.searchSort == nil {
+		return query, nil
+	}
+	if err := o.searchSort.Validate(); err != nil {
+		return "", err
+	}
+	q := query + " sortby " + o.searchSort.String()
+	if err := validateQueryLength(q); err != nil {
+		return "", err
+	}
+	return q, nil
+}
+
+// requestOptionsFromContext returns the per-call options stored in ctx (may be nil).
+func requestOptionsFromContext(ctx context.Context) *requestOptions {
+	o, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return o
+}
+
+// requestIDKey is the context key under which the request ID of a call is stored.
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID of the client call ctx belongs to, or ""
+// if ctx carries none.
+//
+// Every client method that sends requests assigns a random ID to its context. All
+// requests of the call share it: retries, the retry after a token refresh, and the
+// pages or batches of methods such as SearchAll and GetBibliosBulk. Interceptors read
+// it from req.Context() to correlate log output of concurrent calls:
+//
+//	logRequests := func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+//	    resp, err := next(req)
+//	    log.Printf("[%s] %s %s", ops.RequestIDFromContext(req.Context()), req.Method, req.URL.Path)
+//	    return resp, err
+//	}
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestID returns ctx with a new request ID, or ctx itself if it already carries
+// one, so that methods calling other methods keep a single ID per call. The call also
+// gets the attempt count its requests share for Config.MaxRetryBudget.
+func withRequestID(ctx context.Context) context.Context {
+	if RequestIDFromContext(ctx) != "" {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, rand.Text())
+	return context.WithValue(ctx, retryAttemptsKey{}, new(atomic.Int32))
+}
+
+// cancelOnCloseBody cancels a per-attempt context once the response body is closed,
+// so the attempt timeout also covers reading the body.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
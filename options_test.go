@@ -0,0 +1,225 @@
+package epo_ops
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newOptionsTestClient(t *testing.T, timeout time.Duration, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	authServer := newMockAuthServer(t)
+	t.Cleanup(authServer.Close)
+
+	opsServer := newMockOPSServer(t, handler)
+	t.Cleanup(opsServer.Close)
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		Timeout:        timeout,
+		MaxRetries:     0,
+		RetryDelay:     1 * time.Nanosecond,
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	slowHandler := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`<classification/>`))
+	}
+
+	t.Run("Per-call timeout triggers before client-wide timeout", func(t *testing.T) {
+		client := newOptionsTestClient(t, 10*time.Second, slowHandler)
+
+		start := time.Now()
+		_, err := client.GetClassificationSchemaRaw(context.Background(), "A01B", false, false,
+			WithRequestTimeout(50*time.Millisecond))
+		elapsed := time.Since(start)
+
+		if err == nil {
+			t.Fatal("Expected timeout error, got nil")
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+		}
+		if elapsed > 250*time.Millisecond {
+			t.Errorf("Per-call timeout did not trigger early: took %v", elapsed)
+		}
+	})
+
+	t.Run("Per-call timeout can extend client-wide timeout", func(t *testing.T) {
+		client := newOptionsTestClient(t, 50*time.Millisecond, slowHandler)
+
+		// Without the option the client-wide timeout fails the request
+		if _, err := client.GetClassificationSchemaRaw(context.Background(), "A01B", false, false); err == nil {
+			t.Fatal("Expected client-wide timeout error, got nil")
+		}
+
+		result, err := client.GetClassificationSchemaRaw(context.Background(), "A01B", false, false,
+			WithRequestTimeout(5*time.Second))
+		if err != nil {
+			t.Fatalf("Expected success with extended timeout, got: %v", err)
+		}
+		if result != `<classification/>` {
+			t.Errorf("Unexpected response: %q", result)
+		}
+	})
+}
+
+func TestWithAcceptOverride(t *testing.T) {
+	var gotAccept string
+	client := newOptionsTestClient(t, 5*time.Second, func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := context.Background()
+
+	// Default Accept header for register endpoints
+	if _, err := client.GetRegisterBiblioMultipleRaw(ctx, "publication", "epodoc", []string{"EP1000000"}); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if gotAccept != "application/register+xml" {
+		t.Errorf("Default Accept: got %q, want %q", gotAccept, "application/register+xml")
+	}
+
+	// Overridden Accept header
+	if _, err := client.GetRegisterBiblioMultipleRaw(ctx, "publication", "epodoc", []string{"EP1000000"},
+		WithAcceptOverride("application/json")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if gotAccept != "application/json" {
+		t.Errorf("Overridden Accept: got %q, want %q", gotAccept, "application/json")
+	}
+
+	// Retrieval methods keep the endpoint default unless overridden
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if gotAccept != "application/exchange+xml" {
+		t.Errorf("Default biblio Accept: got %q, want %q", gotAccept, "application/exchange+xml")
+	}
+	if _, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1",
+		WithAcceptOverride("application/xml")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if gotAccept != "application/xml" {
+		t.Errorf("Overridden biblio Accept: got %q, want %q", gotAccept, "application/xml")
+	}
+	if _, err := client.GetFamilyRaw(ctx, "publication", "docdb", "EP.1000000.B1",
+		WithAcceptOverride("application/json")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if gotAccept != "application/json" {
+		t.Errorf("Overridden family Accept: got %q, want %q", gotAccept, "application/json")
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	var mu sync.Mutex
+	attempts := make(map[string]int) // request path -> attempts so far
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.URL.Path]++
+		first := attempts[r.URL.Path] == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	var logMu sync.Mutex
+	logged := make(map[string][]string) // request path -> request ID per attempt
+	logRequests := func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+		logMu.Lock()
+		logged[req.URL.Path] = append(logged[req.URL.Path], RequestIDFromContext(req.Context()))
+		logMu.Unlock()
+		return next(req)
+	}
+
+	client, err := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		MaxRetries:     1,
+		RetryDelay:     time.Millisecond,
+		Interceptors:   []RequestInterceptor{logRequests},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	// Concurrent calls, each retried once after a 503
+	numbers := []string{"EP.1000000.B1", "EP.1000001.B1", "EP.1000002.B1"}
+	var wg sync.WaitGroup
+	for _, number := range numbers {
+		wg.Go(func() {
+			if _, err := client.GetBiblioRaw(context.Background(), "publication", "docdb", number); err != nil {
+				t.Errorf("GetBiblioRaw(%s) failed: %v", number, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for path, ids := range logged {
+		if len(ids) != 2 {
+			t.Errorf("%s: got %d attempts, want 2", path, len(ids))
+			continue
+		}
+		if ids[0] == "" || ids[0] != ids[1] {
+			t.Errorf("%s: initial request ID %q, retry request ID %q", path, ids[0], ids[1])
+		}
+		if seen[ids[0]] {
+			t.Errorf("%s: request ID %q shared with another call", path, ids[0])
+		}
+		seen[ids[0]] = true
+	}
+	if len(logged) != len(numbers) {
+		t.Errorf("Logged %d calls, want %d", len(logged), len(numbers))
+	}
+
+	// Methods calling other methods keep the ID of the outer call
+	var ids []string
+	_, err = client.DescribeRequest(context.Background(), func(ctx context.Context) error {
+		ctx = withRequestID(ctx)
+		ids = append(ids, RequestIDFromContext(ctx))
+		_, err := client.GetBiblio(ctx, "publication", "docdb", "EP.1000003.B1")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("DescribeRequest failed: %v", err)
+	}
+	if got := logged["/published-data/publication/docdb/EP.1000003.B1/biblio"]; len(got) != 1 || got[0] != ids[0] {
+		t.Errorf("Nested call request ID: got %v, want %s", got, ids[0])
+	}
+
+	if id := RequestIDFromContext(context.Background()); id != "" {
+		t.Errorf("Background context: got request ID %q", id)
+	}
+}
@@ -1,8 +1,14 @@
 package epo_ops
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseFamily(t *testing.T) {
@@ -26,77 +32,1533 @@ func TestParseFamily(t *testing.T) {
 	}
 }
 
+func TestParseFamily_PublicationStages(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/family_stages.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseFamily(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamily failed: %v", err)
+	}
+
+	if len(data.Members) != 2 {
+		t.Fatalf("Members: got %d, want 2", len(data.Members))
+	}
+
+	member := data.Members[0]
+	// Primary publication is the first docdb document-id, not the leading epodoc entry
+	if member.Country != "EP" || member.DocNumber != "2400812" || member.Kind != "A1" || member.Date != "20111228" {
+		t.Errorf("Primary publication: got %s%s%s (%s), want EP2400812A1 (20111228)",
+			member.Country, member.DocNumber, member.Kind, member.Date)
+	}
+
+	if len(member.Publications) != 3 {
+		t.Fatalf("Publications: got %d, want 3", len(member.Publications))
+	}
+	last := member.Publications[2]
+	if last.Kind != "B1" || last.Date != "20130508" || last.Type != "docdb" {
+		t.Errorf("B1 publication: got kind %q date %q type %q", last.Kind, last.Date, last.Type)
+	}
+
+	if len(data.Members[1].Publications) != 1 {
+		t.Errorf("Second member publications: got %d, want 1", len(data.Members[1].Publications))
+	}
+}
+
+func TestDateAccessors(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		{"Full date", "20111228", day(2011, time.December, 28)},
+		{"Year and month", "201112", day(2011, time.December, 1)},
+		{"Year only", "2011", day(2011, time.January, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			biblio := &BiblioData{PublicationDate: tt.value}
+			member := &FamilyMember{Date: tt.value}
+			event := &LegalEvent{DateMigr: tt.value}
+
+			for name, accessor := range map[string]func() (time.Time, error){
+				"BiblioData.PublicationDateTime": biblio.PublicationDateTime,
+				"FamilyMember.PublicationDate":   member.PublicationDate,
+				"LegalEvent.Date":                event.Date,
+			} {
+				got, err := accessor()
+				if err != nil {
+					t.Errorf("%s(%q) failed: %v", name, tt.value, err)
+				} else if !got.Equal(tt.want) {
+					t.Errorf("%s(%q) = %v, want %v", name, tt.value, got, tt.want)
+				}
+			}
+		})
+	}
+
+	// Dates from a parsed response
+	xmlData, err := os.ReadFile("testdata/biblio.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	biblio, err := ParseBiblio(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseBiblio failed: %v", err)
+	}
+	if got, err := biblio.PublicationDateTime(); err != nil || !got.Equal(day(2011, time.December, 28)) {
+		t.Errorf("PublicationDateTime() = %v, %v; want 2011-12-28", got, err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := (&LegalEvent{}).Date(); !errors.As(err, &validationErr) || validationErr.Field != "DateMigr" {
+		t.Errorf("Expected ValidationError for DateMigr, got %v", err)
+	}
+	if _, err := (&FamilyMember{Date: "2011-12-28"}).PublicationDate(); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError for malformed date, got %v", err)
+	}
+}
+
+func TestFamilyDataDeduplicateByApplication(t *testing.T) {
+	app := `<application-reference doc-id="316859723"><document-id document-id-type="docdb">` +
+		`<country>EP</country><doc-number>10167109</doc-number><kind>A</kind></document-id></application-reference>`
+	priority := `<priority-claim sequence="1" kind="national"><document-id document-id-type="docdb">` +
+		`<country>US</country><doc-number>35782510</doc-number><kind>P</kind><date>20100618</date></document-id></priority-claim>`
+	publication := func(country, number, kind, date string) string {
+		return `<publication-reference><document-id document-id-type="docdb"><country>` + country +
+			`</country><doc-number>` + number + `</doc-number><kind>` + kind + `</kind><date>` + date +
+			`</date></document-id></publication-reference>`
+	}
+	xmlData := `<ops:world-patent-data xmlns:ops="http://ops.epo.org" xmlns="http://www.epo.org/exchange">
+  <ops:patent-family legal="false" total-result-count="4">
+    <ops:family-member family-id="43088294">` + publication("EP", "2400812", "A1", "20111228") + app + priority + `</ops:family-member>
+    <ops:family-member family-id="43088294">` + publication("US", "2011318412", "A1", "20111229") + `</ops:family-member>
+    <ops:family-member family-id="43088294">` + publication("EP", "2400812", "B1", "20130508") + app + priority + `</ops:family-member>
+    <ops:family-member family-id="43088294">` + publication("EP", "2400812", "A1", "20111228") + app + `</ops:family-member>
+  </ops:patent-family>
+</ops:world-patent-data>`
+
+	data, err := ParseFamily(xmlData)
+	if err != nil {
+		t.Fatalf("ParseFamily failed: %v", err)
+	}
+
+	members := data.DeduplicateByApplication()
+	if len(members) != 2 {
+		t.Fatalf("Members: got %d, want 2 (EP and US)", len(members))
+	}
+
+	ep := members[0]
+	if ep.Country != "EP" || ep.Kind != "A1" || ep.ApplicationRef.DocNumber != "10167109" {
+		t.Errorf("EP member: got %s%s%s (application %s)", ep.Country, ep.DocNumber, ep.Kind, ep.ApplicationRef.DocNumber)
+	}
+	if len(ep.Publications) != 2 || ep.Publications[0].Kind != "A1" || ep.Publications[1].Kind != "B1" {
+		t.Errorf("EP publications: got %+v, want A1 and B1", ep.Publications)
+	}
+	if len(ep.PriorityClaims) != 1 {
+		t.Errorf("EP priority claims: got %d, want 1", len(ep.PriorityClaims))
+	}
+
+	// Members without an application reference are kept as is
+	if members[1].Country != "US" {
+		t.Errorf("Second member: got %s, want US", members[1].Country)
+	}
+
+	// The parsed members are left untouched
+	if len(data.Members) != 4 || len(data.Members[0].Publications) != 1 {
+		t.Errorf("Members modified: got %d members, first with %d publications", len(data.Members), len(data.Members[0].Publications))
+	}
+}
+
+func TestFamilyDataPriorityGraph(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/family_priorities.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseFamily(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamily failed: %v", err)
+	}
+
+	// The US, EP and CN members share the US provisional; the EP members also claim
+	// the US application. The JP member is a direct filing claimed by the KR member.
+	want := map[string][]string{
+		"US35782510P":     {"US2011318412A1", "EP2400812A1", "EP2400812B1", "CN102300000A"},
+		"US201113162738A": {"EP2400812A1", "EP2400812B1"},
+		"JP2011100000A":   {"KR20120000001A"},
+	}
+	if graph := data.PriorityGraph(); !reflect.DeepEqual(graph, want) {
+		t.Errorf("PriorityGraph:\ngot  %v\nwant %v", graph, want)
+	}
+
+	// The US application claims the provisional, so it is not a root
+	roots := data.RootPriorities()
+	var got []string
+	for _, root := range roots {
+		got = append(got, root.Country+root.DocNumber+root.Kind+"@"+root.Date)
+	}
+	if wantRoots := []string{"US35782510P@20100618", "JP2011100000A@20110520"}; !reflect.DeepEqual(got, wantRoots) {
+		t.Errorf("RootPriorities: got %v, want %v", got, wantRoots)
+	}
+
+	// A family without priority claims has neither links nor roots
+	direct := &FamilyData{Members: []FamilyMember{{Country: "JP", DocNumber: "2012234567", Kind: "A"}}}
+	if graph := direct.PriorityGraph(); len(graph) != 0 {
+		t.Errorf("Expected empty graph, got %v", graph)
+	}
+	if roots := direct.RootPriorities(); roots != nil {
+		t.Errorf("Expected no roots, got %v", roots)
+	}
+}
+
+func TestParseFamilyMultiple(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/family_multiple.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	families, err := ParseFamilyMultiple(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamilyMultiple failed: %v", err)
+	}
+
+	if len(families) != 2 {
+		t.Fatalf("Families: got %d, want 2", len(families))
+	}
+
+	if families[0].PatentNumber != "EP2400812" || families[0].FamilyID != "43088294" || len(families[0].Members) != 2 {
+		t.Errorf("First family: got %s (family %s, %d members)",
+			families[0].PatentNumber, families[0].FamilyID, len(families[0].Members))
+	}
+	if families[1].PatentNumber != "EP1000000" || families[1].FamilyID != "16005563" || len(families[1].Members) != 1 {
+		t.Errorf("Second family: got %s (family %s, %d members)",
+			families[1].PatentNumber, families[1].FamilyID, len(families[1].Members))
+	}
+
+	// A single family response is still parsed as a one-element slice
+	single, err := os.ReadFile("testdata/family.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	families, err = ParseFamilyMultiple(string(single))
+	if err != nil {
+		t.Fatalf("ParseFamilyMultiple (single) failed: %v", err)
+	}
+	if len(families) != 1 {
+		t.Errorf("Single family: got %d families, want 1", len(families))
+	}
+
+	// No families at all is an error
+	if _, err := ParseFamilyMultiple(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without families")
+	}
+}
+
+func TestParseFamilyBiblio(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/family_biblio.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseFamilyBiblio(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamilyBiblio failed: %v", err)
+	}
+
+	if data.FamilyID != "43088294" || data.TotalCount != 3 {
+		t.Errorf("Family: got ID %q, total %d", data.FamilyID, data.TotalCount)
+	}
+	if len(data.Members) != 3 {
+		t.Fatalf("Members: got %d, want 3", len(data.Members))
+	}
+
+	for i, member := range data.Members {
+		if member.Biblio == nil {
+			t.Fatalf("Member %d: missing biblio data", i)
+		}
+		if member.Biblio.Country != member.Country || member.Biblio.DocNumber != member.DocNumber {
+			t.Errorf("Member %d: biblio %s%s does not match member %s%s", i,
+				member.Biblio.Country, member.Biblio.DocNumber, member.Country, member.DocNumber)
+		}
+	}
+
+	ep := data.Members[0].Biblio
+	if ep.PatentNumber != "EP2400812A1" || ep.PublicationDate != "20111228" {
+		t.Errorf("EP member: got %s (%s)", ep.PatentNumber, ep.PublicationDate)
+	}
+	if ep.Titles["fr"] != "MISE EN RÉSEAU BLUETOOTH" || ep.Titles["de"] != "BLUETOOTH-VERNETZUNG" {
+		t.Errorf("EP titles: got %v", ep.Titles)
+	}
+	if len(ep.Applicants) != 1 || ep.Applicants[0].Name != "9SOLUTIONS OY" || ep.Applicants[0].Country != "FI" {
+		t.Errorf("EP applicants: got %+v", ep.Applicants)
+	}
+	if len(ep.IPCClasses) != 1 || len(ep.CPCClasses) != 1 || ep.CPCClasses[0].Full != "H04W 84/20" {
+		t.Errorf("EP classifications: IPC %v, CPC %+v", ep.IPCClasses, ep.CPCClasses)
+	}
+
+	// Distinct titles per member
+	if ca := data.Members[1].Biblio; ca.Titles["fr"] != "RESEAUTAGE PAR LIEN BLUETOOTH" {
+		t.Errorf("CA french title: got %q", ca.Titles["fr"])
+	}
+	us := data.Members[2].Biblio
+	if us.Titles["en"] != "Bluetooth networking" {
+		t.Errorf("US title: got %q", us.Titles["en"])
+	}
+	if len(us.Applicants) != 2 || us.Applicants[1].Name != "KYLMÄNEN JARI" {
+		t.Errorf("US applicants: got %+v", us.Applicants)
+	}
+
+	// Family responses without biblio constituent leave Biblio nil
+	plain, err := os.ReadFile("testdata/family.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	data, err = ParseFamilyBiblio(string(plain))
+	if err != nil {
+		t.Fatalf("ParseFamilyBiblio (plain) failed: %v", err)
+	}
+	if len(data.Members) == 0 || data.Members[0].Biblio != nil {
+		t.Errorf("Plain family: expected members without biblio data")
+	}
+}
+
+func TestParseFamilyLegal(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/family_legal.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseFamilyLegal(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamilyLegal failed: %v", err)
+	}
+
+	if data.PatentNumber != "EP2400812" || data.FamilyID != "43088294" || data.TotalCount != 2 {
+		t.Errorf("Family: got %s, ID %q, total %d", data.PatentNumber, data.FamilyID, data.TotalCount)
+	}
+	if len(data.Members) != 2 {
+		t.Fatalf("Members: got %d, want 2", len(data.Members))
+	}
+
+	ep := data.Members[0]
+	if ep.Country != "EP" || ep.DocNumber != "2400812" {
+		t.Errorf("EP member: got %s%s", ep.Country, ep.DocNumber)
+	}
+	if len(ep.LegalEvents) != 3 {
+		t.Fatalf("EP legal events: got %d, want 3", len(ep.LegalEvents))
+	}
+	withdrawn := ep.LegalEvents[2]
+	if withdrawn.Code != "18D " || withdrawn.Influence != "-" || withdrawn.DateMigr != "20140130" {
+		t.Errorf("EP event 3: got %+v", withdrawn)
+	}
+	if withdrawn.Fields["L007EP"] != "2014-01-29" {
+		t.Errorf("EP event 3 L007EP: got %q", withdrawn.Fields["L007EP"])
+	}
+
+	us := data.Members[1]
+	if us.Country != "US" || len(us.LegalEvents) != 1 {
+		t.Fatalf("US member: got %s with %d events, want 1", us.Country, len(us.LegalEvents))
+	}
+	if us.LegalEvents[0].Code != "AS  " || us.LegalEvents[0].Fields["L001EP"] != "US" {
+		t.Errorf("US event: got %+v", us.LegalEvents[0])
+	}
+
+	// Family responses without legal constituent leave LegalEvents empty
+	plain, err := os.ReadFile("testdata/family.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	data, err = ParseFamilyLegal(string(plain))
+	if err != nil {
+		t.Fatalf("ParseFamilyLegal (plain) failed: %v", err)
+	}
+	if len(data.Members) == 0 || len(data.Members[0].LegalEvents) != 0 {
+		t.Errorf("Plain family: expected members without legal events")
+	}
+}
+
+func TestFamilyLegalDataStatusSummary(t *testing.T) {
+	event := func(code, desc, date string) LegalEvent {
+		return LegalEvent{Code: code, Description: desc, DateMigr: date}
+	}
+	member := func(country, kind string, events ...LegalEvent) FamilyLegalMember {
+		return FamilyLegalMember{FamilyMember: FamilyMember{Country: country, Kind: kind}, LegalEvents: events}
+	}
+	data := &FamilyLegalData{Members: []FamilyLegalMember{
+		// Granted without opposition; a lapse in one contracting state does not count
+		member("EP", "A1",
+			event("17P ", "REQUEST FOR EXAMINATION FILED", "20120425"),
+			event("26N ", "NO OPPOSITION FILED", "20160301"),
+			event("PG25", "LAPSED IN A CONTRACTING STATE [ANNOUNCED VIA POSTGRANT INFORMATION FROM NATIONAL OFFICE TO EPO]", "20170101")),
+		// Granted publication, lapse listed before an older event
+		member("US", "B2",
+			event("FP  ", "LAPSED DUE TO FAILURE TO PAY MAINTENANCE FEE", "20200515"),
+			event("AS  ", "ASSIGNMENT", "20150101")),
+		// Application withdrawn after publication
+		member("CN", "A",
+			event("C06 ", "PUBLICATION", "20110101"),
+			event("C10 ", "ENTRY INTO SUBSTANTIVE EXAMINATION", "20110301"),
+			event("WD01", "INVENTION PATENT APPLICATION DEEMED WITHDRAWN AFTER PUBLICATION", "20130601")),
+		// No events: pending
+		member("JP", "A"),
+		// Lapsed and restored
+		member("GB", "B",
+			event("PCNP", "PATENT CEASED THROUGH NON-PAYMENT OF RENEWAL FEE", "20180101"),
+			event("S28 ", "RESTORATION OF PATENTS", "20180601")),
+		// A withdrawn application and a granted patent of the same country
+		member("DE", "A1", event("R120", "APPLICATION WITHDRAWN OR TAKEN TO BE WITHDRAWN", "20140101")),
+		member("DE", "B4", event("R020", "PATENT GRANTED NOW FINAL", "20150101")),
+	}}
+
+	want := map[string]string{
+		"EP": LegalStatusGranted,
+		"US": LegalStatusLapsed,
+		"CN": LegalStatusWithdrawn,
+		"JP": LegalStatusPending,
+		"GB": LegalStatusGranted,
+		"DE": LegalStatusGranted,
+	}
+	if got := data.StatusSummary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StatusSummary():\ngot  %v\nwant %v", got, want)
+	}
+	if got := data.Members[5].LegalStatus(); got != LegalStatusWithdrawn {
+		t.Errorf("DE application status: got %q, want %q", got, LegalStatusWithdrawn)
+	}
+
+	// A granted EP patent later revoked after opposition proceedings
+	revoked := member("EP", "B1",
+		event("26  ", "OPPOSITION FILED", "20170101"),
+		event("RDAE", "INFORMATION PROVIDED ON EPO PATENT REVOKED", "20190101"))
+	if got := revoked.LegalStatus(); got != LegalStatusLapsed {
+		t.Errorf("Revoked EP patent status: got %q, want %q", got, LegalStatusLapsed)
+	}
+
+	xmlData, err := os.ReadFile("testdata/family_legal.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	parsed, err := ParseFamilyLegal(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamilyLegal failed: %v", err)
+	}
+	want = map[string]string{"EP": LegalStatusWithdrawn, "US": LegalStatusPending}
+	if got := parsed.StatusSummary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StatusSummary() of family_legal.xml: got %v, want %v", got, want)
+	}
+}
+
+func TestParseFullCycle(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/full_cycle.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseFullCycle(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFullCycle failed: %v", err)
+	}
+
+	if data.PatentNumber != "EP2400812" || data.FamilyID != "43088294" {
+		t.Errorf("Patent: got %s (family %s)", data.PatentNumber, data.FamilyID)
+	}
+	if len(data.Stages) != 2 {
+		t.Fatalf("Stages: got %d, want 2", len(data.Stages))
+	}
+
+	want := []struct{ kind, date, documentID string }{
+		{"A1", "20111228", "EP.2400812.A1"},
+		{"B1", "20191127", "EP.2400812.B1"},
+	}
+	for i, w := range want {
+		stage := data.Stages[i]
+		if stage.Kind != w.kind || stage.Date != w.date || stage.DocumentID != w.documentID {
+			t.Errorf("Stage %d: got %s %s %s, want %s %s %s", i,
+				stage.Kind, stage.Date, stage.DocumentID, w.kind, w.date, w.documentID)
+		}
+		if stage.Biblio == nil || stage.Biblio.Titles["en"] != "BLUETOOTH NETWORKING" {
+			t.Errorf("Stage %d: missing biblio data", i)
+		}
+	}
+
+	// The grant carries classifications that were added after the application
+	if len(data.Stages[1].Biblio.IPCClasses) <= len(data.Stages[0].Biblio.IPCClasses) {
+		t.Errorf("Expected more IPC classes at grant: A1 %v, B1 %v",
+			data.Stages[0].Biblio.IPCClasses, data.Stages[1].Biblio.IPCClasses)
+	}
+
+	if _, err := ParseFullCycle(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without publication stages")
+	}
+}
+
 func TestParseLegal(t *testing.T) {
 	xmlData, err := os.ReadFile("demo/examples/get_legal/response.xml")
 	if err != nil {
 		t.Fatalf("Failed to read test file: %v", err)
 	}
 
-	data, err := ParseLegal(string(xmlData))
+	data, err := ParseLegal(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseLegal failed: %v", err)
+	}
+
+	if len(data.LegalEvents) == 0 {
+		t.Error("Expected legal events, got none")
+	}
+
+	t.Logf("Parsed %d legal events for patent %s", len(data.LegalEvents), data.PatentNumber)
+	for i, event := range data.LegalEvents {
+		if i < 5 { // Log first 5 events
+			t.Logf("Event %d: Code=%s, Desc=%s, Influence=%s", i+1, event.Code, event.Description, event.Influence)
+		}
+	}
+}
+
+func TestLegalDataFilters(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/legal_events.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseLegal(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseLegal failed: %v", err)
+	}
+	if len(data.LegalEvents) != 6 {
+		t.Fatalf("LegalEvents: got %d, want 6", len(data.LegalEvents))
+	}
+
+	positive := data.FilterByInfluence("+")
+	if len(positive) != 3 {
+		t.Errorf("FilterByInfluence(+): got %d events, want 3", len(positive))
+	}
+	negative := data.FilterByInfluence("-")
+	if len(negative) != 2 {
+		t.Errorf("FilterByInfluence(-): got %d events, want 2", len(negative))
+	}
+	for _, event := range negative {
+		if event.Code != "PG25" {
+			t.Errorf("FilterByInfluence(-): unexpected code %q", event.Code)
+		}
+	}
+	if neutral := data.FilterByInfluence(""); len(neutral) != 1 || neutral[0].Code != "PGFP" {
+		t.Errorf("FilterByInfluence(\"\"): got %+v, want the PGFP event", neutral)
+	}
+
+	postGrant := data.FilterByCodePrefix("pg")
+	if len(postGrant) != 3 {
+		t.Errorf("FilterByCodePrefix(pg): got %d events, want 3", len(postGrant))
+	}
+	if events := data.FilterByCodePrefix("XX"); events != nil {
+		t.Errorf("FilterByCodePrefix(XX): got %d events, want none", len(events))
+	}
+
+	latest := data.LatestByCode()
+	want := map[string]string{
+		"AK":   "20141022",
+		"17P":  "20120425",
+		"PG25": "20170630",
+		"PGFP": "20150731",
+	}
+	if len(latest) != len(want) {
+		t.Errorf("LatestByCode: got %d codes, want %d", len(latest), len(want))
+	}
+	for code, date := range want {
+		if event, ok := latest[code]; !ok || event.DateMigr != date {
+			t.Errorf("LatestByCode[%s]: got %q, want %q", code, event.DateMigr, date)
+		}
+	}
+}
+
+func TestParseProceduralSteps(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/register_procedural_steps.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseProceduralSteps(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseProceduralSteps failed: %v", err)
+	}
+
+	if data.Status != "No opposition filed within time limit" {
+		t.Errorf("Status: got %q", data.Status)
+	}
+	if len(data.Steps) != 8 {
+		t.Fatalf("Steps: got %d, want 8", len(data.Steps))
+	}
+
+	tests := []struct {
+		index       int
+		code        string
+		phase       string
+		date        string
+		description string
+	}{
+		{0, "PFEE", "filing", "20100624", "Payment of the filing fee"},
+		{1, "SREP", "search", "20101020", "Despatch of the European search report"},
+		{2, "EXAM", "examination", "20110620", "Request for examination"},
+		{4, "IGRA", "examination", "20190627", "Intention to grant the patent"},
+		{5, "GRNT", "grant", "20191127", "The patent has been granted"},
+	}
+	for _, tt := range tests {
+		step := data.Steps[tt.index]
+		if step.Code != tt.code || step.Phase != tt.phase || step.Date != tt.date || step.Description != tt.description {
+			t.Errorf("Step %d: got %s/%s/%s/%q, want %s/%s/%s/%q", tt.index,
+				step.Code, step.Phase, step.Date, step.Description,
+				tt.code, tt.phase, tt.date, tt.description)
+		}
+	}
+
+	exre := data.Steps[3]
+	if len(exre.Deadlines) != 1 || exre.Deadlines[0] != "04 months" {
+		t.Errorf("EXRE deadlines: got %v", exre.Deadlines)
+	}
+	if exre.Dates["DATE_OF_REPLY"] != "20170601" {
+		t.Errorf("EXRE reply date: got %q", exre.Dates["DATE_OF_REPLY"])
+	}
+
+	if renewal := data.Steps[6]; renewal.Texts["YEAR"] != "03" {
+		t.Errorf("Renewal year: got %q", renewal.Texts["YEAR"])
+	}
+
+	// Steps without dates keep an empty Date
+	if prol := data.Steps[7]; prol.Date != "" || prol.Texts["procedure language"] != "en" {
+		t.Errorf("PROL step: got date %q, texts %v", prol.Date, prol.Texts)
+	}
+
+	if _, err := ParseProceduralSteps(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without register document")
+	}
+}
+
+func TestParseRegisterBiblio(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/register_biblio.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseRegisterBiblio(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseRegisterBiblio failed: %v", err)
+	}
+
+	if data.Status != "No opposition filed within time limit" {
+		t.Errorf("Status: got %q", data.Status)
+	}
+	if data.ApplicationNumber != "EP10167109" || data.FilingDate != "20100624" {
+		t.Errorf("Application: got %s filed %s", data.ApplicationNumber, data.FilingDate)
+	}
+	if len(data.Publications) != 2 || data.Publications[1].Kind != "B1" {
+		t.Errorf("Publications: got %+v", data.Publications)
+	}
+	if len(data.Statuses) != 3 || data.Statuses[1] != (RegisterStatus{Code: "8", Date: "20191025", Text: "The patent has been granted"}) {
+		t.Errorf("Statuses: got %+v", data.Statuses)
+	}
+	if data.Titles["en"] != "BLUETOOTH NETWORKING" || data.Titles["de"] != "BLUETOOTH-VERNETZUNG" {
+		t.Errorf("Titles: got %v", data.Titles)
+	}
+	if len(data.Applicants) != 1 || data.Applicants[0] != "9Solutions Oy" {
+		t.Errorf("Applicants: got %v", data.Applicants)
+	}
+
+	// The latest designation no longer includes IT; the region EP is not a state
+	wantStates := []string{"AT", "BE", "CH", "DE", "FI", "FR", "GB", "HU"}
+	if !reflect.DeepEqual(data.DesignatedStates, wantStates) {
+		t.Errorf("DesignatedStates: got %v, want %v", data.DesignatedStates, wantStates)
+	}
+
+	// Lapses come from the latest term-of-grant
+	wantLapsed := map[string]string{"HU": "20100624", "AT": "20191127", "BE": "20200630"}
+	if !reflect.DeepEqual(data.LapsedStates, wantLapsed) {
+		t.Errorf("LapsedStates: got %v, want %v", data.LapsedStates, wantLapsed)
+	}
+
+	// Renewal fee for year 05 pays up to the 5th anniversary of the filing date
+	if got := data.RenewalStatus["EP"]; got != "20150624" {
+		t.Errorf("RenewalStatus[EP]: got %q, want 20150624", got)
+	}
+
+	// Without procedural data there is no renewal information
+	biblioOnly := strings.ReplaceAll(string(xmlData), "RFEE", "PFEE")
+	data, err = ParseRegisterBiblio(biblioOnly)
+	if err != nil {
+		t.Fatalf("ParseRegisterBiblio failed: %v", err)
+	}
+	if len(data.RenewalStatus) != 0 {
+		t.Errorf("RenewalStatus without RFEE steps: got %v", data.RenewalStatus)
+	}
+
+	if _, err := ParseRegisterBiblio(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without register document")
+	}
+}
+
+func TestParseRegisterEvents(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/register_events.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseRegisterEvents(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseRegisterEvents failed: %v", err)
+	}
+
+	if data.Status != "No opposition filed within time limit" {
+		t.Errorf("Status: got %q", data.Status)
+	}
+
+	want := []RegisterEvent{
+		{"EVT_434577438", "0009250", "Lapse of the patent in a contracting state", "20220708", false},
+		{"EVT_434577450", "0009261", "No opposition filed within time limit", "20201002", true},
+		{"EVT_434577461", "0009210", "(Expected) grant", "20191025", true},
+		{"EVT_434577466", "EPIDOSNIGR1", "New entry: Communication of intention to grant a patent", "20190626", true},
+		{"EVT_434577472", "EPIDOSNEXR3", "New entry: Reply to examination report", "20190510", false},
+		{"EVT_434577490", "0009171", "Request for examination filed", "20120615", false},
+		{"EVT_434577492", "0009012", "Publication in section I.1 EP Bulletin", "20111228", false},
+	}
+	if !reflect.DeepEqual(data.Events, want) {
+		t.Errorf("Events:\ngot  %+v\nwant %+v", data.Events, want)
+	}
+
+	if _, err := ParseRegisterEvents(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without register document")
+	}
+}
+
+func TestParseRegisterUNIP(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/register_unip_unitary.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseRegisterUNIP(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseRegisterUNIP failed: %v", err)
+	}
+	if !data.UnitaryEffectRegistered || data.Status != "Unitary effect registered" {
+		t.Errorf("Unitary effect: got %v (%q)", data.UnitaryEffectRegistered, data.Status)
+	}
+	if data.RequestDate != "20230710" {
+		t.Errorf("RequestDate: got %q", data.RequestDate)
+	}
+	if len(data.ParticipatingStates) != 17 || data.ParticipatingStates[0] != "AT" || data.ParticipatingStates[16] != "SI" {
+		t.Errorf("ParticipatingStates: got %v", data.ParticipatingStates)
+	}
+	if len(data.Statuses) != 2 || data.Statuses[0].Code != "UP2" || data.Statuses[0].Date != "20230801" {
+		t.Errorf("Statuses: got %+v", data.Statuses)
+	}
+	if data.UPCOptOut || data.OptOutDate != "" {
+		t.Errorf("Opt-out: got %v (%q), want none", data.UPCOptOut, data.OptOutDate)
+	}
+
+	xmlData, err = os.ReadFile("testdata/register_unip_optout.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err = ParseRegisterUNIP(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseRegisterUNIP (opt-out) failed: %v", err)
+	}
+	if !data.UPCOptOut || data.OptOutDate != "20230301" {
+		t.Errorf("Opt-out: got %v (%q), want true (20230301)", data.UPCOptOut, data.OptOutDate)
+	}
+	if data.UnitaryEffectRegistered || data.Status != "" || len(data.ParticipatingStates) != 0 {
+		t.Errorf("Unitary effect: got %+v, want none", data)
+	}
+
+	// A later withdrawal clears the opt-out
+	withdrawn := strings.Replace(string(xmlData), "<ns3:events-data>", `<ns3:events-data>
+          <ns3:dossier-event id="EVT_3" event-type="new">
+            <ns3:event-date><ns3:date>20240105</ns3:date></ns3:event-date>
+            <ns3:event-code>UPOW</ns3:event-code>
+            <ns3:event-text event-text-type="DESCRIPTION">Withdrawal of the opt-out registered</ns3:event-text>
+          </ns3:dossier-event>`, 1)
+	data, err = ParseRegisterUNIP(withdrawn)
+	if err != nil {
+		t.Fatalf("ParseRegisterUNIP (withdrawn) failed: %v", err)
+	}
+	if data.UPCOptOut || data.OptOutDate != "" {
+		t.Errorf("Withdrawn opt-out: got %v (%q), want none", data.UPCOptOut, data.OptOutDate)
+	}
+
+	// Real response for a patent without UPP data
+	xmlData, err = os.ReadFile("demo/examples/get_register_unip/response.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	data, err = ParseRegisterUNIP(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseRegisterUNIP (demo) failed: %v", err)
+	}
+	if data.UnitaryEffectRegistered || data.UPCOptOut {
+		t.Errorf("Demo response: got %+v, want no unitary effect or opt-out", data)
+	}
+
+	// JSON responses are not supported
+	var validationErr *DataValidationError
+	if _, err := ParseRegisterUNIP(`{"ops:world-patent-data": {}}`); !errors.As(err, &validationErr) {
+		t.Errorf("Expected DataValidationError for JSON input, got %v", err)
+	}
+}
+
+func TestParseDescription(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/description.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseDescription(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseDescription failed: %v", err)
+	}
+
+	if len(data.Paragraphs) == 0 {
+		t.Error("Expected paragraphs, got none")
+	}
+
+	t.Logf("Parsed %d paragraphs for patent %s (language: %s)", len(data.Paragraphs), data.PatentNumber, data.Language)
+	for i, para := range data.Paragraphs {
+		if i < 3 { // Log first 3 paragraphs
+			maxLen := 50
+			if len(para.Text) < maxLen {
+				maxLen = len(para.Text)
+			}
+			t.Logf("Paragraph %d (ID=%s): %s", i+1, para.ID, para.Text[:maxLen]+"...")
+		}
+	}
+}
+
+func TestStreamDescription(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/description.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	parsed, err := ParseDescription(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseDescription failed: %v", err)
+	}
+
+	var streamed []Paragraph
+	err = StreamDescription(bytes.NewReader(xmlData), func(p Paragraph) error {
+		streamed = append(streamed, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamDescription failed: %v", err)
+	}
+
+	// Streaming yields the same paragraphs as ParseDescription
+	if !reflect.DeepEqual(streamed, parsed.Paragraphs) {
+		t.Errorf("Streamed paragraphs differ from parsed:\n got: %+v\nwant: %+v", streamed, parsed.Paragraphs)
+	}
+
+	t.Run("Callback error stops streaming", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := StreamDescription(bytes.NewReader(xmlData), func(p Paragraph) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("Expected callback error, got: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 callback, got: %d", calls)
+		}
+	})
+
+	t.Run("Truncated XML", func(t *testing.T) {
+		truncated := xmlData[:bytes.Index(xmlData, []byte(`<p id="p-0003"`))+10]
+		calls := 0
+		err := StreamDescription(bytes.NewReader(truncated), func(p Paragraph) error {
+			calls++
+			return nil
+		})
+		var parseErr *XMLParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected XMLParseError, got: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected paragraphs before truncation to be emitted (2), got: %d", calls)
+		}
+	})
+}
+
+func TestParseDescription_Markup(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/description_markup.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseDescription(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseDescription failed: %v", err)
+	}
+
+	want := []Paragraph{
+		{ID: "p0001", Num: "0001", Text: "The invention relates to the production of hydrogen peroxide (H2O2) from H2 and O2."},
+		{ID: "p0002", Num: "0002", Text: "As shown in Fig. 1, the reactor (1) is fed via the inlet (2); see also paragraph [0001].",
+			Refs: []ParagraphRef{
+				{Element: "figref", IDRef: "f0001", Text: "Fig. 1"},
+				{Element: "crossref", IDRef: "p0001", Text: "[0001]"},
+			}},
+		{ID: "p0003", Num: "0003", Text: "The yield is at least 95 %, measured at 105 Pa."},
+	}
+	if !reflect.DeepEqual(data.Paragraphs, want) {
+		t.Errorf("Paragraphs:\n got: %+v\nwant: %+v", data.Paragraphs, want)
+	}
+
+	// Streaming keeps the same inline text and references
+	var streamed []Paragraph
+	err = StreamDescription(bytes.NewReader(xmlData), func(p Paragraph) error {
+		streamed = append(streamed, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamDescription failed: %v", err)
+	}
+	if !reflect.DeepEqual(streamed, want) {
+		t.Errorf("Streamed paragraphs:\n got: %+v\nwant: %+v", streamed, want)
+	}
+}
+
+// largeDescriptionXML builds a description document with n paragraphs
+func largeDescriptionXML(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` +
+		`<ops:world-patent-data xmlns:ops="http://ops.epo.org" xmlns="http://www.epo.org/exchange">` +
+		`<ftxt:fulltext-documents xmlns:ftxt="http://www.epo.org/fulltext">` +
+		`<ftxt:fulltext-document country="EP" doc-number="2400812" kind="B1"><description lang="en">`)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&buf, `<p id="p-%05d" num="%05d">The compound of example %d was dissolved in 50 ml of `+
+			`dichloromethane and stirred at room temperature for 2 hours before purification.</p>`, i, i, i)
+	}
+	buf.WriteString(`</description></ftxt:fulltext-document></ftxt:fulltext-documents></ops:world-patent-data>`)
+	return buf.Bytes()
+}
+
+func BenchmarkParseDescription(b *testing.B) {
+	xmlData := string(largeDescriptionXML(5000))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseDescription(xmlData); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStreamDescription(b *testing.B) {
+	xmlData := largeDescriptionXML(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := StreamDescription(bytes.NewReader(xmlData), func(p Paragraph) error {
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// largeFulltextXML builds a fulltext document with n description paragraphs and two claims
+func largeFulltextXML(n int) string {
+	claims := `<claims lang="en"><claim id="c-en-0001" num="0001"><claim-text>A method comprising a step.</claim-text></claim>` +
+		`<claim id="c-en-0002" num="0002"><claim-text>The method of claim 1.</claim-text></claim></claims>`
+	return strings.Replace(string(largeDescriptionXML(n)), "</ftxt:fulltext-document>", claims+"</ftxt:fulltext-document>", 1)
+}
+
+func TestParseFulltext(t *testing.T) {
+	data, err := ParseFulltext(string(loadTestData("fulltext.xml")))
+	if err != nil {
+		t.Fatalf("ParseFulltext failed: %v", err)
+	}
+	if data.Country != "EP" || data.DocNumber != "2400812" || data.Kind != "A1" || data.Language != "en" {
+		t.Errorf("Document: got %s%s%s (%s)", data.Country, data.DocNumber, data.Kind, data.Language)
+	}
+	if data.Biblio == nil || data.Biblio.Titles["de"] != "BLUETOOTH-VERNETZUNG" {
+		t.Errorf("Biblio: got %+v", data.Biblio)
+	}
+	if data.Abstract == nil || !strings.Contains(data.Abstract.Text, "\nEach circuitry connects") {
+		t.Errorf("Abstract: got %+v", data.Abstract)
+	}
+	if data.Description == nil || len(data.Description.Paragraphs) != 2 {
+		t.Errorf("Description: got %+v", data.Description)
+	}
+	if data.Claims == nil || len(data.Claims.Claims) != 2 {
+		t.Errorf("Claims: got %+v", data.Claims)
+	}
+
+	// Sections the document lacks are empty, not nil
+	data, err = ParseFulltext(largeFulltextXML(1))
+	if err != nil {
+		t.Fatalf("ParseFulltext failed: %v", err)
+	}
+	if data.Biblio == nil || len(data.Biblio.Titles) != 0 {
+		t.Errorf("Biblio without bibliographic-data: got %+v", data.Biblio)
+	}
+	if data.Abstract == nil || data.Abstract.Text != "" {
+		t.Errorf("Abstract without abstract: got %+v", data.Abstract)
+	}
+
+	var parseErr *XMLParseError
+	if _, err := ParseFulltext("<world-patent-data>"); !errors.As(err, &parseErr) {
+		t.Errorf("Expected XMLParseError for malformed XML, got %v", err)
+	}
+	var validationErr *DataValidationError
+	if _, err := ParseFulltext(string(loadTestData("biblio.xml"))); !errors.As(err, &validationErr) {
+		t.Errorf("Expected DataValidationError for a biblio response, got %v", err)
+	}
+}
+
+func TestParseFulltextSections(t *testing.T) {
+	xmlData := largeFulltextXML(3)
+
+	data, err := ParseFulltextSections(xmlData, EndpointClaims)
+	if err != nil {
+		t.Fatalf("ParseFulltextSections failed: %v", err)
+	}
+	if data.Country != "EP" || data.DocNumber != "2400812" || data.Kind != "B1" {
+		t.Errorf("Document: got %s%s%s", data.Country, data.DocNumber, data.Kind)
+	}
+	if data.Claims == nil || len(data.Claims.Claims) != 2 {
+		t.Fatalf("Claims: got %+v, want 2 claims", data.Claims)
+	}
+	if data.Biblio != nil || data.Abstract != nil || data.Description != nil {
+		t.Errorf("Unrequested sections: biblio %v, abstract %v, description %v", data.Biblio, data.Abstract, data.Description)
+	}
+
+	data, err = ParseFulltextSections(xmlData, EndpointDescription, EndpointClaims)
+	if err != nil {
+		t.Fatalf("ParseFulltextSections failed: %v", err)
+	}
+	if data.Description == nil || len(data.Description.Paragraphs) != 3 || data.Claims == nil {
+		t.Errorf("Description and claims: got %+v, %+v", data.Description, data.Claims)
+	}
+	if data.Biblio != nil || data.Abstract != nil {
+		t.Errorf("Unrequested sections: biblio %v, abstract %v", data.Biblio, data.Abstract)
+	}
+
+	// Without sections, the result matches ParseFulltext
+	all, err := ParseFulltextSections(xmlData)
+	if err != nil {
+		t.Fatalf("ParseFulltextSections failed: %v", err)
+	}
+	want, err := ParseFulltext(xmlData)
+	if err != nil {
+		t.Fatalf("ParseFulltext failed: %v", err)
+	}
+	if !reflect.DeepEqual(all, want) {
+		t.Errorf("All sections:\n got: %+v\nwant: %+v", all, want)
+	}
+
+	// Bibliographic data and abstracts are read from the fulltext-document
+	front, err := ParseFulltextSections(string(loadTestData("fulltext.xml")), EndpointBiblio, EndpointAbstract)
+	if err != nil {
+		t.Fatalf("ParseFulltextSections failed: %v", err)
+	}
+	if front.Biblio == nil || front.Biblio.Titles["en"] != "BLUETOOTH NETWORKING" || front.Biblio.PublicationDate != "20111228" {
+		t.Errorf("Biblio: got %+v", front.Biblio)
+	}
+	if front.Abstract == nil || front.Abstract.Language != "en" ||
+		!strings.HasPrefix(front.Abstract.Text, "The invention relates to a communication device") {
+		t.Errorf("Abstract: got %+v", front.Abstract)
+	}
+	if front.Description != nil || front.Claims != nil {
+		t.Errorf("Unrequested sections: description %v, claims %v", front.Description, front.Claims)
+	}
+
+	var validationErr *ValidationError
+	if _, err := ParseFulltextSections(xmlData, "drawings"); !errors.As(err, &validationErr) || validationErr.Field != "sections" {
+		t.Errorf("Expected ValidationError for unknown section, got %v", err)
+	}
+}
+
+func BenchmarkParseFulltext(b *testing.B) {
+	xmlData := largeFulltextXML(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseFulltext(xmlData); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseFulltextClaimsOnly(b *testing.B) {
+	xmlData := largeFulltextXML(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseFulltextSections(xmlData, EndpointClaims); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseSearch(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/search.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseSearch(string(xmlData))
 	if err != nil {
-		t.Fatalf("ParseLegal failed: %v", err)
+		t.Fatalf("ParseSearch failed: %v", err)
 	}
 
-	if len(data.LegalEvents) == 0 {
-		t.Error("Expected legal events, got none")
+	if data.TotalCount == 0 {
+		t.Error("Expected total count > 0")
 	}
 
-	t.Logf("Parsed %d legal events for patent %s", len(data.LegalEvents), data.PatentNumber)
-	for i, event := range data.LegalEvents {
-		if i < 5 { // Log first 5 events
-			t.Logf("Event %d: Code=%s, Desc=%s, Influence=%s", i+1, event.Code, event.Description, event.Influence)
+	t.Logf("Search results: %d total, range %d-%d, found %d results",
+		data.TotalCount, data.RangeBegin, data.RangeEnd, len(data.Results))
+	t.Logf("Query: %s", data.Query)
+
+	for i, result := range data.Results {
+		t.Logf("Result %d: %s%s (Family %s)", i+1, result.Country, result.DocNumber, result.FamilyID)
+	}
+}
+
+func TestParseSearchEmpty(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/search_empty.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseSearch(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseSearch failed: %v", err)
+	}
+	if !data.IsEmpty() {
+		t.Errorf("Expected empty search result, got %d total, %d results", data.TotalCount, len(data.Results))
+	}
+	if data.Query != `ti="zzqxv"` {
+		t.Errorf("Query: got %q", data.Query)
+	}
+
+	full, err := os.ReadFile("testdata/search.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	if data, err := ParseSearch(string(full)); err != nil || data.IsEmpty() {
+		t.Errorf("Expected non-empty search result, got %+v, %v", data, err)
+	}
+
+	// A response without biblio-search is not a search result
+	biblio, err := os.ReadFile("testdata/biblio.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	var validationErr *DataValidationError
+	if _, err := ParseSearch(string(biblio)); !errors.As(err, &validationErr) || validationErr.MissingField != "biblio-search" {
+		t.Errorf("Expected DataValidationError for biblio-search, got %v", err)
+	}
+}
+
+func TestSearchResultDataNextRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		total, end int
+		want       SearchRange
+		wantOK     bool
+	}{
+		{name: "First page", total: 250, end: 100, want: SearchRange{Begin: 101, End: 200}, wantOK: true},
+		{name: "Last partial page", total: 250, end: 200, want: SearchRange{Begin: 201, End: 250}, wantOK: true},
+		{name: "Exhausted", total: 250, end: 250},
+		{name: "Short first page", total: 250, end: 25, want: SearchRange{Begin: 26, End: 125}, wantOK: true},
+		{name: "Below ceiling", total: 5000, end: 1900, want: SearchRange{Begin: 1901, End: 2000}, wantOK: true},
+		{name: "Ceiling cutoff", total: 5000, end: 1950, want: SearchRange{Begin: 1951, End: 2000}, wantOK: true},
+		{name: "At ceiling", total: 5000, end: 2000},
+		{name: "Empty", total: 0, end: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &SearchResultData{TotalCount: tt.total, RangeBegin: 1, RangeEnd: tt.end}
+			got, ok := data.NextRange()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NextRange() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if data.HasMore() != tt.wantOK {
+				t.Errorf("HasMore() = %v, want %v", data.HasMore(), tt.wantOK)
+			}
+			if ok {
+				if err := got.Validate(); err != nil {
+					t.Errorf("NextRange() returned an invalid range: %v", err)
+				}
+			}
+		})
+	}
+
+	// The register search has its own ceiling
+	register := &RegisterSearchData{TotalCount: 10000, RangeBegin: 1, RangeEnd: 5}
+	if got, ok := register.NextRange(); !ok || got != (SearchRange{Begin: 6, End: 105}) {
+		t.Errorf("RegisterSearchData.NextRange() = %v, %v", got, ok)
+	}
+	register.RangeEnd = MaxRegisterSearchResults
+	if register.HasMore() {
+		t.Error("Expected no more register results at the ceiling")
+	}
+}
+
+func TestParseRegisterSearch(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/register_search.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseRegisterSearch(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseRegisterSearch failed: %v", err)
+	}
+
+	if data.TotalCount != 10000 || data.RangeBegin != 1 || data.RangeEnd != 5 || data.Query != "ti=battery" {
+		t.Errorf("Header: got count %d, range %d-%d, query %q", data.TotalCount, data.RangeBegin, data.RangeEnd, data.Query)
+	}
+	if len(data.Results) != 5 {
+		t.Fatalf("Results: got %d, want 5", len(data.Results))
+	}
+	want := RegisterSearchResult{
+		Country:           "EP",
+		PublicationNumber: "4636834",
+		PublicationDate:   "20251022",
+		ApplicationNumber: "EP25198922",
+		Title:             "PROCESSING EQUIPMENT, ELECTRODE PLATE PROCESSING EQUIPMENT, BATTERY PROCESSING EQUIPMENT, AND SHEET PROCESSING METHOD",
+	}
+	if data.Results[0] != want {
+		t.Errorf("First result:\ngot  %+v\nwant %+v", data.Results[0], want)
+	}
+	if data.Results[2].Title != "BATTERY PACK" {
+		t.Errorf("Third result title: got %q", data.Results[2].Title)
+	}
+	if data.IsEmpty() {
+		t.Error("Expected non-empty register search result")
+	}
+
+	empty, err := ParseRegisterSearch(`<world-patent-data><register-search total-result-count="0"/></world-patent-data>`)
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("Expected empty register search result, got %+v, %v", empty, err)
+	}
+
+	var validationErr *DataValidationError
+	if _, err := ParseRegisterSearch(`<world-patent-data></world-patent-data>`); !errors.As(err, &validationErr) {
+		t.Errorf("Expected DataValidationError for missing register-search, got %v", err)
+	}
+}
+
+func TestParseSearchFullCycle(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/search_full_cycle.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseSearchFullCycle(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseSearchFullCycle failed: %v", err)
+	}
+
+	if data.Query != "ti=networking" || data.TotalCount != 17 || data.RangeBegin != 1 || data.RangeEnd != 2 {
+		t.Errorf("Unexpected header: %q, total %d, range %d-%d", data.Query, data.TotalCount, data.RangeBegin, data.RangeEnd)
+	}
+	if len(data.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(data.Results))
+	}
+
+	want := []struct {
+		docNumber, kind, title string
+		stages                 []string
+	}{
+		{"2400812", "A1", "Bluetooth networking", []string{"A1 20111228", "B1 20191127"}},
+		{"3000001", "B1", "Battery module", []string{"A1 20160330", "B1 20190306", "B9 20190710"}},
+	}
+	for i, w := range want {
+		result := data.Results[i]
+		if result.DocNumber != w.docNumber || result.Kind != w.kind || result.Title != w.title {
+			t.Errorf("Result %d: got %s %s %q, want %s %s %q", i,
+				result.DocNumber, result.Kind, result.Title, w.docNumber, w.kind, w.title)
+		}
+		if result.FullCycle == nil {
+			t.Fatalf("Result %d: missing full cycle", i)
+		}
+		if result.FullCycle.PatentNumber != "EP"+w.docNumber || result.FullCycle.FamilyID != result.FamilyID {
+			t.Errorf("Result %d: got patent %s (family %s)", i, result.FullCycle.PatentNumber, result.FullCycle.FamilyID)
 		}
+		var stages []string
+		for _, stage := range result.FullCycle.Stages {
+			stages = append(stages, stage.Kind+" "+stage.Date)
+		}
+		if !reflect.DeepEqual(stages, w.stages) {
+			t.Errorf("Result %d stages: got %v, want %v", i, stages, w.stages)
+		}
+	}
+
+	if _, err := ParseSearchFullCycle(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without biblio-search")
 	}
 }
 
-func TestParseDescription(t *testing.T) {
-	xmlData, err := os.ReadFile("testdata/description.xml")
+func TestParseSearchBiblio(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/search_biblio.xml")
 	if err != nil {
 		t.Fatalf("Failed to read test file: %v", err)
 	}
 
-	data, err := ParseDescription(string(xmlData))
+	data, err := ParseSearchBiblio(string(xmlData))
 	if err != nil {
-		t.Fatalf("ParseDescription failed: %v", err)
+		t.Fatalf("ParseSearchBiblio failed: %v", err)
 	}
 
-	if len(data.Paragraphs) == 0 {
-		t.Error("Expected paragraphs, got none")
+	if data.TotalCount != 42 || data.RangeBegin != 1 || data.RangeEnd != 2 {
+		t.Errorf("Unexpected counts: total %d, range %d-%d", data.TotalCount, data.RangeBegin, data.RangeEnd)
+	}
+	if len(data.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(data.Results))
 	}
 
-	t.Logf("Parsed %d paragraphs for patent %s (language: %s)", len(data.Paragraphs), data.PatentNumber, data.Language)
-	for i, para := range data.Paragraphs {
-		if i < 3 { // Log first 3 paragraphs
-			maxLen := 50
-			if len(para.Text) < maxLen {
-				maxLen = len(para.Text)
+	want := []struct {
+		patentNumber string
+		title        string
+		date         string
+		applicants   []string
+	}{
+		{"EP2400812A1", "Battery Management System", "20111228", []string{"SAMSUNG SDI CO LTD"}},
+		{"US2011316474A1", "Improved Battery Technology", "20111229", []string{"PANASONIC CORP", "SANYO ELECTRIC CO"}},
+	}
+	for i, w := range want {
+		result := data.Results[i]
+		if result.Title != w.title {
+			t.Errorf("Result %d: expected title %q, got %q", i, w.title, result.Title)
+		}
+		if result.Biblio == nil {
+			t.Fatalf("Result %d: expected bibliographic data", i)
+		}
+		if result.Biblio.PatentNumber != w.patentNumber {
+			t.Errorf("Result %d: expected patent number %s, got %s", i, w.patentNumber, result.Biblio.PatentNumber)
+		}
+		if result.Biblio.PublicationDate != w.date {
+			t.Errorf("Result %d: expected publication date %s, got %s", i, w.date, result.Biblio.PublicationDate)
+		}
+		if len(result.Biblio.Applicants) != len(w.applicants) {
+			t.Fatalf("Result %d: expected %d applicants, got %d", i, len(w.applicants), len(result.Biblio.Applicants))
+		}
+		for j, name := range w.applicants {
+			if result.Biblio.Applicants[j].Name != name {
+				t.Errorf("Result %d: expected applicant %q, got %q", i, name, result.Biblio.Applicants[j].Name)
 			}
-			t.Logf("Paragraph %d (ID=%s): %s", i+1, para.ID, para.Text[:maxLen]+"...")
 		}
 	}
+
+	first := data.Results[0].Biblio
+	if len(first.IPCClasses) != 1 || len(first.CPCClasses) != 1 || first.CPCClasses[0].Full != "H01M 10/44" {
+		t.Errorf("Unexpected classifications: IPC %v, CPC %v", first.IPCClasses, first.CPCClasses)
+	}
+	if len(first.Inventors) != 1 || first.Inventors[0].Country != "KR" {
+		t.Errorf("Unexpected inventors: %v", first.Inventors)
+	}
+
+	// The flat layout of plain searches is accepted too
+	flat, err := os.ReadFile("testdata/search.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	flatData, err := ParseSearchBiblio(string(flat))
+	if err != nil {
+		t.Fatalf("ParseSearchBiblio (flat) failed: %v", err)
+	}
+	if len(flatData.Results) == 0 || flatData.Results[0].Biblio == nil {
+		t.Error("Expected results with bibliographic data for flat layout")
+	}
+
+	if _, err := ParseSearchBiblio("<not-xml"); err == nil {
+		t.Error("Expected error for invalid XML")
+	}
 }
 
-func TestParseSearch(t *testing.T) {
-	xmlData, err := os.ReadFile("testdata/search.xml")
+func TestParseClassificationMapping(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/classification_mapping.xml")
 	if err != nil {
 		t.Fatalf("Failed to read test file: %v", err)
 	}
 
-	data, err := ParseSearch(string(xmlData))
+	data, err := ParseClassificationMapping(string(xmlData))
 	if err != nil {
-		t.Fatalf("ParseSearch failed: %v", err)
+		t.Fatalf("ParseClassificationMapping failed: %v", err)
 	}
 
-	if data.TotalCount == 0 {
-		t.Error("Expected total count > 0")
+	if data.Input != "A01D2085/008" || data.InputScheme != "ECLA" || data.OutputScheme != "CPC" {
+		t.Errorf("Unexpected input: %q (%s -> %s)", data.Input, data.InputScheme, data.OutputScheme)
 	}
 
-	t.Logf("Search results: %d total, range %d-%d, found %d results",
-		data.TotalCount, data.RangeBegin, data.RangeEnd, len(data.Results))
-	t.Logf("Query: %s", data.Query)
+	want := []MappedClass{
+		{Symbol: "A01D2085/008", Scheme: "CPC", Additional: false},
+		{Symbol: "A01D2085/007", Scheme: "CPC", Additional: true},
+	}
+	if !reflect.DeepEqual(data.Mappings, want) {
+		t.Errorf("Mappings = %+v, want %+v", data.Mappings, want)
+	}
 
-	for i, result := range data.Results {
-		t.Logf("Result %d: %s%s (Family %s)", i+1, result.Country, result.DocNumber, result.FamilyID)
+	// Recorded CPC -> IPC response
+	demoData, err := os.ReadFile("demo/examples/get_classification_mapping/response.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	demo, err := ParseClassificationMapping(string(demoData))
+	if err != nil {
+		t.Fatalf("ParseClassificationMapping (demo) failed: %v", err)
+	}
+	if demo.Input != "H04W84/18" || len(demo.Mappings) != 1 || demo.Mappings[0].Scheme != "IPC" || !demo.Mappings[0].Additional {
+		t.Errorf("Unexpected demo mapping: %+v", demo)
+	}
+
+	// Responses without mappings are rejected
+	var dataErr *DataValidationError
+	if _, err := ParseClassificationMapping(`<ops:world-patent-data xmlns:ops="http://ops.epo.org"/>`); !errors.As(err, &dataErr) {
+		t.Errorf("Expected DataValidationError, got %v", err)
+	}
+}
+
+func TestParseClassificationSchema(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/classification_schema_subclass.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseClassificationSchema(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseClassificationSchema failed: %v", err)
+	}
+
+	if data.ExportDate != "2025-08-01" {
+		t.Errorf("ExportDate: got %q", data.ExportDate)
+	}
+	if len(data.Items) != 1 {
+		t.Fatalf("Items: got %d, want 1", len(data.Items))
+	}
+
+	subclass := data.Items[0]
+	if subclass.Symbol != "H04W" || subclass.Level != 5 || subclass.Title != "WIRELESS COMMUNICATION NETWORKS" {
+		t.Errorf("Subclass: got %s level %d %q", subclass.Symbol, subclass.Level, subclass.Title)
+	}
+	wantNotes := []ClassificationNote{
+		{Type: "note", Text: "This subclass covers: planning or deployment specially adapted for wireless networks;\n" +
+			"Cordless telephones are covered by group H04M1/72."},
+		{Type: "warning", Text: "In this subclass non-limiting references may still be displayed in the scheme."},
+	}
+	if !reflect.DeepEqual(subclass.Notes, wantNotes) {
+		t.Errorf("Notes:\ngot  %q\nwant %q", subclass.Notes, wantNotes)
+	}
+
+	// Symbols and levels of the whole tree, depth-first
+	var got []string
+	var walk func(nodes []ClassificationNode, depth int)
+	walk = func(nodes []ClassificationNode, depth int) {
+		for _, node := range nodes {
+			got = append(got, fmt.Sprintf("%s%s/%d", strings.Repeat(" ", depth), node.Symbol, node.Level))
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(subclass.Children, 0)
+	want := []string{
+		"H04W84/00/7",
+		" H04W84/02/8",
+		"  H04W84/04/9",
+		"   H04W84/042/10",
+		" H04W84/18/8",
+		"  H04W84/20/9",
+		"H04W88/00/7",
+		" H04W88/02/8",
+		" H04W88/08/8",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tree:\ngot  %q\nwant %q", got, want)
+	}
+
+	deep := subclass.Children[0].Children[0].Children[0]
+	if deep.Title != "Large scale networks; Deep hierarchical networks" || deep.DefinitionExists {
+		t.Errorf("H04W84/04: got %q (definition %v)", deep.Title, deep.DefinitionExists)
+	}
+	devices := subclass.Children[1]
+	if !devices.NotAllocatable || !devices.DefinitionExists {
+		t.Errorf("H04W88/00 flags: got not-allocatable %v, definition %v", devices.NotAllocatable, devices.DefinitionExists)
+	}
+	if notes := devices.Children[0].Notes; len(notes) != 1 || !strings.HasSuffix(notes[0].Text, "group H04M1/72.") {
+		t.Errorf("H04W88/02 notes: got %+v", notes)
+	}
+
+	if _, err := ParseClassificationSchema(`<world-patent-data></world-patent-data>`); err == nil {
+		t.Error("Expected error for response without classification scheme")
+	}
+}
+
+func TestParseClassificationStatistics(t *testing.T) {
+	want := []ClassificationStat{
+		{Symbol: "H04W72/00", Title: "Local resource management", Percentage: 9.770115},
+		{Symbol: "H04W24/00", Title: "Supervisory, monitoring or testing arrangements", Percentage: 6.130268},
+		{Symbol: "H04W4/00", Title: "Services specially adapted for wireless communication networks; Facilities therefor", Percentage: 3.6398468},
+		{Symbol: "H04L1/00", Title: "Arrangements for detecting or preventing errors in the information received", Percentage: 3.6398468},
+	}
+
+	for _, fixture := range []string{"classification_statistics.xml", "classification_statistics.json"} {
+		t.Run(fixture, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + fixture)
+			if err != nil {
+				t.Fatalf("Failed to read test file: %v", err)
+			}
+
+			stats, err := ParseClassificationStatistics(string(data))
+			if err != nil {
+				t.Fatalf("ParseClassificationStatistics failed: %v", err)
+			}
+			if !reflect.DeepEqual(stats, want) {
+				t.Errorf("Stats:\n got %+v\nwant %+v", stats, want)
+			}
+		})
+	}
+
+	// Real response, already ordered by EPO
+	data, err := os.ReadFile("demo/examples/get_classification_statistics/response.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	stats, err := ParseClassificationStatistics(string(data))
+	if err != nil {
+		t.Fatalf("ParseClassificationStatistics (demo) failed: %v", err)
+	}
+	if len(stats) != 10 || stats[0].Symbol != "H04W72/00" || stats[9].Symbol != "H04W92/00" {
+		t.Errorf("Demo stats: got %d entries %+v", len(stats), stats)
+	}
+
+	// Searches without matches yield an empty result
+	empty := []string{
+		`<ops:world-patent-data xmlns:ops="http://ops.epo.org"><ops:classification-search total-result-count="0"><ops:search-result/></ops:classification-search></ops:world-patent-data>`,
+		`{"ops:world-patent-data": {"ops:classification-search": {"@total-result-count": "0", "ops:search-result": ""}}}`,
+		`{"ops:world-patent-data": {"ops:classification-search": {"@total-result-count": "0"}}}`,
+	}
+	for _, response := range empty {
+		stats, err := ParseClassificationStatistics(response)
+		if err != nil {
+			t.Errorf("ParseClassificationStatistics(%s) failed: %v", response, err)
+		}
+		if len(stats) != 0 {
+			t.Errorf("ParseClassificationStatistics(%s): got %d entries, want none", response, len(stats))
+		}
+	}
+
+	var validationErr *DataValidationError
+	invalid := `<world-patent-data><classification-search><search-result><classification-statistics classification-symbol="H04W4/00" percentage="high"/></search-result></classification-search></world-patent-data>`
+	if _, err := ParseClassificationStatistics(invalid); !errors.As(err, &validationErr) {
+		t.Errorf("Expected DataValidationError for invalid percentage, got %v", err)
 	}
 }
 
@@ -120,3 +1582,156 @@ func TestParseEquivalents(t *testing.T) {
 		t.Logf("Equivalent %d: %s%s", i+1, equiv.Country, equiv.DocNumber)
 	}
 }
+
+func TestPatentNumbers(t *testing.T) {
+	search, err := ParseSearch(string(loadTestData("search.xml")))
+	if err != nil {
+		t.Fatalf("ParseSearch failed: %v", err)
+	}
+	family, err := ParseFamily(string(loadTestData("family_stages.xml")))
+	if err != nil {
+		t.Fatalf("ParseFamily failed: %v", err)
+	}
+	equivalents, err := ParseEquivalents(string(loadTestData("equivalents.xml")))
+	if err != nil {
+		t.Fatalf("ParseEquivalents failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data PatentNumbers
+		want []string
+	}{
+		{name: "Search", data: search, want: []string{"EP.2400812.A1", "EP.2400813.A1"}},
+		{name: "Family", data: family, want: []string{"EP.2400812.A1", "US.2011318412.A1"}},
+		{name: "Equivalents without kind", data: equivalents, want: []string{"EP2400812", "US2012057518", "CA2744162"}},
+		{
+			name: "Normalized and deduplicated",
+			data: &FamilyData{Members: []FamilyMember{
+				{Country: "ep", DocNumber: " 2400812 ", Kind: "a1"},
+				{Country: "EP", DocNumber: "2400812", Kind: "A1"},
+				{Country: "EP", DocNumber: "2400812", Kind: "B1"},
+				{DocNumber: "123"},
+			}},
+			want: []string{"EP.2400812.A1", "EP.2400812.B1"},
+		},
+		{name: "Empty", data: &SearchResultData{}, want: nil},
+	}
+
+	// Numbers carry their components, normalized
+	members := (&FamilyData{Members: []FamilyMember{{Country: "ep", DocNumber: " 2400812 ", Kind: "a1"}}}).Numbers()
+	if want := []PatentNumber{{Country: "EP", Number: "2400812", Kind: "A1"}}; !reflect.DeepEqual(members, want) {
+		t.Errorf("Numbers: got %+v, want %+v", members, want)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Ref gives DOCDB with a kind code and EPODOC without
+			var got []string
+			for _, pn := range tt.data.Numbers() {
+				format, number := pn.Ref()
+				wantFormat := FormatDocDB
+				if pn.Kind == "" {
+					wantFormat = FormatEPODOC
+				}
+				if format != wantFormat {
+					t.Errorf("Ref(%+v): format %q, want %q", pn, format, wantFormat)
+				}
+				got = append(got, number)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Numbers: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLegalDataEventsSince(t *testing.T) {
+	data := &LegalData{LegalEvents: []LegalEvent{
+		{Code: "AK", DateMigr: "00010101"},
+		{Code: "17P", DateMigr: "2012-04-25"},
+		{Code: "PG25", DateMigr: " 20170630 "},
+		{Code: "PGFP", DateMigr: ""},
+		{Code: "RIC1", DateMigr: "20120424"},
+	}}
+
+	var codes []string
+	for _, event := range data.EventsSince(time.Date(2012, time.April, 25, 0, 0, 0, 0, time.UTC)) {
+		codes = append(codes, event.Code)
+	}
+	if want := []string{"17P", "PG25"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("EventsSince: got %v, want %v", codes, want)
+	}
+	if events := data.EventsSince(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)); len(events) != 0 {
+		t.Errorf("EventsSince(2020): got %+v, want none", events)
+	}
+}
+
+func TestParsersRejectOtherResponses(t *testing.T) {
+	legal := string(loadTestData("legal.xml"))
+	biblio := string(loadTestData("biblio.xml"))
+
+	tests := []struct {
+		name    string
+		parse   func(string) error
+		xmlData string
+		field   string
+	}{
+		{"ParseBiblio", func(x string) error { _, err := ParseBiblio(x); return err }, legal, "exchange-document"},
+		{"ParseAbstract", func(x string) error { _, err := ParseAbstract(x); return err }, legal, "exchange-document"},
+		{"ParseClaims", func(x string) error { _, err := ParseClaims(x); return err }, biblio, "fulltext-document"},
+		{"ParseDescription", func(x string) error { _, err := ParseDescription(x); return err }, biblio, "fulltext-document"},
+		{"ParseFulltext", func(x string) error { _, err := ParseFulltext(x); return err }, legal, "fulltext-document"},
+		{"ParseClassificationTitles", func(x string) error { _, err := ParseClassificationTitles(x); return err }, biblio, "class-scheme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *DataValidationError
+			if err := tt.parse(tt.xmlData); !errors.As(err, &validationErr) {
+				t.Fatalf("Expected DataValidationError, got %v", err)
+			}
+			if validationErr.Parser != tt.name || validationErr.MissingField != tt.field {
+				t.Errorf("Unexpected error: %+v", validationErr)
+			}
+		})
+	}
+
+	// Malformed XML is still an XMLParseError
+	var parseErr *XMLParseError
+	if _, err := ParseBiblio("<world-patent-data>"); !errors.As(err, &parseErr) {
+		t.Errorf("Expected XMLParseError, got %v", err)
+	}
+}
+
+func TestFamilyDataRepresentative(t *testing.T) {
+	xmlData, err := os.ReadFile("testdata/family_priorities.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	data, err := ParseFamily(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseFamily failed: %v", err)
+	}
+
+	// US, EP and CN members claim the 2010 US provisional; the EP A1 and the CN A were
+	// published first, on the same day, and the EP A1 is listed first
+	member, ok := data.Representative()
+	if !ok || member.Country+member.DocNumber+member.Kind != "EP2400812A1" {
+		t.Errorf("Representative: got %s%s%s, want EP2400812A1", member.Country, member.DocNumber, member.Kind)
+	}
+
+	// An original filing without priority claims is dated by its application
+	direct := &FamilyData{Members: []FamilyMember{
+		{Country: "KR", DocNumber: "1", Date: "20120102", PriorityClaims: []PriorityClaim{{Date: "20110520"}}},
+		{Country: "JP", DocNumber: "2", Date: "20121129", ApplicationRef: ApplicationReference{Date: "20110519"}},
+	}}
+	if member, ok := direct.Representative(); !ok || member.Country != "JP" {
+		t.Errorf("Representative: got %q, want JP", member.Country)
+	}
+
+	if _, ok := (&FamilyData{}).Representative(); ok {
+		t.Error("Expected no representative for an empty family")
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/patent-dev/epo-ops/internal/dateutil"
 )
 
 // QuotaInfo contains quota information from EPO OPS API responses.
@@ -82,6 +85,36 @@ func ParseQuotaHeaders(headers http.Header) *QuotaInfo {
 	return info
 }
 
+// ServiceStatus returns the throttling state of one service bucket from the
+// X-Throttling-Control header.
+//
+// EPO reports a traffic-light color and the allowed requests per minute for each
+// service, e.g. "busy (images=green:100, inpadoc=yellow:45, other=green:1000,
+// retrieval=green:200, search=green:30)". For service "images" this returns
+// ("green", 100, true). Returns false if the header has no entry for the service
+// or q is nil.
+func (q *QuotaInfo) ServiceStatus(service string) (status string, requestsPerMinute int, ok bool) {
+	if q == nil {
+		return "", 0, false
+	}
+	_, services, found := strings.Cut(q.ThrottlingControl, "(")
+	if !found {
+		return "", 0, false
+	}
+	services = strings.TrimSuffix(strings.TrimSpace(services), ")")
+
+	for _, entry := range strings.Split(services, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || strings.TrimSpace(name) != service {
+			continue
+		}
+		color, limit, _ := strings.Cut(strings.TrimSpace(value), ":")
+		requestsPerMinute, _ = strconv.Atoi(strings.TrimSpace(limit))
+		return strings.TrimSpace(color), requestsPerMinute, true
+	}
+	return "", 0, false
+}
+
 // parseQuotaMetric parses a quota metric header value in format "used=123,quota=456"
 func parseQuotaMetric(header string) QuotaMetric {
 	metric := QuotaMetric{}
@@ -148,17 +181,28 @@ func calculateStatus(individual, registered *QuotaMetric) string {
 	}
 }
 
-// quotaTracker holds the last quota information from API responses.
+// quotaTracker holds the last quota information from API responses,
+// overall and per endpoint category (see getEndpointFromPath).
+//
+// Stored QuotaInfo values are never modified after Update, so the pointers
+// returned by Get and GetFor are safe to read concurrently.
 type quotaTracker struct {
-	mu   sync.RWMutex
-	last *QuotaInfo
+	mu         sync.RWMutex
+	last       *QuotaInfo
+	byEndpoint map[string]*QuotaInfo
 }
 
-// Update sets the last quota information.
-func (qt *quotaTracker) Update(info *QuotaInfo) {
+// Update sets the last quota information, and the last one for endpoint if it is non-empty.
+func (qt *quotaTracker) Update(endpoint string, info *QuotaInfo) {
 	qt.mu.Lock()
 	defer qt.mu.Unlock()
 	qt.last = info
+	if endpoint != "" {
+		if qt.byEndpoint == nil {
+			qt.byEndpoint = make(map[string]*QuotaInfo)
+		}
+		qt.byEndpoint[endpoint] = info
+	}
 }
 
 // Get returns the last quota information (may be nil).
@@ -168,6 +212,34 @@ func (qt *quotaTracker) Get() *QuotaInfo {
 	return qt.last
 }
 
+// GetFor returns the last quota information for an endpoint category (may be nil).
+func (qt *quotaTracker) GetFor(endpoint string) *QuotaInfo {
+	qt.mu.RLock()
+	defer qt.mu.RUnlock()
+	return qt.byEndpoint[endpoint]
+}
+
+// usageStatsDateLayout is the day-first date format of the Usage Statistics API (dd/mm/yyyy)
+const usageStatsDateLayout = "02/01/2006"
+
+// FormatTimeRange formats a date range for the Usage Statistics API ("dd/mm/yyyy~dd/mm/yyyy").
+//
+// Only the calendar dates of from and to are used, in their own locations; the time of
+// day is ignored. Returns a ConfigError if to is before from.
+func FormatTimeRange(from, to time.Time) (string, error) {
+	start, end := from.Format(usageStatsDateLayout), to.Format(usageStatsDateLayout)
+	if calendarDay(to).Before(calendarDay(from)) {
+		return "", &ConfigError{Message: fmt.Sprintf("end date %s is before start date %s", end, start)}
+	}
+	return start + "~" + end, nil
+}
+
+// calendarDay returns midnight UTC of t's calendar date in t's location.
+func calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
 // ValidateTimeRange validates a time range string for the Usage Statistics API.
 //
 // Valid formats:
@@ -238,6 +310,11 @@ func validateDate(date string) error {
 		return &ConfigError{Message: "year must be a 4-digit number"}
 	}
 
+	// Validate calendar date (e.g., rejects 31/04 and 29/02 in non-leap years)
+	if err := dateutil.CheckDate(y, m, d); err != nil {
+		return &ConfigError{Message: err.Error()}
+	}
+
 	return nil
 }
 
@@ -1,8 +1,10 @@
 package epo_ops
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateTimeRange(t *testing.T) {
@@ -28,6 +30,11 @@ func TestValidateTimeRange(t *testing.T) {
 			timeRange: "31/12/2022",
 			wantError: false,
 		},
+		{
+			name:      "Valid single date - leap day",
+			timeRange: "29/02/2024",
+			wantError: false,
+		},
 
 		// Valid date ranges
 		{
@@ -94,6 +101,19 @@ func TestValidateTimeRange(t *testing.T) {
 			errorMsg:  "day must be between",
 		},
 
+		{
+			name:      "Invalid day - Feb 29 in non-leap year",
+			timeRange: "29/02/2023",
+			wantError: true,
+			errorMsg:  "day must be between 01 and 28",
+		},
+		{
+			name:      "Invalid day - 31 in 30-day month",
+			timeRange: "31/04/2024",
+			wantError: true,
+			errorMsg:  "day must be between 01 and 30",
+		},
+
 		// Invalid - month errors
 		{
 			name:      "Invalid month - zero",
@@ -167,6 +187,63 @@ func TestValidateTimeRange(t *testing.T) {
 	}
 }
 
+func TestFormatTimeRange(t *testing.T) {
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want string
+	}{
+		{
+			name: "Single-digit days and months",
+			from: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, time.February, 9, 0, 0, 0, 0, time.UTC),
+			want: "05/01/2024~09/02/2024",
+		},
+		{
+			name: "Day-first order",
+			from: time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, time.December, 3, 0, 0, 0, 0, time.UTC),
+			want: "12/03/2024~03/12/2024",
+		},
+		{
+			name: "Same day, time of day ignored",
+			from: time.Date(2024, time.July, 1, 18, 30, 0, 0, time.UTC),
+			to:   time.Date(2024, time.July, 1, 6, 0, 0, 0, time.UTC),
+			want: "01/07/2024~01/07/2024",
+		},
+		{
+			name: "Year boundary",
+			from: time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+			want: "25/12/2023~05/01/2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatTimeRange(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("FormatTimeRange() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatTimeRange() = %q, want %q", got, tt.want)
+			}
+			if err := ValidateTimeRange(got); err != nil {
+				t.Errorf("ValidateTimeRange(%q) unexpected error: %v", got, err)
+			}
+		})
+	}
+
+	_, err := FormatTimeRange(
+		time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 9, 23, 59, 0, 0, time.UTC))
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Errorf("Expected ConfigError for reversed range, got %v", err)
+	}
+}
+
 func TestParseUsageStats(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -312,3 +389,36 @@ func TestParseUsageStats_DetailedValidation(t *testing.T) {
 		t.Errorf("Service = %q, want %q", entry.Service, "biblio")
 	}
 }
+
+func TestServiceStatus(t *testing.T) {
+	quota := &QuotaInfo{ThrottlingControl: "busy (images=green:100, inpadoc=yellow:45, other=black:0, retrieval=red:20, search=green:30)"}
+
+	tests := []struct {
+		service    string
+		wantStatus string
+		wantLimit  int
+		wantOK     bool
+	}{
+		{"images", "green", 100, true},
+		{"inpadoc", "yellow", 45, true},
+		{"other", "black", 0, true},
+		{"search", "green", 30, true},
+		{"register", "", 0, false},
+	}
+	for _, tt := range tests {
+		status, limit, ok := quota.ServiceStatus(tt.service)
+		if status != tt.wantStatus || limit != tt.wantLimit || ok != tt.wantOK {
+			t.Errorf("ServiceStatus(%q) = (%q, %d, %v), want (%q, %d, %v)",
+				tt.service, status, limit, ok, tt.wantStatus, tt.wantLimit, tt.wantOK)
+		}
+	}
+
+	// Without per-service details there is nothing to report
+	if _, _, ok := (&QuotaInfo{ThrottlingControl: "green"}).ServiceStatus("images"); ok {
+		t.Error("Expected no service status for plain \"green\"")
+	}
+	var none *QuotaInfo
+	if _, _, ok := none.ServiceStatus("images"); ok {
+		t.Error("Expected no service status for nil QuotaInfo")
+	}
+}
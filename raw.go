@@ -0,0 +1,76 @@
+package epo_ops
+
+import (
+	"context"
+	"net/http"
+)
+
+// RawResponse is a complete API response: the body together with the status code and
+// headers (ETag, Content-Type, quota headers) that the string-returning methods discard.
+type RawResponse struct {
+	Body       []byte
+	Headers    http.Header
+	StatusCode int
+
+	// Quota is parsed from the quota headers of this response
+	Quota *QuotaInfo
+}
+
+// ETag returns the ETag header of the response (empty if EPO sent none).
+func (r *RawResponse) ETag() string {
+	return r.Headers.Get("ETag")
+}
+
+// ContentType returns the Content-Type header of the response.
+func (r *RawResponse) ContentType() string {
+	return r.Headers.Get("Content-Type")
+}
+
+// DoRaw executes a request with the client's retry logic, token refresh and quota tracking
+// and returns the complete response.
+//
+// fn receives the context to issue the request with and an HTTP client that adds the access
+// token, the Accept header and Config.Interceptors. It can reach endpoints this package has
+// no method for, or send conditional requests (If-None-Match); a 304 Not Modified response
+// is returned with an empty body. Config.Cache is not used. Other non-200 responses are
+// returned as typed errors, as for all other methods.
+//
+// Example:
+//
+//	raw, err := client.DoRaw(ctx, func(ctx context.Context, httpClient *http.Client) (*http.Response, error) {
+//	    req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+//	        "https://ops.epo.org/3.2/rest-services/published-data/publication/docdb/EP.1000000.B1/biblio", nil)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return httpClient.Do(req)
+//	})
+//	fmt.Println(raw.StatusCode, raw.ETag(), raw.Quota.Status)
+func (c *Client) DoRaw(ctx context.Context, fn func(ctx context.Context, httpClient *http.Client) (*http.Response, error)) (*RawResponse, error) {
+	ctx = withRequestID(ctx)
+	return c.executeRawRequest(ctx, func() (*http.Response, error) {
+		return fn(ctx, c.httpClient)
+	})
+}
+
+// executeRawRequest executes an HTTP request with retry logic and 401 handling and
+// reads the successful response into a RawResponse.
+func (c *Client) executeRawRequest(ctx context.Context, fn func() (*http.Response, error)) (*RawResponse, error) {
+	resp, err := c.executeStreamRequest(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawResponse{
+		Body:       body,
+		Headers:    resp.Header,
+		StatusCode: resp.StatusCode,
+		Quota:      ParseQuotaHeaders(resp.Header),
+	}, nil
+}
@@ -0,0 +1,114 @@
+package epo_ops
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetBiblioWithMeta(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	requests := 0
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests++
+		if r.Header.Get("If-None-Match") == `"biblio-v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"biblio-v1"`)
+		w.Header().Set("X-IndividualQuota", "used=2500,quota=4000000000")
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(loadTestData("biblio.xml"))
+	})
+	defer opsServer.Close()
+
+	config := &Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		Cache:          NewLRUCache(10),
+	}
+	config.AuthURL = authServer.URL + "/auth/accesstoken"
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	raw, err := client.GetBiblioWithMeta(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1")
+	if err != nil {
+		t.Fatalf("GetBiblioWithMeta failed: %v", err)
+	}
+
+	if raw.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode: got %d, want 200", raw.StatusCode)
+	}
+	if raw.ETag() != `"biblio-v1"` {
+		t.Errorf("ETag: got %q", raw.ETag())
+	}
+	if raw.ContentType() != "application/xml" {
+		t.Errorf("ContentType: got %q", raw.ContentType())
+	}
+	if raw.Quota == nil || raw.Quota.Status != "green" || raw.Quota.Individual.Used != 2500 {
+		t.Errorf("Quota: got %+v", raw.Quota)
+	}
+	if _, err := ParseBiblio(string(raw.Body)); err != nil {
+		t.Errorf("ParseBiblio on body failed: %v", err)
+	}
+
+	// WithMeta methods always hit EPO and leave the cache alone
+	if _, err := client.GetBiblioWithMeta(ctx, RefTypePublication, FormatDocDB, "EP.1000000.B1"); err != nil {
+		t.Fatalf("GetBiblioWithMeta failed: %v", err)
+	}
+	if requests != 2 || config.Cache.(*LRUCache).Len() != 0 {
+		t.Errorf("Expected 2 uncached requests, got %d requests and %d cache entries", requests, config.Cache.(*LRUCache).Len())
+	}
+
+	// Validation happens before the request
+	if _, err := client.GetClaimsWithMeta(ctx, "invalid", FormatDocDB, "EP.1000000.B1"); err == nil {
+		t.Error("Expected validation error for invalid refType")
+	}
+
+	t.Run("DoRaw conditional request", func(t *testing.T) {
+		raw, err := client.DoRaw(ctx, func(ctx context.Context, httpClient *http.Client) (*http.Response, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+				opsServer.URL+"/published-data/publication/docdb/EP.1000000.B1/biblio", nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("If-None-Match", `"biblio-v1"`)
+			return httpClient.Do(req)
+		})
+		if err != nil {
+			t.Fatalf("DoRaw failed: %v", err)
+		}
+		if raw.StatusCode != http.StatusNotModified || len(raw.Body) != 0 {
+			t.Errorf("Expected empty 304 response, got %d with %d bytes", raw.StatusCode, len(raw.Body))
+		}
+		if q := client.LastQuotaFor(EndpointBiblio); q == nil || q.Status != "green" {
+			t.Errorf("DoRaw responses must update quota tracking, got %+v", q)
+		}
+	})
+
+	t.Run("DoRaw error response", func(t *testing.T) {
+		_, err := client.DoRaw(ctx, func(ctx context.Context, httpClient *http.Client) (*http.Response, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, opsServer.URL+"/missing", nil)
+			if err != nil {
+				return nil, err
+			}
+			return httpClient.Do(req)
+		})
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) {
+			t.Errorf("Expected NotFoundError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package epo_ops
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RecordingTransport is an http.RoundTripper that writes every request/response pair
+// it passes on to a fixture file in Dir, for replaying with ReplayTransport.
+//
+// Set Config.RecordDir to record the API requests of a client; the transport can also
+// wrap any other http.Client. Fixtures are JSON files named after the method and path
+// of the request (see ReplayTransport). A request that is sent again, e.g. retried
+// after a 503, overwrites the fixture with its latest response. Request headers are
+// not stored, so fixtures never contain the access token.
+type RecordingTransport struct {
+	// Dir is the directory fixtures are written to. It is created if missing.
+	Dir string
+
+	// Base sends the requests. Optional: nil uses http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// ReplayTransport is an http.RoundTripper that answers requests with the responses
+// recorded by RecordingTransport, without network access.
+//
+// Set Config.ReplayDir to run a client against recorded fixtures, e.g. in offline
+// integration tests. Responses are looked up by method, path, query and request body;
+// a request without a recording fails with an error wrapping fs.ErrNotExist that names
+// the expected fixture file. Token requests without a recording are answered with a
+// dummy access token, so a replaying client needs no EPO credentials.
+type ReplayTransport struct {
+	// Dir is the directory the fixtures were recorded to.
+	Dir string
+}
+
+// recordedExchange is the fixture file format of RecordingTransport.
+// Bodies that are not valid UTF-8 (e.g. images) are stored base64-encoded.
+type recordedExchange struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header"`
+	Body        string      `json:"body,omitempty"`
+	BodyBase64  []byte      `json:"body_base64,omitempty"`
+}
+
+// fixtureNameChars matches the characters replaced in fixture file names.
+var fixtureNameChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// fixturePath returns the fixture file of a request below dir, e.g.
+// "GET_published-data_publication_docdb_EP.1000000.B1_biblio_1a2b3c4d.json". The
+// hash of method, path, query and body keeps requests apart that differ only in
+// their query or body.
+func fixturePath(dir string, req *http.Request, body []byte) string {
+	query := req.URL.Query().Encode()
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.Path + "?" + query + "\n" + string(body)))
+
+	name := fixtureNameChars.ReplaceAllString(req.Method+"_"+strings.Trim(req.URL.Path, "/"), "_")
+	if len(name) > 150 {
+		name = name[:150]
+	}
+	return filepath.Join(dir, name+"_"+hex.EncodeToString(sum[:4])+".json")
+}
+
+// readRequestBody reads the request body and replaces it with an unread copy.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// RoundTrip sends the request and records the response.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	exchange := recordedExchange{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: string(requestBody),
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header,
+	}
+	if utf8.Valid(body) {
+		exchange.Body = string(body)
+	} else {
+		exchange.BodyBase64 = body
+	}
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode recording: %w", err)
+	}
+
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	if err := os.WriteFile(fixturePath(t.Dir, req, requestBody), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write recording: %w", err)
+	}
+	return resp, nil
+}
+
+// RoundTrip answers the request with its recorded response.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fixturePath(t.Dir, req, requestBody)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && strings.HasSuffix(req.URL.Path, "/accesstoken") {
+		return replayResponse(req, http.StatusOK, http.Header{"Content-Type": {"application/json"}},
+			[]byte(`{"access_token":"replay","expires_in":"3600","token_type":"Bearer"}`)), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no recorded response for %s %s: %w", req.Method, req.URL, err)
+	}
+
+	var exchange recordedExchange
+	if err := json.Unmarshal(data, &exchange); err != nil {
+		return nil, fmt.Errorf("failed to decode recording %s: %w", path, err)
+	}
+	body := exchange.BodyBase64
+	if body == nil {
+		body = []byte(exchange.Body)
+	}
+	return replayResponse(req, exchange.StatusCode, exchange.Header, body), nil
+}
+
+// replayResponse builds the response to req from recorded parts.
+func replayResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
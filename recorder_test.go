@@ -0,0 +1,134 @@
+package epo_ops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	image := []byte{0x49, 0x49, 0x2a, 0x00, 0xff, 0xfe, 0x00}
+	opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/biblio"):
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write(loadTestData("biblio.xml"))
+		case strings.Contains(r.URL.Path, "/search"):
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write(loadTestData("search.xml"))
+		case strings.Contains(r.URL.Path, "/images/"):
+			w.Header().Set("Content-Type", "image/tiff")
+			_, _ = w.Write(image)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer opsServer.Close()
+
+	dir := t.TempDir()
+	type results struct {
+		Biblio *BiblioData
+		Search *SearchResultData
+		Image  []byte
+		Bulk   string
+		Quota  *QuotaInfo
+	}
+	run := func(client *Client) (results, error) {
+		ctx := context.Background()
+		var r results
+		var err error
+		if r.Biblio, err = client.GetBiblio(ctx, "publication", "docdb", "EP.1000000.B1"); err != nil {
+			return r, err
+		}
+		if r.Search, err = client.Search(ctx, "ti=battery", "1-5"); err != nil {
+			return r, err
+		}
+		if r.Image, err = client.GetImage(ctx, "EP", "1000000", "B1", ImageTypeFullImage, 1); err != nil {
+			return r, err
+		}
+		if r.Bulk, err = client.GetBiblioMultiple(ctx, "publication", "docdb", []string{"EP.1000000.B1", "EP.1000001.A1"}); err != nil {
+			return r, err
+		}
+		r.Quota = client.GetLastQuota()
+		return r, nil
+	}
+
+	recorder, err := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		RecordDir:      dir,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	recorded, err := run(recorder)
+	if err != nil {
+		t.Fatalf("Recording failed: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 4 {
+		t.Fatalf("Fixtures: got %v (%v), want 4 files", files, err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read fixture: %v", err)
+		}
+		if bytes.Contains(data, []byte("test_token_12345")) {
+			t.Errorf("Fixture %s contains the access token", filepath.Base(file))
+		}
+	}
+
+	// Replaying needs neither the servers nor credentials of a real account
+	opsServer.Close()
+	authServer.Close()
+	replayer, err := NewClient(&Config{
+		ConsumerKey:    "test",
+		ConsumerSecret: "test",
+		BaseURL:        opsServer.URL,
+		AuthURL:        authServer.URL + "/auth/accesstoken",
+		ReplayDir:      dir,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	replayed, err := run(replayer)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("Replayed results differ:\ngot  %+v\nwant %+v", replayed, recorded)
+	}
+	if !bytes.Equal(replayed.Image, image) {
+		t.Errorf("Binary body: got %v, want %v", replayed.Image, image)
+	}
+
+	// Requests without a recording fail instead of reaching the network
+	_, err = replayer.GetBiblio(context.Background(), "publication", "docdb", "EP.2000000.B1")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist for a request without recording, got %v", err)
+	}
+	_, err = replayer.GetBiblioMultiple(context.Background(), "publication", "docdb", []string{"EP.1000000.B1"})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist for a POST with another body, got %v", err)
+	}
+
+	var configErr *ConfigError
+	_, err = NewClient(&Config{ConsumerKey: "test", ConsumerSecret: "test", RecordDir: dir, ReplayDir: dir})
+	if !errors.As(err, &configErr) {
+		t.Errorf("Expected ConfigError for RecordDir with ReplayDir, got %v", err)
+	}
+}
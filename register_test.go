@@ -353,7 +353,7 @@ func TestSearchRegister(t *testing.T) {
 		},
 		{
 			name:      "Valid applicant search",
-			query:     "applicant=siemens",
+			query:     "pa=siemens",
 			rangeSpec: "1-5",
 			wantError: false,
 		},
@@ -434,7 +434,7 @@ func TestSearchRegisterWithConstituent(t *testing.T) {
 		{
 			name:        "Valid procedural-steps search",
 			constituent: "procedural-steps",
-			query:       "applicant=siemens",
+			query:       "pa=siemens",
 			rangeSpec:   "1-5",
 			wantError:   false,
 		},
@@ -4,25 +4,77 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math/rand/v2"
 	"net"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
 
+// ExponentialBackoff returns a backoff strategy for Config.BackoffStrategy that waits
+// base * 2^(attempt-1) plus random jitter of up to 50%, capped at maxDelay.
+//
+// With base=1s the delays are roughly 1-1.5s, 2-3s, 4-6s, 8-12s, ...
+// A maxDelay of 0 disables the cap.
+func ExponentialBackoff(base, maxDelay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		// Cap shift amount to prevent overflow (max 2^10 = 1024x multiplier)
+		shift := attempt - 1
+		if shift < 0 {
+			shift = 0
+		} else if shift > 10 {
+			shift = 10
+		}
+		delay := base * time.Duration(1<<shift)
+
+		// Add up to 50% random jitter to avoid synchronized retries
+		if half := int64(delay / 2); half > 0 {
+			delay += time.Duration(rand.Int64N(half + 1))
+		}
+
+		if maxDelay > 0 && delay > maxDelay {
+			delay = maxDelay
+		}
+		return delay
+	}
+}
+
+// backoff returns the delay before the given retry attempt (1-based).
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.config.BackoffStrategy != nil {
+		return c.config.BackoffStrategy(attempt)
+	}
+	return ExponentialBackoff(c.config.RetryDelay, c.config.MaxRetryDelay)(attempt)
+}
+
 // retryableRequest executes a function with retry logic and exponential backoff.
+//
+// Delays between attempts come from Config.BackoffStrategy (default: ExponentialBackoff
+// with jitter). If the last attempt still returns a retryable status code, the response
+// is returned unchanged so the caller can map its body to a typed error.
 func (c *Client) retryableRequest(ctx context.Context, fn func() (*http.Response, error)) (*http.Response, error) {
+	return c.retryWithBudget(ctx, nil, fn)
+}
+
+// retryWithBudget implements retryableRequest, retrying only while budget allows
+// another attempt. A nil budget is unlimited.
+func (c *Client) retryWithBudget(ctx context.Context, budget *retryBudget, fn func() (*http.Response, error)) (*http.Response, error) {
 	var lastErr error
 	var resp *http.Response
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.stats.retries.Add(1)
+		}
+
 		// Execute request
 		resp, lastErr = fn()
 
 		// If no error and status is OK or non-retryable, return immediately
 		if lastErr == nil {
-			if !isRetryableStatusCode(resp.StatusCode) {
+			if !isRetryableStatusCode(resp.StatusCode) || attempt == c.config.MaxRetries || !budget.allow() {
 				return resp, nil
 			}
 			// Close the body if we're going to retry
@@ -42,15 +94,10 @@ func (c *Client) retryableRequest(ctx context.Context, fn func() (*http.Response
 
 		// Don't sleep after the last attempt
 		if attempt < c.config.MaxRetries {
-			// Exponential backoff: 1s, 2s, 4s, 8s, etc.
-			// Cap shift amount to prevent overflow (max 2^10 = 1024x multiplier)
-			shift := attempt
-			if shift < 0 {
-				shift = 0
-			} else if shift > 10 {
-				shift = 10
+			if !budget.allow() {
+				return resp, lastErr
 			}
-			backoff := c.config.RetryDelay * time.Duration(1<<shift)
+			backoff := c.backoff(attempt + 1)
 
 			// Sleep with context cancellation support
 			select {
@@ -65,6 +112,61 @@ func (c *Client) retryableRequest(ctx context.Context, fn func() (*http.Response
 	return resp, lastErr
 }
 
+// defaultMaxRetryBudget is the default Config.MaxRetryBudget.
+const defaultMaxRetryBudget = 5
+
+// retryBudget counts the HTTP attempts of one public method call against
+// Config.MaxRetryBudget. The requests of the call share the attempt count; each
+// request has its own retryBudget to record whether the budget stopped its retries.
+// Its methods accept a nil receiver, which is an unlimited budget.
+type retryBudget struct {
+	limit    int32
+	attempts *atomic.Int32 // attempts of the whole call
+	hit      atomic.Bool   // a retry of this request was skipped because the budget was used up
+}
+
+// retryAttemptsKey is the context key under which withRequestID stores the attempt
+// count shared by the requests of a call.
+type retryAttemptsKey struct{}
+
+// newRetryBudget returns the budget for one API request of the call ctx belongs to,
+// nil if Config.MaxRetryBudget is negative. Outside a call (no attempt count in ctx)
+// the request gets a budget of its own.
+func (c *Client) newRetryBudget(ctx context.Context) *retryBudget {
+	if c.config.MaxRetryBudget < 0 {
+		return nil
+	}
+	attempts, _ := ctx.Value(retryAttemptsKey{}).(*atomic.Int32)
+	if attempts == nil {
+		attempts = new(atomic.Int32)
+	}
+	return &retryBudget{limit: int32(c.config.MaxRetryBudget), attempts: attempts}
+}
+
+// spend records an attempt.
+func (b *retryBudget) spend() {
+	if b != nil {
+		b.attempts.Add(1)
+	}
+}
+
+// allow reports whether another attempt is within the budget, recording when it is not.
+func (b *retryBudget) allow() bool {
+	if b == nil || b.attempts.Load() < b.limit {
+		return true
+	}
+	b.hit.Store(true)
+	return false
+}
+
+// wrap returns err wrapped in a RetryBudgetExceededError if the budget stopped a retry.
+func (b *retryBudget) wrap(err error) error {
+	if b == nil || !b.hit.Load() {
+		return err
+	}
+	return &RetryBudgetExceededError{Attempts: int(b.attempts.Load()), Err: err}
+}
+
 // isRetryableError determines if an error should trigger a retry.
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -139,6 +241,7 @@ func isRetryableError(err error) bool {
 func isRetryableStatusCode(statusCode int) bool {
 	switch statusCode {
 	case http.StatusRequestTimeout, // 408
+		http.StatusTooManyRequests,     // 429 (OPS throttling)
 		http.StatusInternalServerError, // 500
 		http.StatusBadGateway,          // 502
 		http.StatusServiceUnavailable,  // 503
@@ -3,9 +3,11 @@ package epo_ops
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -106,9 +108,9 @@ func TestIsRetryableStatusCode(t *testing.T) {
 			expected:   true,
 		},
 		{
-			name:       "429 Too Many Requests not retryable",
+			name:       "429 Too Many Requests retryable",
 			statusCode: http.StatusTooManyRequests,
-			expected:   false,
+			expected:   true,
 		},
 		{
 			name:       "500 Internal Server Error retryable",
@@ -287,3 +289,240 @@ func TestRetryableRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestExponentialBackoff(t *testing.T) {
+	base := 100 * time.Millisecond
+	strategy := ExponentialBackoff(base, 0)
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		minDelay := base * time.Duration(1<<(attempt-1))
+		maxDelay := minDelay + minDelay/2
+
+		for i := 0; i < 20; i++ {
+			delay := strategy(attempt)
+			if delay < minDelay || delay > maxDelay {
+				t.Fatalf("attempt %d: delay %v outside [%v, %v]", attempt, delay, minDelay, maxDelay)
+			}
+		}
+	}
+
+	t.Run("Capped at max delay", func(t *testing.T) {
+		capped := ExponentialBackoff(time.Second, 5*time.Second)
+		if delay := capped(10); delay != 5*time.Second {
+			t.Errorf("Expected delay capped at 5s, got: %v", delay)
+		}
+	})
+}
+
+func TestRetryableRequest_Backoff(t *testing.T) {
+	t.Run("Increasing delays between attempts", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ConsumerKey = "test"
+		config.ConsumerSecret = "test"
+		config.MaxRetries = 3
+		config.RetryDelay = 10 * time.Millisecond
+
+		client, err := NewClient(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		var attempts []time.Time
+		resp, err := client.retryableRequest(context.Background(), func() (*http.Response, error) {
+			attempts = append(attempts, time.Now())
+			if len(attempts) <= 3 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       io.NopCloser(nil),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(nil),
+			}, nil
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got: %d", resp.StatusCode)
+		}
+		if len(attempts) != 4 {
+			t.Fatalf("Expected 4 attempts, got: %d", len(attempts))
+		}
+
+		// Jitter ranges [d, 1.5d] of consecutive attempts never overlap, so delays strictly increase
+		var previous time.Duration
+		for i := 1; i < len(attempts); i++ {
+			delay := attempts[i].Sub(attempts[i-1])
+			minDelay := config.RetryDelay * time.Duration(1<<(i-1))
+			if delay < minDelay {
+				t.Errorf("Delay %d: got %v, want at least %v", i, delay, minDelay)
+			}
+			if delay <= previous {
+				t.Errorf("Delay %d (%v) not greater than previous (%v)", i, delay, previous)
+			}
+			previous = delay
+		}
+	})
+
+	t.Run("Custom backoff strategy", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ConsumerKey = "test"
+		config.ConsumerSecret = "test"
+		config.MaxRetries = 2
+
+		var requested []int
+		config.BackoffStrategy = func(attempt int) time.Duration {
+			requested = append(requested, attempt)
+			return time.Nanosecond
+		}
+
+		client, err := NewClient(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		_, _ = client.retryableRequest(context.Background(), func() (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       io.NopCloser(nil),
+			}, nil
+		})
+
+		if len(requested) != 2 || requested[0] != 1 || requested[1] != 2 {
+			t.Errorf("Expected backoff for attempts [1 2], got: %v", requested)
+		}
+	})
+
+	t.Run("Last retryable response returned for error mapping", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ConsumerKey = "test"
+		config.ConsumerSecret = "test"
+		config.MaxRetries = 1
+		config.RetryDelay = time.Nanosecond
+
+		client, err := NewClient(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		resp, err := client.retryableRequest(context.Background(), func() (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(nil),
+			}, nil
+		})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected final 503 response to be returned")
+		}
+	})
+}
+
+func TestMaxRetryBudget(t *testing.T) {
+	authServer := newMockAuthServer(t)
+	defer authServer.Close()
+
+	newClient := func(t *testing.T, status int, budget int) (*Client, *atomic.Int32) {
+		var attempts atomic.Int32
+		opsServer := newMockOPSServer(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(status)
+		})
+		t.Cleanup(opsServer.Close)
+
+		client, err := NewClient(&Config{
+			ConsumerKey:    "test",
+			ConsumerSecret: "test",
+			BaseURL:        opsServer.URL,
+			AuthURL:        authServer.URL + "/auth/accesstoken",
+			MaxRetries:     10,
+			MaxRetryBudget: budget,
+			RetryDelay:     time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		return client, &attempts
+	}
+
+	t.Run("Always unavailable", func(t *testing.T) {
+		client, attempts := newClient(t, http.StatusServiceUnavailable, 3)
+
+		_, err := client.GetBiblioRaw(context.Background(), "publication", "docdb", "EP.1000000.B1")
+		if got := attempts.Load(); got != 3 {
+			t.Errorf("Expected 3 attempts, got %d", got)
+		}
+		var budgetErr *RetryBudgetExceededError
+		if !errors.As(err, &budgetErr) || budgetErr.Attempts != 3 {
+			t.Fatalf("Expected RetryBudgetExceededError after 3 attempts, got %v", err)
+		}
+		var serviceErr *ServiceUnavailableError
+		if !errors.As(err, &serviceErr) {
+			t.Errorf("Expected wrapped ServiceUnavailableError, got %v", err)
+		}
+	})
+
+	t.Run("Token refresh counts against the budget", func(t *testing.T) {
+		client, attempts := newClient(t, http.StatusUnauthorized, 1)
+
+		_, err := client.GetBiblioRaw(context.Background(), "publication", "docdb", "EP.1000000.B1")
+		if got := attempts.Load(); got != 1 {
+			t.Errorf("Expected 1 attempt, got %d", got)
+		}
+		var budgetErr *RetryBudgetExceededError
+		if !errors.As(err, &budgetErr) {
+			t.Errorf("Expected RetryBudgetExceededError, got %v", err)
+		}
+	})
+
+	t.Run("Requests of one call share the budget", func(t *testing.T) {
+		client, attempts := newClient(t, http.StatusServiceUnavailable, 4)
+
+		// 250 numbers are sent in 3 batches: the first uses up the budget with its
+		// retries, the others are still sent once but not retried
+		numbers := make([]string, 250)
+		for i := range numbers {
+			numbers[i] = fmt.Sprintf("EP.%d.A1", 1000000+i)
+		}
+		results, err := client.GetBibliosBulkDetailed(context.Background(), "publication", "docdb", numbers, nil)
+		if err != nil {
+			t.Fatalf("GetBibliosBulkDetailed failed: %v", err)
+		}
+		if got := attempts.Load(); got != 6 {
+			t.Errorf("Expected 6 attempts, got %d", got)
+		}
+		for _, result := range results {
+			var budgetErr *RetryBudgetExceededError
+			if !errors.As(result.Err, &budgetErr) {
+				t.Errorf("Batch %d: expected RetryBudgetExceededError, got %v", result.Batch, result.Err)
+			}
+		}
+
+		// A new call starts with a fresh budget
+		attempts.Store(0)
+		_, _ = client.GetBiblioRaw(context.Background(), "publication", "docdb", "EP.1000000.B1")
+		if got := attempts.Load(); got != 4 {
+			t.Errorf("Expected 4 attempts for the next call, got %d", got)
+		}
+	})
+
+	t.Run("Retries within the budget are not wrapped", func(t *testing.T) {
+		client, attempts := newClient(t, http.StatusServiceUnavailable, -1)
+		client.config.MaxRetries = 2
+
+		_, err := client.GetBiblioRaw(context.Background(), "publication", "docdb", "EP.1000000.B1")
+		if got := attempts.Load(); got != 3 {
+			t.Errorf("Expected 3 attempts, got %d", got)
+		}
+		var budgetErr *RetryBudgetExceededError
+		if errors.As(err, &budgetErr) {
+			t.Errorf("Expected no RetryBudgetExceededError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,405 @@
+package epo_ops
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// xsdNamespace is the namespace of XML Schema definitions
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+
+// maxSchemaErrors limits the number of problems reported by ValidateAgainstSchema
+const maxSchemaErrors = 5
+
+// ValidateAgainstSchema validates an XML response against a named embedded XSD
+// (see GetEmbeddedXSD for the available names).
+//
+// Go's standard library has no XSD support, so this is a structural check that
+// detects changed response shapes rather than a full schema validation:
+//
+//   - the document must be well-formed (see ValidateXML)
+//   - it must contain an element declared at the top level of the schema, in the
+//     schema's target namespace (e.g., exch:exchange-documents for "exchange-documents";
+//     OPS wraps it in ops:world-patent-data). The outermost such element is validated.
+//   - starting from that element, every element with a known declaration must carry
+//     the attributes declared use="required" and the child elements its sequence
+//     declares with minOccurs other than 0, checked recursively
+//
+// Element order, occurrence limits, choices, simple-type facets (enumerations,
+// patterns) and elements not declared in the schema are not checked.
+//
+// Returns a *ValidationError listing up to five problems, or a *ConfigError for an
+// unknown schema name.
+//
+// Example:
+//
+//	xmlData, err := client.GetBiblioRaw(ctx, "publication", "docdb", "EP.1000000.B1")
+//	if err := ops.ValidateAgainstSchema([]byte(xmlData), "exchange-documents"); err != nil {
+//	    log.Printf("biblio response changed shape: %v", err)
+//	}
+func ValidateAgainstSchema(xmlData []byte, schemaName string) error {
+	schema, err := loadSchema(schemaName)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateXML(xmlData); err != nil {
+		return err
+	}
+	root, err := parseXMLTree(xmlData)
+	if err != nil {
+		return newFormatError(PayloadXML, xmlData, fmt.Sprintf("malformed XML: %v", err))
+	}
+
+	start, decl := schema.findRoot(root)
+	if start == nil {
+		return &ValidationError{
+			Field:   "schema",
+			Format:  schemaName,
+			Value:   root.Name.Local,
+			Message: fmt.Sprintf("no element declared by the schema found in namespace %s", schema.TargetNamespace),
+		}
+	}
+
+	var problems []string
+	schema.check(start, decl, "/"+start.Name.Local, &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	message := strings.Join(problems[:min(len(problems), maxSchemaErrors)], "; ")
+	if len(problems) > maxSchemaErrors {
+		message += fmt.Sprintf(" (and %d more)", len(problems)-maxSchemaErrors)
+	}
+	return &ValidationError{
+		Field:   "schema",
+		Format:  schemaName,
+		Value:   start.Name.Local,
+		Message: message,
+	}
+}
+
+// xmlNode is a minimal element tree used for structural validation.
+type xmlNode struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Children []*xmlNode
+}
+
+// parseXMLTree reads data into an element tree, dropping text and comments.
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{Name: t.Name, Attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no XML element found")
+	}
+	return root, nil
+}
+
+// hasAttr reports whether the element carries an attribute with the given local name.
+func (n *xmlNode) hasAttr(name string) bool {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return true
+		}
+	}
+	return false
+}
+
+// XSD structures: only the parts needed for the structural checks are decoded.
+// encoding/xml resolves the xs:/xsd:/default prefixes of the schemas to xsdNamespace.
+
+type xsdSchema struct {
+	TargetNamespace string           `xml:"targetNamespace,attr"`
+	Namespaces      []xml.Attr       `xml:",any,attr"`
+	Elements        []xsdElement     `xml:"http://www.w3.org/2001/XMLSchema element"`
+	ComplexTypes    []xsdComplexType `xml:"http://www.w3.org/2001/XMLSchema complexType"`
+
+	// Lookup tables built by loadSchema
+	prefixes     map[string]string // prefix -> namespace
+	elements     map[string]*xsdElement
+	complexTypes map[string]*xsdComplexType
+}
+
+type xsdElement struct {
+	Name        string          `xml:"name,attr"`
+	Ref         string          `xml:"ref,attr"`
+	Type        string          `xml:"type,attr"`
+	MinOccurs   string          `xml:"minOccurs,attr"`
+	ComplexType *xsdComplexType `xml:"http://www.w3.org/2001/XMLSchema complexType"`
+}
+
+type xsdComplexType struct {
+	Name           string          `xml:"name,attr"`
+	Sequence       *xsdGroup       `xml:"http://www.w3.org/2001/XMLSchema sequence"`
+	All            *xsdGroup       `xml:"http://www.w3.org/2001/XMLSchema all"`
+	Attributes     []xsdAttribute  `xml:"http://www.w3.org/2001/XMLSchema attribute"`
+	ComplexContent *xsdExtendsType `xml:"http://www.w3.org/2001/XMLSchema complexContent"`
+	SimpleContent  *xsdExtendsType `xml:"http://www.w3.org/2001/XMLSchema simpleContent"`
+}
+
+// xsdExtendsType is a complexContent or simpleContent derivation.
+type xsdExtendsType struct {
+	Extension *struct {
+		Base       string         `xml:"base,attr"`
+		Sequence   *xsdGroup      `xml:"http://www.w3.org/2001/XMLSchema sequence"`
+		Attributes []xsdAttribute `xml:"http://www.w3.org/2001/XMLSchema attribute"`
+	} `xml:"http://www.w3.org/2001/XMLSchema extension"`
+}
+
+type xsdGroup struct {
+	MinOccurs string       `xml:"minOccurs,attr"`
+	Elements  []xsdElement `xml:"http://www.w3.org/2001/XMLSchema element"`
+	Sequences []xsdGroup   `xml:"http://www.w3.org/2001/XMLSchema sequence"`
+}
+
+type xsdAttribute struct {
+	Name string `xml:"name,attr"`
+	Use  string `xml:"use,attr"`
+}
+
+var (
+	schemaMu    sync.Mutex
+	schemaCache = map[string]*xsdSchema{}
+)
+
+// loadSchema decodes the named embedded XSD once and caches the result.
+func loadSchema(name string) (*xsdSchema, error) {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+
+	if schema, ok := schemaCache[name]; ok {
+		return schema, nil
+	}
+
+	content, ok := GetEmbeddedXSD(name)
+	if !ok {
+		return nil, &ConfigError{Message: fmt.Sprintf(
+			"unknown schema %q (available: exchange-documents, fulltext-documents, ops_legal, ops, cpc)", name)}
+	}
+
+	schema := &xsdSchema{}
+	if err := xml.Unmarshal([]byte(content), schema); err != nil {
+		return nil, fmt.Errorf("failed to decode embedded schema %q: %w", name, err)
+	}
+
+	schema.prefixes = map[string]string{}
+	for _, attr := range schema.Namespaces {
+		switch {
+		case attr.Name.Space == "xmlns":
+			schema.prefixes[attr.Name.Local] = attr.Value
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			schema.prefixes[""] = attr.Value
+		}
+	}
+	schema.elements = make(map[string]*xsdElement, len(schema.Elements))
+	for i := range schema.Elements {
+		schema.elements[schema.Elements[i].Name] = &schema.Elements[i]
+	}
+	schema.complexTypes = make(map[string]*xsdComplexType, len(schema.ComplexTypes))
+	for i := range schema.ComplexTypes {
+		schema.complexTypes[schema.ComplexTypes[i].Name] = &schema.ComplexTypes[i]
+	}
+
+	schemaCache[name] = schema
+	return schema, nil
+}
+
+// localName resolves a QName reference (e.g. "exch:bibliographic-dataType") and returns
+// its local part if it refers to the schema's target namespace.
+func (s *xsdSchema) localName(qname string) (string, bool) {
+	prefix, local, found := strings.Cut(qname, ":")
+	if !found {
+		prefix, local = "", qname
+	}
+	if s.prefixes[prefix] != s.TargetNamespace {
+		return "", false
+	}
+	return local, true
+}
+
+// findRoot returns the outermost element of the document that is declared at the
+// top level of the schema, in breadth-first order.
+func (s *xsdSchema) findRoot(root *xmlNode) (*xmlNode, *xsdElement) {
+	queue := []*xmlNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.Name.Space == s.TargetNamespace {
+			if decl, ok := s.elements[node.Name.Local]; ok {
+				return node, decl
+			}
+		}
+		queue = append(queue, node.Children...)
+	}
+	return nil, nil
+}
+
+// resolve follows element references to the declaration carrying the element's type.
+func (s *xsdSchema) resolve(decl *xsdElement) (name string, typ *xsdComplexType) {
+	name = decl.Name
+	if decl.Ref != "" {
+		local, ok := s.localName(decl.Ref)
+		if !ok {
+			return "", nil
+		}
+		name = local
+		if global, ok := s.elements[local]; ok {
+			decl = global
+		} else {
+			return name, nil
+		}
+	}
+	if decl.ComplexType != nil {
+		return name, decl.ComplexType
+	}
+	if decl.Type != "" {
+		if local, ok := s.localName(decl.Type); ok {
+			return name, s.complexTypes[local]
+		}
+	}
+	return name, nil
+}
+
+// check records missing required attributes and child elements of node (declared by decl)
+// and descends into the children that have a declaration.
+func (s *xsdSchema) check(node *xmlNode, decl *xsdElement, path string, problems *[]string) {
+	_, typ := s.resolve(decl)
+	if typ == nil {
+		return
+	}
+
+	for _, attr := range s.requiredAttributes(typ, 0) {
+		if !node.hasAttr(attr) {
+			*problems = append(*problems, fmt.Sprintf("%s: missing required attribute %q", path, attr))
+		}
+	}
+
+	for _, child := range s.childElements(typ, 0) {
+		name, _ := s.resolve(child.decl)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, n := range node.Children {
+			if n.Name.Local != name {
+				continue
+			}
+			found = true
+			s.check(n, child.decl, path+"/"+name, problems)
+		}
+		if !found && child.required {
+			*problems = append(*problems, fmt.Sprintf("%s: missing required element <%s>", path, name))
+		}
+	}
+}
+
+// xsdChild is a child element declaration and whether it must occur.
+type xsdChild struct {
+	decl     *xsdElement
+	required bool
+}
+
+// maxTypeDepth bounds the resolution of type derivation chains.
+const maxTypeDepth = 10
+
+// childElements returns the child element declarations of a complex type, including
+// those inherited through complexContent extension. Elements inside choices are skipped.
+func (s *xsdSchema) childElements(typ *xsdComplexType, depth int) []xsdChild {
+	if typ == nil || depth > maxTypeDepth {
+		return nil
+	}
+
+	var children []xsdChild
+	var collect func(group *xsdGroup, required bool)
+	collect = func(group *xsdGroup, required bool) {
+		if group == nil {
+			return
+		}
+		required = required && group.MinOccurs != "0"
+		for i := range group.Elements {
+			children = append(children, xsdChild{
+				decl:     &group.Elements[i],
+				required: required && group.Elements[i].MinOccurs != "0",
+			})
+		}
+		for i := range group.Sequences {
+			collect(&group.Sequences[i], required)
+		}
+	}
+
+	if ext := extensionOf(typ); ext != nil {
+		if local, ok := s.localName(ext.Extension.Base); ok {
+			children = append(children, s.childElements(s.complexTypes[local], depth+1)...)
+		}
+		collect(ext.Extension.Sequence, true)
+	}
+	collect(typ.Sequence, true)
+	collect(typ.All, true)
+	return children
+}
+
+// requiredAttributes returns the names of the attributes declared use="required",
+// including those inherited through extension.
+func (s *xsdSchema) requiredAttributes(typ *xsdComplexType, depth int) []string {
+	if typ == nil || depth > maxTypeDepth {
+		return nil
+	}
+
+	var names []string
+	attrs := typ.Attributes
+	if ext := extensionOf(typ); ext != nil {
+		if local, ok := s.localName(ext.Extension.Base); ok {
+			names = append(names, s.requiredAttributes(s.complexTypes[local], depth+1)...)
+		}
+		attrs = append(attrs[:len(attrs):len(attrs)], ext.Extension.Attributes...)
+	}
+	for _, attr := range attrs {
+		if attr.Use == "required" && attr.Name != "" {
+			names = append(names, attr.Name)
+		}
+	}
+	return names
+}
+
+// extensionOf returns the complexContent or simpleContent derivation of typ, if it extends a base type.
+func extensionOf(typ *xsdComplexType) *xsdExtendsType {
+	if typ.ComplexContent != nil && typ.ComplexContent.Extension != nil {
+		return typ.ComplexContent
+	}
+	if typ.SimpleContent != nil && typ.SimpleContent.Extension != nil {
+		return typ.SimpleContent
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package epo_ops
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidateAgainstSchema(t *testing.T) {
+	data, err := os.ReadFile("testdata/biblio.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+	biblio := string(data)
+
+	t.Run("Valid biblio", func(t *testing.T) {
+		for _, schema := range []string{"exchange-documents", "ops"} {
+			if err := ValidateAgainstSchema(data, schema); err != nil {
+				t.Errorf("ValidateAgainstSchema(biblio, %q) = %v, want nil", schema, err)
+			}
+		}
+	})
+
+	t.Run("Valid claims", func(t *testing.T) {
+		claims, err := os.ReadFile("testdata/claims.xml")
+		if err != nil {
+			t.Fatalf("Failed to read test data: %v", err)
+		}
+		if err := ValidateAgainstSchema(claims, "fulltext-documents"); err != nil {
+			t.Errorf("ValidateAgainstSchema(claims) = %v, want nil", err)
+		}
+	})
+
+	mangled := []struct {
+		name    string
+		xml     string
+		wantMsg string
+	}{
+		{
+			name:    "Missing bibliographic-data",
+			xml:     regexp.MustCompile(`(?s)<bibliographic-data.*?</bibliographic-data>`).ReplaceAllString(biblio, ""),
+			wantMsg: "/exchange-documents/exchange-document: missing required element <bibliographic-data>",
+		},
+		{
+			name:    "Missing nested publication-reference",
+			xml:     regexp.MustCompile(`(?s)<publication-reference.*?</publication-reference>`).ReplaceAllString(biblio, ""),
+			wantMsg: "bibliographic-data: missing required element <publication-reference>",
+		},
+		{
+			name:    "Missing required attribute",
+			xml:     strings.Replace(biblio, `country="EP"`, "", 1),
+			wantMsg: `missing required attribute "country"`,
+		},
+		{
+			name:    "Changed namespace",
+			xml:     strings.ReplaceAll(biblio, "http://www.epo.org/exchange", "http://example.com/exchange"),
+			wantMsg: "no element declared by the schema",
+		},
+		{
+			name:    "Malformed",
+			xml:     biblio[:len(biblio)/2],
+			wantMsg: "malformed XML",
+		},
+	}
+	for _, tt := range mangled {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgainstSchema([]byte(tt.xml), "exchange-documents")
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("Message: got %q, want it to contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
+	}
+
+	t.Run("Unknown schema", func(t *testing.T) {
+		var configErr *ConfigError
+		if err := ValidateAgainstSchema(data, "register"); !errors.As(err, &configErr) {
+			t.Errorf("Expected ConfigError, got %v", err)
+		}
+	})
+}
+
+func TestListEmbeddedXSDs(t *testing.T) {
+	infos := ListEmbeddedXSDs()
+	if len(infos) != 5 {
+		t.Fatalf("Expected 5 embedded schemas, got %d", len(infos))
+	}
+
+	for _, info := range infos {
+		if info.TargetNamespace == "" {
+			t.Errorf("%s: empty target namespace", info.Name)
+		}
+		if _, ok := GetEmbeddedXSD(info.Name); !ok {
+			t.Errorf("%s: not available from GetEmbeddedXSD", info.Name)
+		}
+	}
+
+	want := map[string]XSDInfo{
+		"exchange-documents": {Name: "exchange-documents", Version: "2.5.7", TargetNamespace: "http://www.epo.org/exchange"},
+		"ops":                {Name: "ops", TargetNamespace: "http://ops.epo.org"},
+		"cpc":                {Name: "cpc", Version: "1.7", TargetNamespace: "http://www.epo.org/cpcexport"},
+	}
+	for _, info := range infos {
+		if w, ok := want[info.Name]; ok && info != w {
+			t.Errorf("Got %+v, want %+v", info, w)
+		}
+	}
+}
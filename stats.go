@@ -0,0 +1,45 @@
+package epo_ops
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// ClientStats is the traffic a client has exchanged with the API, counted locally.
+//
+// Quota headers lag behind and are only updated per response; these counters let long
+// jobs estimate their quota burn in between. Responses served from Config.Cache and
+// described requests (DescribeRequest) are not counted.
+type ClientStats struct {
+	BytesDownloaded int64 // Response body bytes read, including error responses
+	RequestCount    int64 // Responses received, including retried attempts
+	RetryCount      int64 // Attempts repeated after a retryable failure or a rejected token
+}
+
+// clientStats holds the counters behind Client.Stats.
+type clientStats struct {
+	bytes    atomic.Int64
+	requests atomic.Int64
+	retries  atomic.Int64
+}
+
+// Stats returns the traffic counters of the client. It is safe for concurrent use.
+func (c *Client) Stats() ClientStats {
+	return ClientStats{
+		BytesDownloaded: c.stats.bytes.Load(),
+		RequestCount:    c.stats.requests.Load(),
+		RetryCount:      c.stats.retries.Load(),
+	}
+}
+
+// countingBody adds the bytes read from a response body to the client's counter.
+type countingBody struct {
+	io.ReadCloser
+	stats *clientStats
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.stats.bytes.Add(int64(n))
+	return n, err
+}
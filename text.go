@@ -0,0 +1,144 @@
+package epo_ops
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseOption configures the text-bearing parsers (ParseAbstract, ParseClaims and
+// ParseDescription). It is a trailing variadic argument, so existing calls keep
+// compiling and return the text as decoded from the XML.
+type ParseOption func(*parseOptions)
+
+// parseOptions holds the settings collected from ParseOption values.
+type parseOptions struct {
+	// normalizeText post-processes the parsed text (see WithTextNormalization)
+	normalizeText bool
+}
+
+// newParseOptions applies opts to the default parser settings.
+func newParseOptions(opts []ParseOption) parseOptions {
+	var o parseOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// WithTextNormalization post-processes the parsed text for consumers such as NLP
+// pipelines:
+//   - HTML-style entities left in the text after XML decoding (e.g., "&alpha;" or
+//     "&#945;" from double-escaped source data) are decoded
+//   - runs of whitespace, including non-breaking spaces, become a single space; the
+//     line breaks between abstract paragraphs and claim parts are kept, while
+//     description paragraphs become a single line
+//   - fields still containing content without a plain-text representation (private-use
+//     glyphs, U+FFFD replacement characters or control characters, as left by chemical
+//     formulae and math markup) are listed in the UnrepresentableFields of the result
+//
+// Example:
+//
+//	abstract, err := ops.ParseAbstract(xmlData, ops.WithTextNormalization())
+//	if len(abstract.UnrepresentableFields) > 0 {
+//	    // Text may be incomplete, e.g. skip it or fall back to the images
+//	}
+func WithTextNormalization() ParseOption {
+	return func(o *parseOptions) {
+		o.normalizeText = true
+	}
+}
+
+// normalizeText decodes entities in s and normalizes its whitespace (see
+// WithTextNormalization); with multiline set, line breaks are kept and empty lines
+// dropped. ok is false if the result still contains unrepresentable content.
+func normalizeText(s string, multiline bool) (normalized string, ok bool) {
+	s = html.UnescapeString(s)
+
+	if multiline {
+		var lines []string
+		for _, line := range strings.Split(s, "\n") {
+			if line = strings.Join(strings.Fields(line), " "); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		normalized = strings.Join(lines, "\n")
+	} else {
+		normalized = strings.Join(strings.Fields(s), " ")
+	}
+
+	for _, r := range normalized {
+		if r == utf8.RuneError || unicode.Is(unicode.Co, r) || (unicode.IsControl(r) && !unicode.IsSpace(r)) {
+			return normalized, false
+		}
+	}
+	return normalized, true
+}
+
+// normalizeText applies WithTextNormalization to the abstracts; flagged languages are
+// reported as "texts[<lang>]".
+func (d *AbstractData) normalizeText() {
+	for lang, text := range d.Texts {
+		normalized, ok := normalizeText(text, true)
+		d.Texts[lang] = normalized
+		if !ok {
+			d.UnrepresentableFields = append(d.UnrepresentableFields, fmt.Sprintf("texts[%s]", lang))
+		}
+	}
+	sort.Strings(d.UnrepresentableFields)
+	d.Text, _ = normalizeText(d.Text, true)
+}
+
+// normalizeText applies WithTextNormalization to the claims of every language; flagged
+// claims are reported as "claims[<lang>][<number>]".
+func (d *ClaimsData) normalizeText() {
+	// Claims shares its elements with the ClaimsByLanguage entry of the default
+	// language, so normalizing the map covers it as well
+	langs := make([]string, 0, len(d.ClaimsByLanguage))
+	for lang := range d.ClaimsByLanguage {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		claims := d.ClaimsByLanguage[lang]
+		for i := range claims {
+			var ok bool
+			claims[i].Text, ok = normalizeText(claims[i].Text, true)
+			normalizeClaimTexts(claims[i].Parts)
+			if !ok {
+				d.UnrepresentableFields = append(d.UnrepresentableFields,
+					fmt.Sprintf("claims[%s][%d]", lang, claims[i].Number))
+			}
+		}
+	}
+}
+
+// normalizeClaimTexts normalizes the text of nested claim-text parts in place.
+func normalizeClaimTexts(parts []ClaimText) {
+	for i := range parts {
+		parts[i].Text, _ = normalizeText(parts[i].Text, true)
+		normalizeClaimTexts(parts[i].Parts)
+	}
+}
+
+// normalizeText applies WithTextNormalization to the paragraphs; flagged paragraphs are
+// reported as "paragraphs[<index>]" (0-based).
+func (d *DescriptionData) normalizeText() {
+	for i := range d.Paragraphs {
+		p := &d.Paragraphs[i]
+		var ok bool
+		p.Text, ok = normalizeText(p.Text, false)
+		for j := range p.Refs {
+			p.Refs[j].Text, _ = normalizeText(p.Refs[j].Text, false)
+		}
+		if !ok {
+			d.UnrepresentableFields = append(d.UnrepresentableFields, fmt.Sprintf("paragraphs[%d]", i))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package epo_ops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAbstractTextNormalization(t *testing.T) {
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?>
+<ops:world-patent-data xmlns="http://www.epo.org/exchange" xmlns:ops="http://ops.epo.org">
+  <exchange-documents>
+    <exchange-document country="EP" doc-number="1000000" kind="A1">
+      <abstract lang="en"><p>An &#945;-olefin   polymer with &amp;beta;-sheet &amp;#947; units.</p></abstract>
+      <abstract lang="de"><p>Ein Polymer der Formel &#xE000; (siehe Zeichnung).</p></abstract>
+    </exchange-document>
+  </exchange-documents>
+</ops:world-patent-data>`
+
+	data, err := ParseAbstract(xmlData, WithTextNormalization())
+	if err != nil {
+		t.Fatalf("ParseAbstract failed: %v", err)
+	}
+	if want := "An α-olefin polymer with β-sheet γ units."; data.Text != want || data.Texts["en"] != want {
+		t.Errorf("Text: got %q, want %q", data.Text, want)
+	}
+	if want := []string{"texts[de]"}; !reflect.DeepEqual(data.UnrepresentableFields, want) {
+		t.Errorf("UnrepresentableFields: got %v, want %v", data.UnrepresentableFields, want)
+	}
+
+	// Without the option, double-escaped entities are kept as they are
+	plain, err := ParseAbstract(xmlData)
+	if err != nil {
+		t.Fatalf("ParseAbstract failed: %v", err)
+	}
+	if want := "An α-olefin polymer with &beta;-sheet &#947; units."; plain.Text != want {
+		t.Errorf("Text without normalization: got %q, want %q", plain.Text, want)
+	}
+	if plain.UnrepresentableFields != nil {
+		t.Errorf("UnrepresentableFields without normalization: got %v", plain.UnrepresentableFields)
+	}
+}
+
+func TestParseClaimsAndDescriptionTextNormalization(t *testing.T) {
+	claimsXML := `<ops:world-patent-data xmlns:ops="http://ops.epo.org"><ftxt:fulltext-documents xmlns="http://www.epo.org/fulltext" xmlns:ftxt="http://www.epo.org/fulltext"><ftxt:fulltext-document>
+		<claims lang="EN">
+			<claim num="0001"><claim-text>1. A compound of formula &amp;#945;&#160;&#160;(I).</claim-text></claim>
+			<claim num="0002"><claim-text>2. The compound of claim 1, wherein R is &#xF020;.</claim-text></claim>
+		</claims>
+	</ftxt:fulltext-document></ftxt:fulltext-documents></ops:world-patent-data>`
+
+	claims, err := ParseClaims(claimsXML, WithTextNormalization())
+	if err != nil {
+		t.Fatalf("ParseClaims failed: %v", err)
+	}
+	if len(claims.Claims) != 2 || claims.Claims[0].Text != "1. A compound of formula α (I)." {
+		t.Fatalf("Claims: got %+v", claims.Claims)
+	}
+	if claims.ClaimsByLanguage["EN"][0].Text != claims.Claims[0].Text {
+		t.Errorf("ClaimsByLanguage not normalized: %q", claims.ClaimsByLanguage["EN"][0].Text)
+	}
+	if want := []string{"claims[EN][2]"}; !reflect.DeepEqual(claims.UnrepresentableFields, want) {
+		t.Errorf("UnrepresentableFields: got %v, want %v", claims.UnrepresentableFields, want)
+	}
+
+	descriptionXML := `<ops:world-patent-data xmlns:ops="http://ops.epo.org"><ftxt:fulltext-documents xmlns="http://www.epo.org/fulltext" xmlns:ftxt="http://www.epo.org/fulltext"><ftxt:fulltext-document>
+		<description lang="EN">
+			<p num="0001">Heated to 80 &amp;deg;C,
+				as shown in <figref idref="f0001">Fig.&#160;1</figref>.</p>
+			<p num="0002">Ratio &#xFFFD; unknown.</p>
+		</description>
+	</ftxt:fulltext-document></ftxt:fulltext-documents></ops:world-patent-data>`
+
+	description, err := ParseDescription(descriptionXML, WithTextNormalization())
+	if err != nil {
+		t.Fatalf("ParseDescription failed: %v", err)
+	}
+	if len(description.Paragraphs) != 2 || description.Paragraphs[0].Text != "Heated to 80 °C, as shown in Fig. 1." {
+		t.Fatalf("Paragraphs: got %+v", description.Paragraphs)
+	}
+	if refs := description.Paragraphs[0].Refs; len(refs) != 1 || refs[0].Text != "Fig. 1" {
+		t.Errorf("Refs: got %+v", refs)
+	}
+	if want := []string{"paragraphs[1]"}; !reflect.DeepEqual(description.UnrepresentableFields, want) {
+		t.Errorf("UnrepresentableFields: got %v, want %v", description.UnrepresentableFields, want)
+	}
+}
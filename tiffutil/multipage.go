@@ -0,0 +1,462 @@
+package tiffutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"github.com/hhrutter/tiff"
+)
+
+// TIFF tags handled by the multi-page functions
+const (
+	tagBitsPerSample   = 258
+	tagStripOffsets    = 273
+	tagStripByteCounts = 279
+	tagXResolution     = 282
+	tagYResolution     = 283
+	tagFreeOffsets     = 288
+	tagFreeByteCounts  = 289
+	tagResolutionUnit  = 296
+	tagPageNumber      = 297
+	tagTileOffsets     = 324
+	tagTileByteCounts  = 325
+	tagSubIFDs         = 330
+	tagJPEGIFOffset    = 513
+	tagJPEGIFByteCount = 514
+	tagExifIFD         = 34665
+	tagGPSIFD          = 34853
+)
+
+// TIFF field types
+const (
+	typeShort = 3
+	typeLong  = 4
+)
+
+// typeSizes holds the size in bytes of one value of each TIFF field type
+var typeSizes = map[uint16]int{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8, 13: 4,
+}
+
+// tiffEntry is one IFD entry. data holds the values in the byte order of the
+// TIFF that will be written.
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	data  []byte
+}
+
+// tiffPage is one IFD together with the image data it points to.
+type tiffPage struct {
+	entries   []tiffEntry
+	chunks    [][]byte // strips or tiles
+	offsetTag uint16   // tagStripOffsets or tagTileOffsets
+	countTag  uint16   // tagStripByteCounts or tagTileByteCounts
+	ifdOffset int64    // offset of the IFD in the source TIFF
+}
+
+// MergeTIFFPages assembles TIFF images into one multi-page TIFF.
+//
+// Each input is typically a single page as returned by the EPO image service
+// (e.g. from DownloadAllPages). Inputs that already contain several pages
+// contribute all of them. The image data is copied as is, so CCITT Group 4
+// drawings keep their compression; only the directory entries are rewritten.
+//
+// The result uses the byte order of the first page. Pages in the other byte
+// order are converted, which works for all 1-bit and 8-bit images; pages with
+// more than 8 bits per sample in a different byte order are rejected.
+//
+// The PageNumber tag of every page is set to its position in the result.
+func MergeTIFFPages(pages [][]byte) ([]byte, error) {
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no TIFF pages")
+	}
+
+	var order binary.ByteOrder
+	var all []*tiffPage
+	for i, data := range pages {
+		pageOrder, err := tiffByteOrder(data)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+		if order == nil {
+			order = pageOrder
+		}
+		parsed, err := readTIFF(data, order)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+		if pageOrder != order {
+			for _, page := range parsed {
+				if bits := page.maxBitsPerSample(order); bits > 8 {
+					return nil, fmt.Errorf("page %d: cannot convert %d-bit samples to another byte order", i+1, bits)
+				}
+			}
+		}
+		all = append(all, parsed...)
+	}
+
+	for i, page := range all {
+		pageNumber := make([]byte, 4)
+		order.PutUint16(pageNumber, uint16(i))
+		order.PutUint16(pageNumber[2:], uint16(len(all)))
+		page.setEntry(tiffEntry{tag: tagPageNumber, typ: typeShort, count: 2, data: pageNumber})
+	}
+
+	return writeTIFF(order, all)
+}
+
+// CountTIFFPages returns the number of pages (image file directories) of a TIFF.
+func CountTIFFPages(tiffData []byte) (int, error) {
+	order, err := tiffByteOrder(tiffData)
+	if err != nil {
+		return 0, err
+	}
+	pages, err := readTIFF(tiffData, order)
+	if err != nil {
+		return 0, err
+	}
+	return len(pages), nil
+}
+
+// DecodeTIFFPages decodes every page of a (multi-page) TIFF.
+func DecodeTIFFPages(tiffData []byte) ([]image.Image, error) {
+	order, err := tiffByteOrder(tiffData)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := readTIFF(tiffData, order)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]image.Image, 0, len(pages))
+	for i, page := range pages {
+		img, err := tiff.DecodeAt(bytes.NewReader(tiffData), page.ifdOffset)
+		if err != nil {
+			return images, fmt.Errorf("failed to decode page %d: %w", i+1, err)
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// tiffByteOrder checks the TIFF header and returns the byte order of the file.
+func tiffByteOrder(data []byte) (binary.ByteOrder, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty TIFF data")
+	}
+	if len(data) < 8 {
+		return nil, fmt.Errorf("invalid TIFF header")
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid TIFF header")
+	}
+
+	switch order.Uint16(data[2:]) {
+	case 42:
+		return order, nil
+	case 43:
+		return nil, fmt.Errorf("BigTIFF is not supported")
+	default:
+		return nil, fmt.Errorf("invalid TIFF header")
+	}
+}
+
+// readTIFF reads all IFDs of a classic TIFF. Entry values are converted to the byte
+// order out; image data is referenced, not copied.
+func readTIFF(data []byte, out binary.ByteOrder) ([]*tiffPage, error) {
+	in, err := tiffByteOrder(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var pages []*tiffPage
+	seen := make(map[uint32]bool)
+	for offset := in.Uint32(data[4:]); offset != 0; {
+		if seen[offset] {
+			return nil, fmt.Errorf("invalid TIFF: IFD loop at offset %d", offset)
+		}
+		seen[offset] = true
+
+		page, next, err := readIFD(data, in, out, offset)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+		offset = next
+	}
+
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("invalid TIFF: no image file directory")
+	}
+	return pages, nil
+}
+
+// readIFD reads the IFD at offset and returns it with the offset of the next IFD.
+func readIFD(data []byte, in, out binary.ByteOrder, offset uint32) (*tiffPage, uint32, error) {
+	if uint64(offset)+2 > uint64(len(data)) {
+		return nil, 0, fmt.Errorf("invalid TIFF: IFD offset %d out of range", offset)
+	}
+	n := int(in.Uint16(data[offset:]))
+	end := uint64(offset) + 2 + uint64(n)*12 + 4
+	if end > uint64(len(data)) {
+		return nil, 0, fmt.Errorf("invalid TIFF: IFD at offset %d is truncated", offset)
+	}
+
+	page := &tiffPage{ifdOffset: int64(offset)}
+	values := make(map[uint16][]uint64)
+	for i := 0; i < n; i++ {
+		raw := data[int(offset)+2+i*12:]
+		entry := tiffEntry{
+			tag:   in.Uint16(raw),
+			typ:   in.Uint16(raw[2:]),
+			count: in.Uint32(raw[4:]),
+		}
+
+		// Directory references and free space lists cannot be carried over
+		switch entry.tag {
+		case tagSubIFDs, tagExifIFD, tagGPSIFD, tagJPEGIFOffset, tagJPEGIFByteCount, tagFreeOffsets, tagFreeByteCounts:
+			continue
+		}
+
+		size, ok := typeSizes[entry.typ]
+		if !ok {
+			// Unknown field types may be skipped by readers (TIFF 6.0, section 2)
+			continue
+		}
+		length := uint64(size) * uint64(entry.count)
+		var value []byte
+		if length <= 4 {
+			value = raw[8 : 8+length]
+		} else {
+			valueOffset := uint64(in.Uint32(raw[8:]))
+			if valueOffset+length > uint64(len(data)) {
+				return nil, 0, fmt.Errorf("invalid TIFF: value of tag %d out of range", entry.tag)
+			}
+			value = data[valueOffset : valueOffset+length]
+		}
+		entry.data = convertValues(value, entry.typ, in, out)
+
+		switch entry.tag {
+		case tagStripOffsets, tagStripByteCounts, tagTileOffsets, tagTileByteCounts:
+			values[entry.tag] = entry.uints(out)
+		}
+		page.entries = append(page.entries, entry)
+	}
+
+	// IFD entries must be sorted by tag; not every writer does so
+	sort.Slice(page.entries, func(i, j int) bool { return page.entries[i].tag < page.entries[j].tag })
+
+	page.offsetTag, page.countTag = tagStripOffsets, tagStripByteCounts
+	if _, ok := values[tagTileOffsets]; ok {
+		page.offsetTag, page.countTag = tagTileOffsets, tagTileByteCounts
+	}
+	offsets, counts := values[page.offsetTag], values[page.countTag]
+	if len(offsets) == 0 || len(offsets) != len(counts) {
+		return nil, 0, fmt.Errorf("invalid TIFF: missing or inconsistent image data offsets")
+	}
+	for i := range offsets {
+		if offsets[i]+counts[i] > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("invalid TIFF: image data out of range")
+		}
+		page.chunks = append(page.chunks, data[offsets[i]:offsets[i]+counts[i]])
+	}
+
+	next := in.Uint32(data[end-4:])
+	return page, next, nil
+}
+
+// convertValues converts the values of an entry from byte order in to byte order out.
+func convertValues(value []byte, typ uint16, in, out binary.ByteOrder) []byte {
+	converted := make([]byte, len(value))
+	copy(converted, value)
+	if in == out {
+		return converted
+	}
+
+	// Rationals are pairs of 4-byte integers; all other types swap per value
+	size := typeSizes[typ]
+	if typ == 5 || typ == 10 {
+		size = 4
+	}
+	for i := 0; i+size <= len(converted); i += size {
+		for a, b := i, i+size-1; a < b; a, b = a+1, b-1 {
+			converted[a], converted[b] = converted[b], converted[a]
+		}
+	}
+	return converted
+}
+
+// uints returns the values of a SHORT, LONG or BYTE entry.
+func (e tiffEntry) uints(order binary.ByteOrder) []uint64 {
+	values := make([]uint64, 0, e.count)
+	for i := 0; i < int(e.count); i++ {
+		switch e.typ {
+		case typeShort:
+			values = append(values, uint64(order.Uint16(e.data[i*2:])))
+		case typeLong:
+			values = append(values, uint64(order.Uint32(e.data[i*4:])))
+		case 1:
+			values = append(values, uint64(e.data[i]))
+		}
+	}
+	return values
+}
+
+// entry returns the entry with the given tag.
+func (p *tiffPage) entry(tag uint16) (tiffEntry, bool) {
+	for _, e := range p.entries {
+		if e.tag == tag {
+			return e, true
+		}
+	}
+	return tiffEntry{}, false
+}
+
+// setEntry replaces the entry with the same tag or adds it, keeping entries sorted by tag.
+func (p *tiffPage) setEntry(entry tiffEntry) {
+	for i, e := range p.entries {
+		if e.tag == entry.tag {
+			p.entries[i] = entry
+			return
+		}
+		if e.tag > entry.tag {
+			p.entries = append(p.entries[:i], append([]tiffEntry{entry}, p.entries[i:]...)...)
+			return
+		}
+	}
+	p.entries = append(p.entries, entry)
+}
+
+// maxBitsPerSample returns the largest BitsPerSample value of the page (1 if absent).
+func (p *tiffPage) maxBitsPerSample(order binary.ByteOrder) int {
+	bits := 1
+	if e, ok := p.entry(tagBitsPerSample); ok {
+		for _, v := range e.uints(order) {
+			bits = max(bits, int(v))
+		}
+	}
+	return bits
+}
+
+// resolution returns the horizontal and vertical resolution of the page in dots per inch,
+// or 0 if the page does not declare it.
+func (p *tiffPage) resolution(order binary.ByteOrder) (x, y float64) {
+	unit := uint64(2) // inch
+	if e, ok := p.entry(tagResolutionUnit); ok {
+		if v := e.uints(order); len(v) == 1 {
+			unit = v[0]
+		}
+	}
+
+	rational := func(tag uint16) float64 {
+		e, ok := p.entry(tag)
+		if !ok || e.typ != 5 || e.count < 1 {
+			return 0
+		}
+		num, den := order.Uint32(e.data), order.Uint32(e.data[4:])
+		if den == 0 {
+			return 0
+		}
+		return float64(num) / float64(den)
+	}
+	x, y = rational(tagXResolution), rational(tagYResolution)
+
+	switch unit {
+	case 2:
+		return x, y
+	case 3: // centimeter
+		return x * 2.54, y * 2.54
+	default: // no absolute unit
+		return 0, 0
+	}
+}
+
+// writeTIFF writes pages as one classic TIFF in byte order order. The entry values
+// of pages must already be in that byte order.
+func writeTIFF(order binary.ByteOrder, pages []*tiffPage) ([]byte, error) {
+	var buf bytes.Buffer
+	if order == binary.BigEndian {
+		buf.WriteString("MM")
+	} else {
+		buf.WriteString("II")
+	}
+	header := make([]byte, 6)
+	order.PutUint16(header, 42)
+	buf.Write(header)
+
+	// Offset of the pointer to patch with the offset of the next IFD
+	pointer := 4
+
+	for _, page := range pages {
+		// Image data first, so the IFD can reference it
+		offsets := make([]byte, 4*len(page.chunks))
+		counts := make([]byte, 4*len(page.chunks))
+		for i, chunk := range page.chunks {
+			pad(&buf)
+			order.PutUint32(offsets[i*4:], uint32(buf.Len()))
+			order.PutUint32(counts[i*4:], uint32(len(chunk)))
+			buf.Write(chunk)
+		}
+		entries := append([]tiffEntry(nil), page.entries...)
+		sized := &tiffPage{entries: entries}
+		sized.setEntry(tiffEntry{tag: page.offsetTag, typ: typeLong, count: uint32(len(page.chunks)), data: offsets})
+		sized.setEntry(tiffEntry{tag: page.countTag, typ: typeLong, count: uint32(len(page.chunks)), data: counts})
+		entries = sized.entries
+
+		pad(&buf)
+		ifdOffset := buf.Len()
+		if uint64(ifdOffset) > math.MaxUint32 {
+			return nil, fmt.Errorf("merged TIFF exceeds 4 GiB")
+		}
+		order.PutUint32(buf.Bytes()[pointer:], uint32(ifdOffset))
+
+		ifd := make([]byte, 2+12*len(entries)+4)
+		order.PutUint16(ifd, uint16(len(entries)))
+		valueOffset := ifdOffset + len(ifd)
+		var values []byte
+		for i, entry := range entries {
+			raw := ifd[2+i*12:]
+			order.PutUint16(raw, entry.tag)
+			order.PutUint16(raw[2:], entry.typ)
+			order.PutUint32(raw[4:], entry.count)
+			if len(entry.data) <= 4 {
+				copy(raw[8:12], entry.data)
+				continue
+			}
+			if (valueOffset+len(values))%2 != 0 {
+				values = append(values, 0)
+			}
+			order.PutUint32(raw[8:], uint32(valueOffset+len(values)))
+			values = append(values, entry.data...)
+		}
+		buf.Write(ifd)
+		buf.Write(values)
+		pointer = ifdOffset + len(ifd) - 4
+	}
+
+	if uint64(buf.Len()) > math.MaxUint32 {
+		return nil, fmt.Errorf("merged TIFF exceeds 4 GiB")
+	}
+	return buf.Bytes(), nil
+}
+
+// pad aligns the buffer to a word boundary, as TIFF requires for IFDs and offsets.
+func pad(buf *bytes.Buffer) {
+	if buf.Len()%2 != 0 {
+		buf.WriteByte(0)
+	}
+}
@@ -0,0 +1,158 @@
+package tiffutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hhrutter/tiff"
+)
+
+// encodeTestPage encodes a black-and-white test page with a black top-left pixel.
+func encodeTestPage(t *testing.T, width, height int, order binary.ByteOrder) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.SetGray(0, 0, color.Gray{Y: 0})
+
+	var buf bytes.Buffer
+	if err := tiff.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("Failed to encode TIFF: %v", err)
+	}
+	if order == binary.LittleEndian {
+		return buf.Bytes()
+	}
+
+	// The encoder writes little-endian files only
+	pages, err := readTIFF(buf.Bytes(), order)
+	if err != nil {
+		t.Fatalf("Failed to read TIFF: %v", err)
+	}
+	data, err := writeTIFF(order, pages)
+	if err != nil {
+		t.Fatalf("Failed to write TIFF: %v", err)
+	}
+	return data
+}
+
+func TestMergeTIFFPages(t *testing.T) {
+	bigEndian := encodeTestPage(t, 30, 20, binary.BigEndian)
+	if string(bigEndian[:2]) != "MM" {
+		t.Fatalf("Expected big-endian test page, got %q", bigEndian[:2])
+	}
+
+	pages := [][]byte{
+		encodeTestPage(t, 10, 20, binary.LittleEndian),
+		bigEndian,
+		encodeTestPage(t, 50, 40, binary.LittleEndian),
+	}
+	merged, err := MergeTIFFPages(pages)
+	if err != nil {
+		t.Fatalf("MergeTIFFPages failed: %v", err)
+	}
+
+	count, err := CountTIFFPages(merged)
+	if err != nil {
+		t.Fatalf("CountTIFFPages failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Page count: got %d, want 3", count)
+	}
+
+	images, err := DecodeTIFFPages(merged)
+	if err != nil {
+		t.Fatalf("DecodeTIFFPages failed: %v", err)
+	}
+	wantSizes := []image.Point{{10, 20}, {30, 20}, {50, 40}}
+	if len(images) != len(wantSizes) {
+		t.Fatalf("Decoded pages: got %d, want %d", len(images), len(wantSizes))
+	}
+	for i, img := range images {
+		if size := img.Bounds().Size(); size != wantSizes[i] {
+			t.Errorf("Page %d size: got %v, want %v", i+1, size, wantSizes[i])
+		}
+		if gray := color.GrayModel.Convert(img.At(0, 0)).(color.Gray); gray.Y != 0 {
+			t.Errorf("Page %d: expected black top-left pixel, got %d", i+1, gray.Y)
+		}
+		if gray := color.GrayModel.Convert(img.At(1, 1)).(color.Gray); gray.Y != 0xff {
+			t.Errorf("Page %d: expected white pixel, got %d", i+1, gray.Y)
+		}
+	}
+
+	// The first page's image also decodes as a regular single TIFF
+	if _, err := DecodeTIFF(merged); err != nil {
+		t.Errorf("DecodeTIFF on merged TIFF failed: %v", err)
+	}
+
+	// Merging a merged TIFF keeps all of its pages
+	again, err := MergeTIFFPages([][]byte{merged, pages[0]})
+	if err != nil {
+		t.Fatalf("MergeTIFFPages on merged TIFF failed: %v", err)
+	}
+	if count, _ := CountTIFFPages(again); count != 4 {
+		t.Errorf("Page count: got %d, want 4", count)
+	}
+
+	if _, err := MergeTIFFPages(nil); err == nil {
+		t.Error("Expected error for no pages, got nil")
+	}
+	if _, err := MergeTIFFPages([][]byte{pages[0], []byte("not a valid TIFF file")}); err == nil || !strings.Contains(err.Error(), "page 2") {
+		t.Errorf("Expected error for page 2, got %v", err)
+	}
+	if _, err := CountTIFFPages(pages[0][:20]); err == nil {
+		t.Error("Expected error for truncated TIFF, got nil")
+	}
+}
+
+func TestTIFFPagesToPDF(t *testing.T) {
+	pages := [][]byte{
+		encodeTestPage(t, 144, 72, binary.LittleEndian),
+		encodeTestPage(t, 30, 20, binary.BigEndian),
+	}
+	pdf, err := TIFFPagesToPDF(pages)
+	if err != nil {
+		t.Fatalf("TIFFPagesToPDF failed: %v", err)
+	}
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Error("Output is not a complete PDF document")
+	}
+	if got := regexp.MustCompile(`/Type /Page\b[^s]`).FindAll(pdf, -1); len(got) != 2 {
+		t.Errorf("Expected 2 pages, got %d", len(got))
+	}
+	if !bytes.Contains(pdf, []byte("/Count 2")) {
+		t.Error("Page tree does not count 2 pages")
+	}
+	// The encoder stores 72 dpi, so one pixel is one point
+	if !bytes.Contains(pdf, []byte("/MediaBox [0 0 144.00 72.00]")) {
+		t.Error("Expected 144x72 pt page for 144x72 px at 72 dpi")
+	}
+	if !bytes.Contains(pdf, []byte("/BitsPerComponent 1")) {
+		t.Error("Expected black-and-white pages as 1-bit images")
+	}
+
+	// Every xref entry points to its object
+	xref := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf, -1)
+	if len(xref) != 8 {
+		t.Fatalf("Expected 8 xref entries, got %d", len(xref))
+	}
+	for i, entry := range xref {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("xref entry %d does not point to %q", i+1, want)
+		}
+	}
+
+	if _, err := TIFFPagesToPDF([][]byte{[]byte("not a valid TIFF file")}); err == nil {
+		t.Error("Expected error for invalid TIFF data, got nil")
+	}
+}
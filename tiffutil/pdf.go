@@ -0,0 +1,216 @@
+package tiffutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// defaultDPI is assumed for pages that do not declare their resolution.
+const defaultDPI = 300
+
+// TIFFPagesToPDF combines TIFF images into one PDF document with one image per page.
+//
+// Each input is typically a single page as returned by the EPO image service
+// (e.g. from DownloadAllPages); inputs with several pages contribute all of them.
+// The page size follows the resolution stored in the TIFF (300 dpi if none is
+// given), so drawings print at their original size. Pages are not rotated.
+//
+// Black-and-white pages (e.g. CCITT Group 4 drawings) are embedded as 1-bit
+// images, grayscale pages as 8-bit gray and all others as RGB, each
+// Flate-compressed.
+func TIFFPagesToPDF(pages [][]byte) ([]byte, error) {
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no TIFF pages")
+	}
+
+	var pdfPages []pdfPage
+	for i, data := range pages {
+		order, err := tiffByteOrder(data)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+		parsed, err := readTIFF(data, order)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+		images, err := DecodeTIFFPages(data)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+
+		for j, img := range images {
+			xDPI, yDPI := parsed[j].resolution(order)
+			if xDPI <= 0 || yDPI <= 0 {
+				xDPI, yDPI = defaultDPI, defaultDPI
+			}
+			page, err := newPDFPage(img, xDPI, yDPI)
+			if err != nil {
+				return nil, fmt.Errorf("page %d: %w", i+1, err)
+			}
+			pdfPages = append(pdfPages, page)
+		}
+	}
+
+	return writePDF(pdfPages), nil
+}
+
+// pdfPage is one page image prepared for embedding.
+type pdfPage struct {
+	width, height int     // pixels
+	widthPt       float64 // page size in points (1/72 inch)
+	heightPt      float64
+	colorSpace    string
+	bits          int
+	data          []byte // Flate-compressed samples
+}
+
+// newPDFPage converts img into PDF image samples.
+func newPDFPage(img image.Image, xDPI, yDPI float64) (pdfPage, error) {
+	bounds := img.Bounds()
+	page := pdfPage{
+		width:    bounds.Dx(),
+		height:   bounds.Dy(),
+		widthPt:  float64(bounds.Dx()) * 72 / xDPI,
+		heightPt: float64(bounds.Dy()) * 72 / yDPI,
+	}
+	if page.width == 0 || page.height == 0 {
+		return page, fmt.Errorf("empty image")
+	}
+
+	var samples []byte
+	if isGray(img) {
+		gray := make([]byte, 0, page.width*page.height)
+		bilevel := true
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				v := color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+				bilevel = bilevel && (v == 0 || v == 0xff)
+				gray = append(gray, v)
+			}
+		}
+		page.colorSpace = "DeviceGray"
+		if bilevel {
+			page.bits = 1
+			samples = packBits(gray, page.width, page.height)
+		} else {
+			page.bits = 8
+			samples = gray
+		}
+	} else {
+		page.colorSpace = "DeviceRGB"
+		page.bits = 8
+		samples = make([]byte, 0, page.width*page.height*3)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+				samples = append(samples, c.R, c.G, c.B)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(samples); err != nil {
+		return page, fmt.Errorf("failed to compress image: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return page, fmt.Errorf("failed to compress image: %w", err)
+	}
+	page.data = buf.Bytes()
+	return page, nil
+}
+
+// isGray reports whether img has no color information.
+func isGray(img image.Image) bool {
+	switch m := img.(type) {
+	case *image.Gray, *image.Gray16:
+		return true
+	case *image.Paletted:
+		for _, c := range m.Palette {
+			r, g, b, _ := c.RGBA()
+			if r != g || g != b {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// packBits packs 8-bit black/white samples into rows of 1-bit samples (1 = white).
+func packBits(gray []byte, width, height int) []byte {
+	rowBytes := (width + 7) / 8
+	packed := make([]byte, rowBytes*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if gray[y*width+x] != 0 {
+				packed[y*rowBytes+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return packed
+}
+
+// writePDF writes a PDF document with one full-page image per page.
+func writePDF(pages []pdfPage) []byte {
+	var buf bytes.Buffer
+	var offsets []int // byte offset of each object, object n at index n-1
+
+	begin := func() int {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+		return len(offsets)
+	}
+	end := func() {
+		buf.WriteString("endobj\n")
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 and 2 are the catalog and the page tree; each page takes three
+	// objects (page, content stream, image) starting at object 3
+	begin()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\n")
+	end()
+
+	begin()
+	buf.WriteString("<< /Type /Pages /Kids [")
+	for i := range pages {
+		fmt.Fprintf(&buf, " %d 0 R", 3+3*i)
+	}
+	fmt.Fprintf(&buf, " ] /Count %d >>\n", len(pages))
+	end()
+
+	for _, page := range pages {
+		pageObj := begin()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>\n",
+			page.widthPt, page.heightPt, pageObj+2, pageObj+1)
+		end()
+
+		content := fmt.Sprintf("q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q\n", page.widthPt, page.heightPt)
+		begin()
+		fmt.Fprintf(&buf, "<< /Length %d >>\nstream\n%s\nendstream\n", len(content), content)
+		end()
+
+		begin()
+		fmt.Fprintf(&buf, "<< /Type /XObject /Subtype /Image /Width %d /Height %d "+
+			"/ColorSpace /%s /BitsPerComponent %d /Filter /FlateDecode /Length %d >>\nstream\n",
+			page.width, page.height, page.colorSpace, page.bits, len(page.data))
+		buf.Write(page.data)
+		buf.WriteString("\nendstream\n")
+		end()
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
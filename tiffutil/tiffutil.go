@@ -1,5 +1,6 @@
 // Package tiffutil provides utilities for TIFF image handling, specifically
-// for converting EPO patent TIFF images to PNG format.
+// for converting EPO patent TIFF images to PNG format and for combining the
+// single-page TIFFs served by EPO into one multi-page TIFF or PDF.
 //
 // EPO patent images are often in TIFF format with various compressions:
 //   - CCITT Group 3/4 (for black and white technical drawings)
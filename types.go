@@ -1,6 +1,15 @@
 package epo_ops
 
-import "time"
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/patent-dev/epo-ops/cql"
+)
 
 // Reference types for API requests
 const (
@@ -30,6 +39,15 @@ const (
 	ConstituentFullCycle = "full-cycle"
 )
 
+// FamilyType selects the patent family definition for GetFamilyByType
+type FamilyType string
+
+// Patent family types
+const (
+	FamilySimple   FamilyType = "simple"   // Equivalents: publications with exactly the same priorities
+	FamilyExtended FamilyType = "extended" // INPADOC: publications linked directly or indirectly by a priority
+)
+
 // Endpoint types for Accept header selection
 const (
 	EndpointBiblio      = "biblio"
@@ -65,22 +83,143 @@ type Config struct {
 	// Default: 3
 	MaxRetries int
 
+	// MaxRetryBudget caps the HTTP attempts of one method call, counting the first
+	// attempt, the retries of throttled or unavailable responses and the retry after
+	// a token refresh on 401. When it stops a retry, the last error is returned wrapped
+	// in a RetryBudgetExceededError. Methods that make several API requests (bulk
+	// methods, SearchAll) share one budget across them: each request is still sent
+	// once, but no longer retried once the call has used up the budget.
+	// A negative value disables the budget.
+	// Default: 5
+	MaxRetryBudget int
+
 	// RetryDelay is the base delay between retries.
+	// Retry N waits RetryDelay * 2^(N-1) plus up to 50% random jitter.
 	// Default: 1 second
 	RetryDelay time.Duration
 
+	// MaxRetryDelay caps the delay between retries of the default backoff strategy.
+	// Default: 30 seconds
+	MaxRetryDelay time.Duration
+
+	// BackoffStrategy returns the delay before retry attempt N (1-based).
+	// Optional: when nil, ExponentialBackoff(RetryDelay, MaxRetryDelay) is used.
+	BackoffStrategy func(attempt int) time.Duration
+
 	// Timeout is the HTTP client timeout.
 	// Default: 30 seconds
 	Timeout time.Duration
+
+	// TokenRefreshBuffer is how long before expiry the access token is refreshed proactively.
+	// A 401 response still triggers a refresh as a fallback (e.g., for clock skew).
+	// Default: 60 seconds
+	TokenRefreshBuffer time.Duration
+
+	// Cache stores successful responses of single-document and classification lookups
+	// so repeated requests do not hit EPO (and the quota) again.
+	// Optional: nil disables caching. See NewLRUCache for an in-memory implementation.
+	Cache ResponseCache
+
+	// CacheTTL is how long cached responses stay valid.
+	// Default: 24 hours
+	CacheTTL time.Duration
+
+	// CacheClassifications keeps classification schema responses in a dedicated
+	// in-memory LRU cache of at most 10,000 entries, keyed by the symbol(s) and the
+	// ancestors/navigation flags. It covers GetClassificationSchemaRaw,
+	// GetClassificationSchemaSubclassRaw, GetClassificationSchemaMultipleRaw and the
+	// titles looked up by EnrichCPCTitles, independently of Cache.
+	// Optional: false leaves classification lookups to Cache.
+	CacheClassifications bool
+
+	// ClassificationCacheTTL is how long entries of the classification cache stay valid.
+	// Default: 24 hours
+	ClassificationCacheTTL time.Duration
+
+	// ValidateImagesBeforeFetch makes GetImage (and GetImageThumbnail and
+	// GetFirstPageImage) check the request against the image inquiry of the document
+	// first: an image type the document does not have or a page beyond its page count
+	// fails with a ValidationError instead of an EPO error. Image inquiries are then kept
+	// in a dedicated in-memory LRU cache of at most 1,000 entries for CacheTTL, so each
+	// document costs one inquiry; GetImageInquiry and GetImageRange use it too.
+	// Optional: false requests images without checking.
+	ValidateImagesBeforeFetch bool
+
+	// Interceptors wrap every API request, e.g. for metrics, extra headers or canned
+	// test responses. They run in order (the first one is outermost) and see the
+	// request after the Authorization and Accept headers have been set.
+	// Token requests to AuthURL do not pass through interceptors.
+	// Optional: nil sends requests directly.
+	Interceptors []RequestInterceptor
+
+	// CircuitBreakerThreshold is the number of consecutive calls failing with a
+	// ServiceUnavailableError or without any response, e.g. a refused connection
+	// (after their retries), that opens the circuit breaker. While open, calls fail
+	// fast with a CircuitOpenError; after CircuitBreakerCooldown a single probe call
+	// is let through, and a response below 500 closes the circuit again.
+	// Default: 0 (disabled)
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long an open circuit rejects calls before a probe.
+	// Default: 30 seconds
+	CircuitBreakerCooldown time.Duration
+
+	// MaxResponseBytes caps the size of a response body read into memory. Larger
+	// responses fail with a ResponseTooLargeError instead of being truncated.
+	// Streaming methods such as GetDescriptionStream are not limited.
+	// Optional: 0 (or a negative value) disables the limit; DefaultConfig sets 64 MB.
+	MaxResponseBytes int64
+
+	// RecordDir is a directory every API request and its response are written to as
+	// a fixture file (see RecordingTransport), e.g. to capture EPO responses once for
+	// offline tests. Token requests are not recorded.
+	// Optional: empty disables recording.
+	RecordDir string
+
+	// ReplayDir is a directory of fixtures recorded with RecordDir. When set, requests
+	// are answered from the fixtures instead of EPO (see ReplayTransport), and requests
+	// without a fixture fail. Cannot be combined with RecordDir.
+	// Optional: empty sends requests to EPO.
+	ReplayDir string
 }
 
+// RequestInterceptor intercepts an outgoing API request.
+//
+// An interceptor may inspect or modify req before passing it to next, inspect the
+// response returned by next, or short-circuit by returning a response of its own
+// without calling next. Interceptors run once per attempt, so retried requests pass
+// through them again; RequestIDFromContext(req.Context()) tells the attempts of one
+// call apart from those of concurrent calls.
+//
+// Example:
+//
+//	logRequests := func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+//	    start := time.Now()
+//	    resp, err := next(req)
+//	    log.Printf("%s %s (%v)", req.Method, req.URL.Path, time.Since(start))
+//	    return resp, err
+//	}
+//	client, err := ops.NewClient(&ops.Config{
+//	    ConsumerKey:    "key",
+//	    ConsumerSecret: "secret",
+//	    Interceptors:   []ops.RequestInterceptor{logRequests},
+//	})
+type RequestInterceptor func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error)
+
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
-		BaseURL:    "https://ops.epo.org/3.2/rest-services",
-		MaxRetries: 3,
-		RetryDelay: 1 * time.Second,
-		Timeout:    30 * time.Second,
+		BaseURL:            "https://ops.epo.org/3.2/rest-services",
+		MaxRetries:         3,
+		MaxRetryBudget:     defaultMaxRetryBudget,
+		RetryDelay:         1 * time.Second,
+		MaxRetryDelay:      30 * time.Second,
+		Timeout:            30 * time.Second,
+		TokenRefreshBuffer: 60 * time.Second,
+		CacheTTL:           24 * time.Hour,
+
+		CircuitBreakerCooldown: 30 * time.Second,
+		MaxResponseBytes:       defaultMaxResponseBytes,
 	}
 }
 
@@ -88,20 +227,51 @@ func DefaultConfig() *Config {
 type BulkOptions struct {
 	// MaxConcurrent is the maximum number of concurrent requests.
 	// Default: 1 (sequential processing)
-	// Note: Concurrent processing not yet implemented
+	// Note: Only GetImageInquiriesBulk runs requests concurrently; the batched
+	// bulk methods process their batches sequentially
 	MaxConcurrent int
 
 	// OnProgress is called after each batch completes.
 	// Parameters: current batch number, total batches
+	// (for GetImageInquiriesBulk: completed numbers, total numbers)
 	// Optional: set to nil to disable progress callbacks
 	OnProgress func(current, total int)
 }
 
+// BulkResult is the outcome of one batch of a bulk retrieval.
+// Exactly one of Data and Err is set.
+type BulkResult struct {
+	// Batch is the 1-based batch number
+	Batch int
+
+	// Numbers are the patent numbers requested in this batch
+	Numbers []string
+
+	// Data is the raw XML response for the batch
+	Data string
+
+	// Err is the error of the batch request, if it failed
+	Err error
+}
+
+// MediaResult is a classification media file together with its detected type.
+type MediaResult struct {
+	// Data is the binary media content
+	Data []byte
+
+	// ContentType is the MIME type, e.g. "image/gif"
+	ContentType string
+
+	// Extension is the file extension matching ContentType, including the dot
+	// (e.g. ".gif"); ".bin" if the type is unknown
+	Extension string
+}
+
 // ImageInquiry represents the response from an image inquiry request.
 // It contains information about available images for a patent document.
 type ImageInquiry struct {
 	// DocumentInstances contains the list of available document types and their metadata
-	DocumentInstances []DocumentInstance
+	DocumentInstances []DocumentInstance `json:"document_instances"`
 }
 
 // DocumentInstance represents a single document type available for a patent.
@@ -109,21 +279,68 @@ type ImageInquiry struct {
 type DocumentInstance struct {
 	// Description is a human-readable description of the document type
 	// Examples: "Drawing", "FullDocument", "FirstPageClipping"
-	Description string
+	Description string `json:"description"`
 
 	// Link is the URL to retrieve this document instance
-	Link string
+	Link string `json:"link"`
 
 	// NumberOfPages is the total number of pages in this document instance
-	NumberOfPages int
+	NumberOfPages int `json:"number_of_pages"`
 
 	// Formats lists the available formats for this document instance
 	// Examples: ["pdf", "tiff"], ["application/pdf", "image/tiff"]
-	Formats []string
+	Formats []string `json:"formats"`
 
 	// DocType is the internal document type identifier
 	// Examples: "Drawing", "FullDocument"
-	DocType string
+	DocType string `json:"doc_type"`
+}
+
+// BestFormat returns the first of the preferred formats that the instance offers.
+//
+// Formats are compared by subtype, case-insensitively, so "pdf" matches
+// "application/pdf" and "tiff" matches "image/tiff" (and "tif"). The returned string
+// is the entry of Formats, which can be passed as format to GetImageByLink.
+// Returns false if none of the preferred formats is offered.
+//
+// Example:
+//
+//	format, ok := instance.BestFormat("pdf", "tiff")
+func (d DocumentInstance) BestFormat(preferred ...string) (string, bool) {
+	for _, want := range preferred {
+		want = formatSubtype(want)
+		for _, offered := range d.Formats {
+			if formatSubtype(offered) == want {
+				return offered, true
+			}
+		}
+	}
+	return "", false
+}
+
+// SupportsPDF reports whether the instance is offered as PDF.
+func (d DocumentInstance) SupportsPDF() bool {
+	_, ok := d.BestFormat("pdf")
+	return ok
+}
+
+// SupportsTIFF reports whether the instance is offered as TIFF.
+func (d DocumentInstance) SupportsTIFF() bool {
+	_, ok := d.BestFormat("tiff")
+	return ok
+}
+
+// formatSubtype reduces a format or MIME type to its lowercase subtype
+// ("application/pdf" -> "pdf", "image/tif" -> "tiff").
+func formatSubtype(format string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if i := strings.LastIndex(format, "/"); i >= 0 {
+		format = format[i+1:]
+	}
+	if format == "tif" {
+		return "tiff"
+	}
+	return format
 }
 
 // UsageStats represents usage statistics from the EPO OPS Data Usage API.
@@ -285,6 +502,44 @@ func ParsePatentNumber(number string) PatentNumber {
 	return PatentNumber{}
 }
 
+// Docdb renders the patent number in DOCDB format ("CC.NNNN.KK", e.g. "EP.2400812.A1").
+// Returns an empty string if any component is missing (e.g. for a zero-value
+// PatentNumber returned by ParsePatentNumber on invalid input).
+func (p PatentNumber) Docdb() string {
+	if !p.valid() {
+		return ""
+	}
+	return p.Country + "." + p.Number + "." + p.Kind
+}
+
+// Epodoc renders the patent number in EPODOC format ("CCNNNNKK", e.g. "EP2400812A1").
+// Returns an empty string if any component is missing.
+func (p PatentNumber) Epodoc() string {
+	if !p.valid() {
+		return ""
+	}
+	return p.Country + p.Number + p.Kind
+}
+
+// Ref returns the number format and number string to request the patent with: DOCDB
+// ("EP.2400812.A1") if it has a kind code, EPODOC ("EP2400812") otherwise, as the *For
+// methods (e.g. GetBiblioFor) send it. Both are empty if country or number is missing.
+func (p PatentNumber) Ref() (format, number string) {
+	switch {
+	case p.Country == "" || p.Number == "":
+		return "", ""
+	case p.Kind == "":
+		return FormatEPODOC, p.Country + p.Number
+	default:
+		return FormatDocDB, p.Docdb()
+	}
+}
+
+// valid reports whether all components of the patent number are set.
+func (p PatentNumber) valid() bool {
+	return p.Country != "" && p.Number != "" && p.Kind != ""
+}
+
 // isLetter checks if a byte is a letter (A-Z or a-z)
 func isLetter(b byte) bool {
 	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z')
@@ -304,3 +559,145 @@ func hasDigit(s string) bool {
 	}
 	return false
 }
+
+// MaxSearchRangeSize is the largest number of results a single search request may return.
+// EPO rejects wider ranges with a generic error, so the client checks it up front.
+const MaxSearchRangeSize = 100
+
+// MaxSearchResults is the largest number of results EPO returns for one search query;
+// results beyond it cannot be retrieved, whatever the range.
+const MaxSearchResults = 2000
+
+// MaxRegisterSearchResults is the largest number of results SearchRegisterAll retrieves
+// for one register query. The register service reports larger total counts, but its
+// retrieval ceiling matches the published-data search.
+const MaxRegisterSearchResults = 2000
+
+// DefaultSearchRange is the range used when a search method gets no range ("1-25").
+var DefaultSearchRange = SearchRange{Begin: 1, End: 25}
+
+// SearchRange is the 1-based, inclusive result window of a search request.
+//
+// Build one with NewSearchRange or ParseSearchRange and pass it to a search method
+// with WithRange:
+//
+//	r, err := ops.NewSearchRange(26, 50)
+//	results, err := client.Search(ctx, "ti=battery", "", ops.WithRange(r))
+type SearchRange struct {
+	Begin int
+	End   int
+}
+
+// NewSearchRange creates a search range from begin to end (inclusive).
+//
+// Returns a ValidationError if begin < 1, end < begin, or the range spans
+// more than MaxSearchRangeSize results.
+func NewSearchRange(begin, end int) (SearchRange, error) {
+	r := SearchRange{Begin: begin, End: end}
+	if err := r.Validate(); err != nil {
+		return SearchRange{}, err
+	}
+	return r, nil
+}
+
+// ParseSearchRange parses a range string such as "1-25" (the format taken by the
+// search methods' range argument). A single number "n" is read as "n-n".
+// An empty string returns DefaultSearchRange.
+func ParseSearchRange(s string) (SearchRange, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultSearchRange, nil
+	}
+
+	beginStr, endStr, found := strings.Cut(s, "-")
+	if !found {
+		endStr = beginStr
+	}
+	begin, errBegin := strconv.Atoi(strings.TrimSpace(beginStr))
+	end, errEnd := strconv.Atoi(strings.TrimSpace(endStr))
+	if errBegin != nil || errEnd != nil {
+		return SearchRange{}, &ValidationError{
+			Field:   "range",
+			Format:  "begin-end",
+			Value:   s,
+			Message: "must be in format 'begin-end' (e.g., '1-25')",
+		}
+	}
+	return NewSearchRange(begin, end)
+}
+
+// String returns the range in the "begin-end" format sent to EPO.
+func (r SearchRange) String() string {
+	return strconv.Itoa(r.Begin) + "-" + strconv.Itoa(r.End)
+}
+
+// Size returns the number of results covered by the range.
+func (r SearchRange) Size() int {
+	return r.End - r.Begin + 1
+}
+
+// nextSearchRange returns the MaxSearchRangeSize range following a page that ended
+// at end, limited to total results and the retrieval ceiling. It reports false when
+// no results are left to retrieve.
+func nextSearchRange(end, total, ceiling int) (SearchRange, bool) {
+	limit := min(total, ceiling)
+	begin := end + 1
+	if begin > limit {
+		return SearchRange{}, false
+	}
+	return SearchRange{Begin: begin, End: min(begin+MaxSearchRangeSize-1, limit)}, true
+}
+
+// Validate checks that the range starts at 1 or later, is not inverted and spans
+// at most MaxSearchRangeSize results.
+func (r SearchRange) Validate() error {
+	var message string
+	switch {
+	case r.Begin < 1:
+		message = "begin must be at least 1"
+	case r.End < r.Begin:
+		message = "end must not be before begin"
+	case r.Size() > MaxSearchRangeSize:
+		message = fmt.Sprintf("must span at most %d results", MaxSearchRangeSize)
+	default:
+		return nil
+	}
+	return &ValidationError{
+		Field:   "range",
+		Format:  "begin-end",
+		Value:   r.String(),
+		Message: message,
+	}
+}
+
+// SearchSort orders the results of a published-data search by one field.
+//
+// Pass it to Search, SearchAll or SearchWithConstituent* with WithSort:
+//
+//	results, err := client.Search(ctx, "ti=battery", "1-100",
+//	    ops.WithSort(ops.SearchSort{Field: "pd", Descending: true}))
+type SearchSort struct {
+	Field      string // CQL field of the published-data search (e.g., "pd")
+	Descending bool   // Sort from the highest to the lowest value
+}
+
+// String returns the sort key in CQL sortby syntax (e.g., "pd/sort.descending").
+func (s SearchSort) String() string {
+	if s.Descending {
+		return s.Field + "/sort.descending"
+	}
+	return s.Field + "/sort.ascending"
+}
+
+// Validate checks that the sort field is a field of the published-data search.
+func (s SearchSort) Validate() error {
+	if slices.Contains(cql.FieldsForEndpoint(cql.EndpointSearch), s.Field) {
+		return nil
+	}
+	return &ValidationError{
+		Field:   "sort",
+		Format:  "CQL field of the published-data search",
+		Value:   s.Field,
+		Message: "must be one of " + strings.Join(cql.FieldsForEndpoint(cql.EndpointSearch), ", "),
+	}
+}
@@ -1,6 +1,9 @@
 package epo_ops
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestParsePatentNumber(t *testing.T) {
 	tests := []struct {
@@ -133,3 +136,145 @@ func TestParsePatentNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestPatentNumberFormats(t *testing.T) {
+	parsed := ParsePatentNumber("EP2400812A1")
+	if got := parsed.Docdb(); got != "EP.2400812.A1" {
+		t.Errorf("Docdb: got %q, want %q", got, "EP.2400812.A1")
+	}
+	if got := parsed.Epodoc(); got != "EP2400812A1" {
+		t.Errorf("Epodoc: got %q, want %q", got, "EP2400812A1")
+	}
+
+	// Round trip: parse -> Docdb -> NormalizeToDocdb must be stable
+	normalized, err := NormalizeToDocdb(parsed.Docdb())
+	if err != nil {
+		t.Fatalf("NormalizeToDocdb failed: %v", err)
+	}
+	if normalized != parsed.Docdb() {
+		t.Errorf("NormalizeToDocdb: got %q, want %q", normalized, parsed.Docdb())
+	}
+
+	// Invalid input yields a zero-value PatentNumber, which renders as empty
+	for _, p := range []PatentNumber{{}, ParsePatentNumber("DE123"), {Country: "EP", Number: "2400812"}} {
+		if got := p.Docdb(); got != "" {
+			t.Errorf("Docdb(%+v): got %q, want empty", p, got)
+		}
+		if got := p.Epodoc(); got != "" {
+			t.Errorf("Epodoc(%+v): got %q, want empty", p, got)
+		}
+	}
+
+	// Ref falls back to EPODOC without a kind code
+	for _, tt := range []struct {
+		pn             PatentNumber
+		format, number string
+	}{
+		{parsed, FormatDocDB, "EP.2400812.A1"},
+		{PatentNumber{Country: "US", Number: "2012057518"}, FormatEPODOC, "US2012057518"},
+		{PatentNumber{Number: "2400812", Kind: "A1"}, "", ""},
+	} {
+		if format, number := tt.pn.Ref(); format != tt.format || number != tt.number {
+			t.Errorf("Ref(%+v): got %q %q, want %q %q", tt.pn, format, number, tt.format, tt.number)
+		}
+	}
+}
+
+func TestSearchRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    SearchRange
+		wantErr bool
+	}{
+		{name: "Default", input: "", want: DefaultSearchRange},
+		{name: "First page", input: "1-25", want: SearchRange{Begin: 1, End: 25}},
+		{name: "Maximum span", input: "101-200", want: SearchRange{Begin: 101, End: 200}},
+		{name: "Single result", input: "7", want: SearchRange{Begin: 7, End: 7}},
+		{name: "Spaces", input: " 26 - 50 ", want: SearchRange{Begin: 26, End: 50}},
+		{name: "Span over 100", input: "1-101", wantErr: true},
+		{name: "Inverted", input: "50-26", wantErr: true},
+		{name: "Begin zero", input: "0-25", wantErr: true},
+		{name: "Not a number", input: "first-last", wantErr: true},
+		{name: "Too many parts", input: "1-2-3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearchRange(tt.input)
+			if tt.wantErr {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("ParseSearchRange(%q): expected ValidationError, got %v", tt.input, err)
+				}
+				if validationErr.Field != "range" {
+					t.Errorf("Field: got %q, want %q", validationErr.Field, "range")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSearchRange(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSearchRange(%q): got %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	r, err := NewSearchRange(26, 50)
+	if err != nil {
+		t.Fatalf("NewSearchRange failed: %v", err)
+	}
+	if r.String() != "26-50" || r.Size() != 25 {
+		t.Errorf("NewSearchRange(26, 50): got %q (size %d)", r.String(), r.Size())
+	}
+	if _, err := NewSearchRange(1, 1+MaxSearchRangeSize); err == nil {
+		t.Error("NewSearchRange: expected error for span over MaxSearchRangeSize")
+	}
+	if _, err := NewSearchRange(10, 9); err == nil {
+		t.Error("NewSearchRange: expected error for inverted range")
+	}
+}
+
+func TestDocumentInstanceFormats(t *testing.T) {
+	both := DocumentInstance{Formats: []string{"application/pdf", "image/tiff"}}
+	tiffOnly := DocumentInstance{Formats: []string{"image/tiff"}}
+	shortNames := DocumentInstance{Formats: []string{"PDF"}}
+	none := DocumentInstance{}
+
+	tests := []struct {
+		name      string
+		instance  DocumentInstance
+		preferred []string
+		want      string
+		wantOK    bool
+	}{
+		{"First preference offered", both, []string{"pdf", "tiff"}, "application/pdf", true},
+		{"Order of preference", both, []string{"tiff", "pdf"}, "image/tiff", true},
+		{"Fallback to second preference", tiffOnly, []string{"pdf", "tiff"}, "image/tiff", true},
+		{"MIME type preference", tiffOnly, []string{"image/tiff"}, "image/tiff", true},
+		{"TIF alias", tiffOnly, []string{"tif"}, "image/tiff", true},
+		{"Case-insensitive short names", shortNames, []string{"application/pdf"}, "PDF", true},
+		{"Not offered", tiffOnly, []string{"pdf", "png"}, "", false},
+		{"No formats listed", none, []string{"pdf"}, "", false},
+		{"No preference", both, nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.instance.BestFormat(tt.preferred...)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("BestFormat(%v) = (%q, %v), want (%q, %v)", tt.preferred, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if !both.SupportsPDF() || !both.SupportsTIFF() {
+		t.Error("Expected PDF and TIFF support for both formats")
+	}
+	if tiffOnly.SupportsPDF() || !tiffOnly.SupportsTIFF() {
+		t.Error("Expected TIFF-only support")
+	}
+	if !shortNames.SupportsPDF() || none.SupportsPDF() || none.SupportsTIFF() {
+		t.Error("Unexpected support for short names or empty format list")
+	}
+}
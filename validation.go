@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/patent-dev/epo-ops/cql"
+	"github.com/patent-dev/epo-ops/internal/dateutil"
 )
 
 // Regular expressions for patent number format validation
@@ -154,7 +158,8 @@ func ValidateFormat(format, number string) error {
 //   - 19990101 (January 1, 1999)
 //
 // Note: This only validates the format, not whether the date is valid
-// (e.g., it accepts 20231399 which is not a real date).
+// (e.g., it accepts 20231399 which is not a real date). Use ValidateDateSemantic
+// to also check the calendar date.
 // Empty string is accepted (date is optional in many API calls).
 func ValidateDate(date string) error {
 	if date == "" {
@@ -172,6 +177,32 @@ func ValidateDate(date string) error {
 	return nil
 }
 
+// ValidateDateSemantic validates a date string in YYYYMMDD format and checks that
+// it is a real calendar date.
+//
+// In addition to the format check of ValidateDate, it verifies that:
+//   - the month is between 01 and 12
+//   - the day exists in that month (February 29 only in leap years)
+//   - the year is between 1790 and two years from now
+//
+// Empty string is accepted (date is optional in many API calls).
+// Use ValidateDate for a format-only check.
+func ValidateDateSemantic(date string) error {
+	if err := ValidateDate(date); err != nil || date == "" {
+		return err
+	}
+
+	if err := dateutil.CheckYYYYMMDD(date); err != nil {
+		return &ValidationError{
+			Field:   "date",
+			Value:   date,
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
 // ValidateRefType validates a reference type parameter.
 //
 // Valid reference types:
@@ -220,19 +251,7 @@ func NormalizeToDocdb(number string) (string, error) {
 		}
 	}
 
-	// Remove common whitespace/formatting characters
-	// This allows inputs like "EP 1000000 B1" or "EP-1000000-B1"
-	var cleaned strings.Builder
-	cleaned.Grow(len(number)) // Pre-allocate capacity
-	for i := 0; i < len(number); i++ {
-		c := number[i]
-		// Skip spaces, tabs, hyphens, and slashes
-		if c != ' ' && c != '\t' && c != '-' && c != '/' {
-			cleaned.WriteByte(c)
-		}
-	}
-
-	cleanedStr := cleaned.String()
+	cleanedStr := stripNumberSeparators(number)
 	if cleanedStr == "" {
 		return "", &ValidationError{
 			Field:   "number",
@@ -259,8 +278,9 @@ func NormalizeToDocdb(number string) (string, error) {
 	// Try to parse as EPODOC format
 	parsed := ParsePatentNumber(cleanedStr)
 
-	// Check if parsing was successful
-	if parsed.Country == "" || parsed.Number == "" || parsed.Kind == "" {
+	// Convert to DOCDB format (empty if parsing failed)
+	docdb := parsed.Docdb()
+	if docdb == "" {
 		return "", &ValidationError{
 			Field:   "number",
 			Value:   number,
@@ -268,9 +288,6 @@ func NormalizeToDocdb(number string) (string, error) {
 		}
 	}
 
-	// Convert to DOCDB format
-	docdb := parsed.Country + "." + parsed.Number + "." + parsed.Kind
-
 	// Final validation of the generated DOCDB format
 	if err := ValidateDocdbFormat(docdb); err != nil {
 		return "", &ValidationError{
@@ -283,6 +300,149 @@ func NormalizeToDocdb(number string) (string, error) {
 	return docdb, nil
 }
 
+// NormalizeToEpodoc converts a patent number to EPODOC format (CCnumber[KC]).
+//
+// This is the counterpart of NormalizeToDocdb for endpoints that require EPODOC
+// numbers, such as the register, procedural steps and UNIP services.
+//
+// Supported input formats:
+//   - EPODOC format: "EP1000000B1", "EP1000000" (returns unchanged if valid)
+//   - DOCDB format: "EP.1000000.B1" (dots removed)
+//   - With spaces, hyphens or slashes: "EP 1000000 B1", "EP-1000000-B1"
+//
+// Examples:
+//   - "EP.2884620.A2" → "EP2884620A2"
+//   - "EP 2884620 A2" → "EP2884620A2"
+//   - "EP2884620" → "EP2884620" (kind code is optional in EPODOC)
+//
+// Returns a ValidationError if the input is empty or cannot be normalized to a
+// valid EPODOC number.
+func NormalizeToEpodoc(number string) (string, error) {
+	if number == "" {
+		return "", &ValidationError{
+			Field:   "number",
+			Value:   number,
+			Message: "patent number cannot be empty",
+		}
+	}
+
+	cleanedStr := stripNumberSeparators(number)
+	if cleanedStr == "" {
+		return "", &ValidationError{
+			Field:   "number",
+			Value:   number,
+			Message: "patent number contains only whitespace or separators",
+		}
+	}
+
+	// DOCDB input: validate, then drop the dots
+	if len(cleanedStr) > 4 && cleanedStr[2] == '.' {
+		if err := ValidateDocdbFormat(cleanedStr); err != nil {
+			return "", &ValidationError{
+				Field:   "number",
+				Value:   number,
+				Message: "invalid DOCDB format: " + err.Error(),
+			}
+		}
+		cleanedStr = strings.ReplaceAll(cleanedStr, ".", "")
+	}
+
+	if err := ValidateEpodocFormat(cleanedStr); err != nil {
+		return "", &ValidationError{
+			Field:   "number",
+			Value:   number,
+			Message: "unable to normalize patent number: " + err.Error(),
+		}
+	}
+
+	return cleanedStr, nil
+}
+
+// NormalizeToFormat converts a patent number to the given number format.
+//
+// Parameters:
+//   - number: Patent number in DOCDB, EPODOC or loosely formatted input
+//   - targetFormat: One of FormatDocDB, FormatEPODOC or FormatOriginal
+//
+// DOCDB and EPODOC targets dispatch to NormalizeToDocdb and NormalizeToEpodoc.
+// The original format has no canonical form, so the number is only trimmed
+// and checked with ValidateOriginalFormat.
+//
+// Example:
+//
+//	number, err := ops.NormalizeToFormat("EP 1000000 B1", ops.FormatEPODOC) // "EP1000000B1"
+func NormalizeToFormat(number, targetFormat string) (string, error) {
+	switch targetFormat {
+	case FormatDocDB:
+		return NormalizeToDocdb(number)
+	case FormatEPODOC:
+		return NormalizeToEpodoc(number)
+	case FormatOriginal:
+		trimmed := strings.TrimSpace(number)
+		if err := ValidateOriginalFormat(trimmed); err != nil {
+			return "", err
+		}
+		return trimmed, nil
+	default:
+		return "", &ValidationError{
+			Field:   "format",
+			Value:   targetFormat,
+			Message: "must be 'docdb', 'epodoc', or 'original'",
+		}
+	}
+}
+
+// NormalizeNumbers converts a batch of patent numbers to the given number format.
+//
+// Each input is normalized with NormalizeToFormat. Inputs that cannot be normalized
+// do not abort the batch; their errors are collected in failures, keyed by the
+// index of the input, so callers can report the offending rows.
+//
+// Parameters:
+//   - inputs: Patent numbers in any format accepted by NormalizeToFormat
+//   - targetFormat: One of FormatDocDB, FormatEPODOC or FormatOriginal
+//
+// Returns the successfully normalized numbers in input order (without the failed
+// ones) and a map of input index to error. failures is nil when every input is valid.
+//
+// Example:
+//
+//	numbers, failures := ops.NormalizeNumbers(rows, ops.FormatDocDB)
+//	for i, err := range failures {
+//	    log.Printf("row %d (%q): %v", i+1, rows[i], err)
+//	}
+//	biblios, err := client.GetBiblioMultiple(ctx, ops.RefTypePublication, ops.FormatDocDB, numbers)
+func NormalizeNumbers(inputs []string, targetFormat string) (normalized []string, failures map[int]error) {
+	normalized = make([]string, 0, len(inputs))
+	for i, input := range inputs {
+		number, err := NormalizeToFormat(input, targetFormat)
+		if err != nil {
+			if failures == nil {
+				failures = make(map[int]error)
+			}
+			failures[i] = err
+			continue
+		}
+		normalized = append(normalized, number)
+	}
+	return normalized, failures
+}
+
+// stripNumberSeparators removes whitespace and formatting characters from a patent number.
+// This allows inputs like "EP 1000000 B1", "EP-1000000-B1" or "EP/1000000/B1".
+func stripNumberSeparators(number string) string {
+	var cleaned strings.Builder
+	cleaned.Grow(len(number)) // Pre-allocate capacity
+	for i := 0; i < len(number); i++ {
+		c := number[i]
+		// Skip spaces, tabs, hyphens, and slashes
+		if c != ' ' && c != '\t' && c != '-' && c != '/' {
+			cleaned.WriteByte(c)
+		}
+	}
+	return cleaned.String()
+}
+
 // ValidateBulkNumbers validates a slice of patent numbers for bulk operations.
 // This helper reduces code duplication across GetXMultiple methods.
 //
@@ -319,3 +479,23 @@ func ValidateBulkNumbers(numbers []string, format string) error {
 
 	return nil
 }
+
+// validateQueryLength checks that a search query is at most cql.MaxQueryLength
+// characters long, the limit EPO applies to CQL queries.
+func validateQueryLength(query string) error {
+	n := utf8.RuneCountInString(query)
+	if n <= cql.MaxQueryLength {
+		return nil
+	}
+
+	// Long queries are shortened so the error stays readable
+	value := query
+	if len(value) > 50 {
+		value = strings.ToValidUTF8(value[:50], "") + "..."
+	}
+	return &ValidationError{
+		Field:   "query",
+		Value:   value,
+		Message: fmt.Sprintf("must be at most %d characters (EPO's CQL query limit), got %d", cql.MaxQueryLength, n),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -194,6 +195,44 @@ func TestValidateDate(t *testing.T) {
 	}
 }
 
+func TestValidateDateSemantic(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      string
+		wantError bool
+	}{
+		{"Valid date", "20231015", false},
+		{"Empty date (optional)", "", false},
+		{"Feb 29 in leap year", "20240229", false},
+		{"Feb 29 in century leap year", "20000229", false},
+		{"Feb 29 in non-leap year", "20230229", true},
+		{"Feb 29 in non-leap century", "21000229", true},
+		{"Invalid month", "20231399", true},
+		{"Day 31 in April", "20230431", true},
+		{"Year before 1790", "17001231", true},
+		{"Format error", "2023-10-15", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDateSemantic(tt.date)
+			if (err != nil) != tt.wantError {
+				t.Errorf("ValidateDateSemantic(%q) error = %v, wantError %v", tt.date, err, tt.wantError)
+			}
+
+			if err != nil {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Fatalf("Expected ValidationError, got %T", err)
+				}
+				if valErr.Field != "date" {
+					t.Errorf("Expected field 'date', got %q", valErr.Field)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateRefType(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -574,6 +613,171 @@ func TestNormalizeToDocdb_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestNormalizeToEpodoc(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantError bool
+		errorMsg  string // Partial match for error message
+	}{
+		// Already valid EPODOC format (should return unchanged)
+		{name: "EPODOC EP format", input: "EP2884620A2", want: "EP2884620A2"},
+		{name: "EPODOC US format", input: "US5551212A", want: "US5551212A"},
+		{name: "EPODOC WO format", input: "WO2023123456A1", want: "WO2023123456A1"},
+		{name: "EPODOC DE format single char kind", input: "DE123C", want: "DE123C"},
+		{name: "EPODOC without kind code", input: "EP2884620", want: "EP2884620"},
+
+		// DOCDB format conversions
+		{name: "DOCDB EP", input: "EP.2884620.A2", want: "EP2884620A2"},
+		{name: "DOCDB US", input: "US.5551212.A", want: "US5551212A"},
+		{name: "DOCDB WO", input: "WO.2023123456.A1", want: "WO2023123456A1"},
+
+		// With whitespace, hyphens and slashes (should be cleaned)
+		{name: "With spaces", input: "EP 2884620 A2", want: "EP2884620A2"},
+		{name: "With leading/trailing spaces", input: "  EP2884620A2  ", want: "EP2884620A2"},
+		{name: "DOCDB with spaces", input: "EP .2884620 .A2", want: "EP2884620A2"},
+		{name: "With hyphens", input: "EP-2884620-A2", want: "EP2884620A2"},
+		{name: "With slashes", input: "EP/2884620/A2", want: "EP2884620A2"},
+		{name: "Mixed separators", input: "EP 2884620-A2", want: "EP2884620A2"},
+		{name: "Tab character", input: "EP\t1000000\tA1", want: "EP1000000A1"},
+
+		// Error cases
+		{name: "Empty string", input: "", wantError: true, errorMsg: "cannot be empty"},
+		{name: "Only whitespace", input: "   ", wantError: true, errorMsg: "only whitespace"},
+		{name: "Only separators", input: "---", wantError: true, errorMsg: "only whitespace"},
+		{name: "Missing country code", input: "2884620A2", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Single char country", input: "E2884620A2", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Invalid DOCDB format", input: "EP.2884620.A23", wantError: true, errorMsg: "invalid DOCDB format"},
+		{name: "Lowercase country code", input: "ep2884620A2", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Letters in number", input: "EP28A4620A2", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Invalid kind code format", input: "EP2884620A23", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Only country code", input: "EP", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Missing number portion", input: "EPA2", wantError: true, errorMsg: "unable to normalize"},
+		{name: "Newline character", input: "EP\n1000000\nA1", wantError: true, errorMsg: "unable to normalize"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeToEpodoc(tt.input)
+
+			if (err != nil) != tt.wantError {
+				t.Errorf("NormalizeToEpodoc(%q) error = %v, wantError %v", tt.input, err, tt.wantError)
+				return
+			}
+
+			if tt.wantError {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Errorf("Expected ValidationError, got %T", err)
+				}
+				if tt.errorMsg != "" && !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("NormalizeToEpodoc(%q) error = %q, want error containing %q", tt.input, err.Error(), tt.errorMsg)
+				}
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("NormalizeToEpodoc(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			// Verify result is valid EPODOC format and normalization is idempotent
+			if err := ValidateEpodocFormat(got); err != nil {
+				t.Errorf("NormalizeToEpodoc(%q) produced invalid EPODOC format %q: %v", tt.input, got, err)
+			}
+			if again, err := NormalizeToEpodoc(got); err != nil || again != got {
+				t.Errorf("Not idempotent: %q -> %q (err: %v)", got, again, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeToFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		format    string
+		want      string
+		wantError bool
+	}{
+		{"To DOCDB", "EP 1000000 B1", FormatDocDB, "EP.1000000.B1", false},
+		{"To EPODOC", "EP.1000000.B1", FormatEPODOC, "EP1000000B1", false},
+		{"To original", "  EP 1000000 B1 ", FormatOriginal, "EP 1000000 B1", false},
+		{"Invalid number for DOCDB", "EP1000000", FormatDocDB, "", true},
+		{"Empty original", "   ", FormatOriginal, "", true},
+		{"Unknown format", "EP1000000B1", "invalid", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeToFormat(tt.input, tt.format)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("NormalizeToFormat(%q, %q) error = %v, wantError %v", tt.input, tt.format, err, tt.wantError)
+			}
+			if err != nil {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Errorf("Expected ValidationError, got %T", err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeToFormat(%q, %q) = %q, want %q", tt.input, tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	inputs := []string{
+		"EP1000000B1",
+		"not a number",
+		"EP 2884620 A2",
+		"",
+		"US.5551212.A",
+		"EP2884620", // no kind code: invalid for DOCDB
+	}
+
+	normalized, failures := NormalizeNumbers(inputs, FormatDocDB)
+
+	want := []string{"EP.1000000.B1", "EP.2884620.A2", "US.5551212.A"}
+	if !reflect.DeepEqual(normalized, want) {
+		t.Errorf("normalized = %v, want %v", normalized, want)
+	}
+
+	if len(failures) != 3 {
+		t.Fatalf("Expected 3 failures, got %d: %v", len(failures), failures)
+	}
+	for _, index := range []int{1, 3, 5} {
+		err, ok := failures[index]
+		if !ok {
+			t.Errorf("Expected failure for input %d (%q)", index, inputs[index])
+			continue
+		}
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Errorf("Failure %d: expected ValidationError, got %T", index, err)
+		}
+	}
+
+	t.Run("All valid", func(t *testing.T) {
+		normalized, failures := NormalizeNumbers([]string{"EP.1000000.B1", "EP 2884620"}, FormatEPODOC)
+		if failures != nil {
+			t.Errorf("Expected nil failures, got %v", failures)
+		}
+		if !reflect.DeepEqual(normalized, []string{"EP1000000B1", "EP2884620"}) {
+			t.Errorf("Unexpected normalized numbers: %v", normalized)
+		}
+	})
+
+	t.Run("Invalid target format", func(t *testing.T) {
+		normalized, failures := NormalizeNumbers([]string{"EP1000000B1", "EP2884620A2"}, "invalid")
+		if len(normalized) != 0 || len(failures) != 2 {
+			t.Errorf("Expected every input to fail, got %v / %v", normalized, failures)
+		}
+	})
+}
+
 func TestValidateBulkNumbers(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1,12 +1,22 @@
 package epo_ops
 
 import (
+	"bytes"
 	_ "embed"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/patent-dev/epo-ops/internal/dateutil"
 )
 
 // Embed XSD schemas into the binary at compile time
@@ -28,429 +38,1548 @@ var opsXSD string
 //go:embed resources/CPCSchema.xsd
 var cpcSchemaXSD string
 
+// embeddedXSDs maps schema names to the embedded schemas, in the order ListEmbeddedXSDs
+// returns them.
+var embeddedXSDs = []struct {
+	name    string
+	content *string
+}{
+	{"exchange-documents", &exchangeDocumentsXSD},
+	{"fulltext-documents", &fulltextDocumentsXSD},
+	{"ops_legal", &opsLegalXSD},
+	{"ops", &opsXSD},
+	{"cpc", &cpcSchemaXSD},
+}
+
 // GetEmbeddedXSD returns the embedded XSD schema content by name.
-// This allows users to access schemas for custom validation if needed.
+// This allows users to access schemas for custom validation if needed;
+// ValidateAgainstSchema performs a structural check against them.
 //
 // Available schemas: "exchange-documents", "fulltext-documents", "ops_legal", "ops", "cpc"
+// (see ListEmbeddedXSDs for their versions)
 func GetEmbeddedXSD(name string) (string, bool) {
-	schemas := map[string]string{
-		"exchange-documents": exchangeDocumentsXSD,
-		"fulltext-documents": fulltextDocumentsXSD,
-		"ops_legal":          opsLegalXSD,
-		"ops":                opsXSD,
-		"cpc":                cpcSchemaXSD,
+	for _, schema := range embeddedXSDs {
+		if schema.name == name {
+			return *schema.content, true
+		}
+	}
+	return "", false
+}
+
+// XSDInfo describes an embedded XSD schema.
+type XSDInfo struct {
+	Name            string // Name for GetEmbeddedXSD and ValidateAgainstSchema, e.g. "exchange-documents"
+	Version         string // Schema version, e.g. "2.5.7"; empty if the schema does not declare one
+	TargetNamespace string // e.g. "http://www.epo.org/exchange"
+}
+
+// ListEmbeddedXSDs returns the schemas embedded in the library with the version and
+// target namespace declared in their headers.
+//
+// The version is taken from the version attribute of the schema element or, failing
+// that, from a "Version x.y" line in the comments at the top of the schema. The
+// bundled schemas change when EPO revises the API, so the version tells which
+// revision responses are validated against.
+func ListEmbeddedXSDs() []XSDInfo {
+	infos := make([]XSDInfo, 0, len(embeddedXSDs))
+	for _, schema := range embeddedXSDs {
+		info := parseXSDHeader(*schema.content)
+		info.Name = schema.name
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// xsdCommentVersion matches a version in a schema header comment (e.g., "* Version 1.7").
+var xsdCommentVersion = regexp.MustCompile(`(?m)^[\s*]*Version\s+v?(\d+(?:\.\d+)*)\s*$`)
+
+// parseXSDHeader reads the version and target namespace of a schema from its schema
+// element and the comments before its first declaration.
+func parseXSDHeader(content string) XSDInfo {
+	var info XSDInfo
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	inSchema := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return info
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if inSchema {
+				return info
+			}
+			inSchema = true
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "targetNamespace":
+					info.TargetNamespace = attr.Value
+				case "version":
+					info.Version = attr.Value
+				}
+			}
+		case xml.Comment:
+			if m := xsdCommentVersion.FindSubmatch(t); m != nil && info.Version == "" {
+				info.Version = string(m[1])
+			}
+		}
 	}
-	schema, ok := schemas[name]
-	return schema, ok
 }
 
 // XML Parsing Structs and Functions
 
 // AbstractData represents parsed patent abstract
+//
+// Language and Text hold the preferred abstract: English if available, otherwise
+// the first one in the document. All abstracts are available in Texts.
 type AbstractData struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentNumber string
-	Country      string
-	DocNumber    string
-	Kind         string
-	Language     string
-	Text         string
+	XMLName      xml.Name          `xml:"world-patent-data" json:"-"`
+	PatentNumber string            `json:"patent_number"`
+	Country      string            `json:"country"`
+	DocNumber    string            `json:"doc_number"`
+	Kind         string            `json:"kind"`
+	Language     string            `json:"language"`
+	Text         string            `json:"text"`
+	Texts        map[string]string `json:"texts"` // lang -> abstract text
+
+	// UnrepresentableFields lists the texts still containing content without a plain-text
+	// representation (e.g., "texts[en]"); set only with WithTextNormalization
+	UnrepresentableFields []string `json:"unrepresentable_fields,omitempty"`
+}
+
+// PlainText returns the preferred abstract as readable plain text, one paragraph per line.
+//
+// The tags of inline markup such as <sub>, <sup> and <b> are stripped while their
+// content is kept (e.g., "H2O" for H<sub>2</sub>O), and line breaks and indentation
+// within a paragraph are collapsed into single spaces. ParseAbstract already stores
+// Text and Texts this way; PlainText also normalizes abstracts built by hand.
+func (d *AbstractData) PlainText() string {
+	var lines []string
+	for _, line := range strings.Split(d.Text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // BiblioData represents parsed bibliographic data
 type BiblioData struct {
-	XMLName         xml.Name `xml:"world-patent-data"`
-	PatentNumber    string
-	Country         string
-	DocNumber       string
-	Kind            string
-	PublicationDate string
-	FamilyID        string
-	Titles          map[string]string // lang -> title
-	Applicants      []Party
-	Inventors       []Party
-	IPCClasses      []string
-	CPCClasses      []CPCClass
+	XMLName          xml.Name          `xml:"world-patent-data" json:"-"`
+	PatentNumber     string            `json:"patent_number"`
+	Country          string            `json:"country"`
+	DocNumber        string            `json:"doc_number"`
+	Kind             string            `json:"kind"`
+	PublicationDate  string            `json:"publication_date"`
+	FamilyID         string            `json:"family_id"`
+	Titles           map[string]string `json:"titles"` // lang -> title
+	Applicants       []Party           `json:"applicants"`
+	Inventors        []Party           `json:"inventors"`
+	IPCClasses       []string          `json:"ipc_classes"`
+	IPCClassesParsed []IPCClass        `json:"ipc_classes_parsed"` // IPCClasses parsed with ParseIPCSymbol (unparseable entries are skipped)
+	CPCClasses       []CPCClass        `json:"cpc_classes"`
+}
+
+// PublicationDateTime returns PublicationDate as midnight UTC. Partial dates (YYYYMM,
+// YYYY) default to the first month or day; an empty or malformed date returns a
+// ValidationError.
+func (d *BiblioData) PublicationDateTime() (time.Time, error) {
+	return parseEPODate("PublicationDate", d.PublicationDate)
+}
+
+// parseEPODate parses an EPO YYYYMMDD date (or a partial YYYYMM or YYYY date) of the
+// named field.
+func parseEPODate(field, date string) (time.Time, error) {
+	t, err := dateutil.ParseYYYYMMDD(strings.TrimSpace(date))
+	if err != nil {
+		return time.Time{}, &ValidationError{
+			Field:   field,
+			Value:   date,
+			Message: err.Error(),
+		}
+	}
+	return t, nil
+}
+
+// FullCycleStage is a single publication stage of a patent (e.g., the A1 or B1 publication)
+type FullCycleStage struct {
+	Country    string      `json:"country"`
+	DocNumber  string      `json:"doc_number"`
+	Kind       string      `json:"kind"`
+	Date       string      `json:"date"`        // Publication date (YYYYMMDD)
+	DocumentID string      `json:"document_id"` // DOCDB document-id (e.g., "EP.2400812.A1")
+	Biblio     *BiblioData `json:"biblio"`      // Bibliographic data as published at this stage
+}
+
+// FullCycleData represents the publication history of a patent from the full-cycle constituent
+type FullCycleData struct {
+	PatentNumber string           `json:"patent_number"` // Country and number without kind code (e.g., "EP2400812")
+	FamilyID     string           `json:"family_id"`
+	Stages       []FullCycleStage `json:"stages"` // Ordered by publication date
+}
+
+// ConstituentsData represents a published-data response with several constituents
+// (e.g., from GetConstituents). Sections that were not requested are nil.
+type ConstituentsData struct {
+	Biblio    *BiblioData    `json:"biblio"`
+	Abstract  *AbstractData  `json:"abstract"`
+	FullCycle *FullCycleData `json:"full_cycle"`
 }
 
 // ClaimsData represents parsed patent claims
+//
+// Language and Claims hold the first claims block of the document. EP grants
+// carry claims in all three official languages; every language is available
+// in ClaimsByLanguage.
 type ClaimsData struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentNumber string
-	Country      string
-	DocNumber    string
-	Kind         string
-	Language     string
-	Claims       []Claim
+	XMLName          xml.Name           `xml:"world-patent-data" json:"-"`
+	PatentNumber     string             `json:"patent_number"`
+	Country          string             `json:"country"`
+	DocNumber        string             `json:"doc_number"`
+	Kind             string             `json:"kind"`
+	Language         string             `json:"language"`
+	Claims           []Claim            `json:"claims"`
+	ClaimsByLanguage map[string][]Claim `json:"claims_by_language"` // lang -> claims (language codes as returned by EPO, e.g. "EN")
+
+	// UnrepresentableFields lists the claims still containing content without a
+	// plain-text representation (e.g., "claims[EN][3]"); set only with WithTextNormalization
+	UnrepresentableFields []string `json:"unrepresentable_fields,omitempty"`
+}
+
+// AllLanguages returns the languages in which claims are available, sorted alphabetically.
+func (d *ClaimsData) AllLanguages() []string {
+	languages := make([]string, 0, len(d.ClaimsByLanguage))
+	for lang := range d.ClaimsByLanguage {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// InLanguage returns a copy of the claims data with Language and Claims set to
+// the requested language (case-insensitive, e.g. "en" or "EN"). An exact match wins;
+// otherwise the first matching language in AllLanguages order is used.
+// Returns nil if the document has no claims in that language.
+func (d *ClaimsData) InLanguage(lang string) *ClaimsData {
+	language, ok := lang, false
+	if _, ok = d.ClaimsByLanguage[lang]; !ok {
+		for _, candidate := range d.AllLanguages() {
+			if strings.EqualFold(candidate, lang) {
+				language, ok = candidate, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	data := *d
+	data.Language = language
+	data.Claims = d.ClaimsByLanguage[language]
+	return &data
 }
 
 // Party represents an applicant or inventor
 type Party struct {
-	Name    string
-	Country string
+	Name    string `json:"name"`
+	Country string `json:"country"`
 }
 
 // CPCClass represents a Cooperative Patent Classification
 type CPCClass struct {
-	Section   string
-	Class     string
-	Subclass  string
-	MainGroup string
-	Subgroup  string
-	Full      string // Combined representation (e.g., "H04W 84/20")
+	Section   string `json:"section"`
+	Class     string `json:"class"`
+	Subclass  string `json:"subclass"`
+	MainGroup string `json:"main_group"`
+	Subgroup  string `json:"subgroup"`
+	Full      string `json:"full"`  // Combined representation (e.g., "H04W 84/20")
+	Title     string `json:"title"` // Class title; empty unless filled in by Client.EnrichCPCTitles
+}
+
+// IPCClass represents an International Patent Classification (IPCR) entry
+//
+// Besides the symbol, IPCR texts in bibliographic data carry the IPC version and
+// flags describing the classification; flags missing from the text are empty.
+type IPCClass struct {
+	Section    string `json:"section"`
+	Class      string `json:"class"`
+	Subclass   string `json:"subclass"`
+	MainGroup  string `json:"main_group"`
+	Subgroup   string `json:"subgroup"`
+	Version    string `json:"version"`     // IPC version (edition) date, YYYYMMDD (e.g., "20060101")
+	Level      string `json:"level"`       // Classification level: "A" (advanced), "C" (core), "S" (subclass)
+	Position   string `json:"position"`    // "F" (first) or "L" (later) position
+	Value      string `json:"value"`       // Classification value: "I" (inventive) or "N" (non-inventive)
+	ActionDate string `json:"action_date"` // Date the classification was assigned, YYYYMMDD
+	Status     string `json:"status"`      // Original or reclassified: "B", "O" or "R"
+	Source     string `json:"source"`      // Classification source: "H" (human), "M" (machine), "G" (generated), "D"
+	Office     string `json:"office"`      // Classifying office (e.g., "EP")
+	Full       string `json:"full"`        // Combined representation (e.g., "H04W 84/20")
 }
 
 // Claim represents a single patent claim
 type Claim struct {
-	Number int
-	Text   string
+	Number    int         `json:"number"`     // From the num or id attribute; the running index only when both are absent
+	Num       string      `json:"num"`        // num attribute of the claim element (e.g., "0003"); empty in text-only responses
+	ID        string      `json:"id"`         // id attribute of the claim element (e.g., "c-en-0003")
+	Text      string      `json:"text"`       // Full claim text, nested claim-text elements joined by newlines
+	Parts     []ClaimText `json:"parts"`      // Nested claim-text elements (e.g., the features of claim 1); nil for plain claims
+	DependsOn []int       `json:"depends_on"` // Earlier claims the text refers to, e.g. [1 2] for "according to claim 1 or 2"
+}
+
+// ClaimText is a nested claim-text element of a claim, such as a feature or a step.
+type ClaimText struct {
+	Text  string      `json:"text"`
+	Parts []ClaimText `json:"parts"`
 }
 
-// FamilyMember represents a single member of a patent family
+// FamilyMember represents a single member of a patent family.
+// Country, DocNumber, Kind and Date describe the primary publication (first docdb document-id);
+// Publications holds every publication document-id of the member (e.g., A1, A2, B1 stages).
 type FamilyMember struct {
-	FamilyID       string
-	Country        string
-	DocNumber      string
-	Kind           string
-	Date           string
-	ApplicationRef ApplicationReference
-	PriorityClaims []PriorityClaim
+	FamilyID       string                 `json:"family_id"`
+	Country        string                 `json:"country"`
+	DocNumber      string                 `json:"doc_number"`
+	Kind           string                 `json:"kind"`
+	Date           string                 `json:"date"`
+	Publications   []PublicationReference `json:"publications"`
+	ApplicationRef ApplicationReference   `json:"application_ref"`
+	PriorityClaims []PriorityClaim        `json:"priority_claims"`
+}
+
+// PublicationDate returns Date, the date of the primary publication, as midnight UTC.
+// Partial dates default to the first month or day, as in BiblioData.PublicationDateTime.
+func (m *FamilyMember) PublicationDate() (time.Time, error) {
+	return parseEPODate("Date", m.Date)
+}
+
+// PublicationReference represents a single publication document-id of a family member
+type PublicationReference struct {
+	Country   string `json:"country"`
+	DocNumber string `json:"doc_number"`
+	Kind      string `json:"kind"`
+	Date      string `json:"date"`
+	Type      string `json:"type"` // document-id-type (e.g., "docdb", "epodoc")
 }
 
 // ApplicationReference represents the application reference for a family member
 type ApplicationReference struct {
-	Country   string
-	DocNumber string
-	Kind      string
-	Date      string
-	DocID     string
+	Country   string `json:"country"`
+	DocNumber string `json:"doc_number"`
+	Kind      string `json:"kind"`
+	Date      string `json:"date"`
+	DocID     string `json:"doc_id"`
 }
 
 // PriorityClaim represents a priority claim for a family member
 type PriorityClaim struct {
-	Country   string
-	DocNumber string
-	Kind      string
-	Date      string
-	Sequence  string
-	Active    string
+	Country   string `json:"country"`
+	DocNumber string `json:"doc_number"`
+	Kind      string `json:"kind"`
+	Date      string `json:"date"`
+	Sequence  string `json:"sequence"`
+	Active    string `json:"active"`
 }
 
 // FamilyData represents parsed patent family data
 type FamilyData struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentNumber string
-	FamilyID     string
-	TotalCount   int
-	Legal        bool
-	Members      []FamilyMember
+	XMLName      xml.Name       `xml:"world-patent-data" json:"-"`
+	PatentNumber string         `json:"patent_number"`
+	FamilyID     string         `json:"family_id"`
+	TotalCount   int            `json:"total_count"`
+	Legal        bool           `json:"legal"`
+	Type         FamilyType     `json:"type"` // FamilyExtended for INPADOC responses, FamilySimple for equivalents
+	Members      []FamilyMember `json:"members"`
 }
 
-// LegalEvent represents a single legal event
-type LegalEvent struct {
-	Code        string
-	Description string
-	Influence   string
-	DateMigr    string
-	Fields      map[string]string
-}
+// DeduplicateByApplication returns one member per application: members with the same
+// application reference (country and doc-number) are merged, e.g. an EP A1 and an EP B1
+// listed as separate family members. The merged member keeps the primary publication of
+// the first member and collects the publications and priority claims of all of them,
+// without duplicates. Members without an application reference are returned as is.
+// Members keep their order of first appearance; d.Members is not modified.
+func (d *FamilyData) DeduplicateByApplication() []FamilyMember {
+	var members []FamilyMember
+	index := make(map[string]int)
+	for _, member := range d.Members {
+		app := member.ApplicationRef
+		if app.Country == "" || app.DocNumber == "" {
+			members = append(members, member)
+			continue
+		}
 
-// LegalData represents parsed legal event data
-type LegalData struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentNumber string
-	FamilyID     string
-	LegalEvents  []LegalEvent
-}
+		key := app.Country + app.DocNumber
+		i, ok := index[key]
+		if !ok {
+			// Copy the slices so merging never writes to d.Members
+			member.Publications = append([]PublicationReference(nil), member.Publications...)
+			member.PriorityClaims = append([]PriorityClaim(nil), member.PriorityClaims...)
+			index[key] = len(members)
+			members = append(members, member)
+			continue
+		}
 
-// Paragraph represents a description paragraph
-type Paragraph struct {
-	ID   string
-	Num  string
-	Text string
+		merged := &members[i]
+		for _, pub := range member.Publications {
+			if !slices.Contains(merged.Publications, pub) {
+				merged.Publications = append(merged.Publications, pub)
+			}
+		}
+		for _, claim := range member.PriorityClaims {
+			if !slices.Contains(merged.PriorityClaims, claim) {
+				merged.PriorityClaims = append(merged.PriorityClaims, claim)
+			}
+		}
+	}
+	return members
 }
 
-// DescriptionData represents parsed description data
-type DescriptionData struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentNumber string
-	Country      string
-	DocNumber    string
-	Kind         string
-	Language     string
-	Paragraphs   []Paragraph
+// Numbers returns the publication numbers of the members, in member order and without
+// duplicates. See PatentNumbers.
+func (d *FamilyData) Numbers() []PatentNumber {
+	var nums patentNumberSet
+	for _, member := range d.Members {
+		nums.add(member.Country, member.DocNumber, member.Kind)
+	}
+	return nums.list
 }
 
-// FulltextData represents complete fulltext document data
-type FulltextData struct {
-	XMLName     xml.Name `xml:"world-patent-data"`
-	Country     string
-	DocNumber   string
-	Kind        string
-	Language    string
-	Status      string
-	Biblio      *BiblioData
-	Abstract    *AbstractData
-	Description *DescriptionData
-	Claims      *ClaimsData
+// MembersForCountries returns the members whose publication country is one of countries,
+// compared case-insensitively (e.g., "us" matches US). Members keep their order; with no
+// countries, no members are returned.
+func (d *FamilyData) MembersForCountries(countries ...string) []FamilyMember {
+	var members []FamilyMember
+	for _, member := range d.Members {
+		for _, country := range countries {
+			if strings.EqualFold(member.Country, strings.TrimSpace(country)) {
+				members = append(members, member)
+				break
+			}
+		}
+	}
+	return members
 }
 
-// SearchResult represents a single search result
-type SearchResult struct {
-	System    string
-	FamilyID  string
-	Country   string
-	DocNumber string
-	Kind      string
-	Title     string
+// PriorityGraph maps each priority to the family members claiming it: the keys are
+// priority document-ids and the values member publication numbers, both as country,
+// number and kind (e.g., "US35782510P" -> ["US2011318412A1", "EP2400812A1"]).
+// Members keep their order of appearance. Members without priority claims (direct
+// filings) do not appear in the graph unless another member claims them.
+func (d *FamilyData) PriorityGraph() map[string][]string {
+	graph := make(map[string][]string)
+	for _, member := range d.Members {
+		number := member.Country + member.DocNumber + member.Kind
+		for _, claim := range member.PriorityClaims {
+			key := claim.Country + claim.DocNumber + claim.Kind
+			if !slices.Contains(graph[key], number) {
+				graph[key] = append(graph[key], number)
+			}
+		}
+	}
+	return graph
 }
 
-// SearchResultData represents search results with pagination
-type SearchResultData struct {
-	XMLName    xml.Name `xml:"world-patent-data"`
-	Query      string
-	TotalCount int
-	RangeBegin int
-	RangeEnd   int
-	Results    []SearchResult
+// RootPriorities returns the originating priorities of the family, one per priority
+// document-id, ordered by date. A priority is not a root if it is the application of a
+// member that itself claims an earlier priority (e.g., a US application claiming a US
+// provisional). Each root is the first claim of it in the family.
+func (d *FamilyData) RootPriorities() []PriorityClaim {
+	// Applications of members that claim priorities of their own
+	derived := make(map[string]bool)
+	for _, member := range d.Members {
+		app := member.ApplicationRef
+		if app.DocNumber == "" {
+			continue
+		}
+		for _, claim := range member.PriorityClaims {
+			if claim.Country != app.Country || claim.DocNumber != app.DocNumber {
+				derived[app.Country+app.DocNumber] = true
+				break
+			}
+		}
+	}
+
+	var roots []PriorityClaim
+	seen := make(map[string]bool)
+	for _, member := range d.Members {
+		for _, claim := range member.PriorityClaims {
+			key := claim.Country + claim.DocNumber + claim.Kind
+			if seen[key] || derived[claim.Country+claim.DocNumber] {
+				continue
+			}
+			seen[key] = true
+			roots = append(roots, claim)
+		}
+	}
+	slices.SortStableFunc(roots, func(a, b PriorityClaim) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	return roots
 }
 
-// EquivalentPatent represents an equivalent patent
-type EquivalentPatent struct {
-	Country   string
-	DocNumber string
-	Kind      string
+// Representative returns the member representing the family: the one with the earliest
+// priority date. A member's priority date is the earliest date of its priority claims or,
+// for a member without claims (an original filing), the date of its application. Ties
+// go to the member published first, then to the member listed first. Members without
+// any priority or application date are only chosen if no member has one.
+// Reports false if the family has no members.
+func (d *FamilyData) Representative() (FamilyMember, bool) {
+	priorityDate := func(m FamilyMember) string {
+		date := ""
+		for _, claim := range m.PriorityClaims {
+			if claim.Date != "" && (date == "" || claim.Date < date) {
+				date = claim.Date
+			}
+		}
+		if date == "" {
+			date = m.ApplicationRef.Date
+		}
+		return date
+	}
+
+	best := -1
+	var bestDate string
+	for i, member := range d.Members {
+		date := priorityDate(member)
+		switch {
+		case best < 0,
+			date != "" && (bestDate == "" || date < bestDate),
+			date == bestDate && member.Date != "" && (d.Members[best].Date == "" || member.Date < d.Members[best].Date):
+			best, bestDate = i, date
+		}
+	}
+	if best < 0 {
+		return FamilyMember{}, false
+	}
+	return d.Members[best], true
 }
 
-// EquivalentsData represents published equivalents inquiry results
-type EquivalentsData struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentNumber string
-	Equivalents  []EquivalentPatent
+// FamilyBiblioMember represents a family member together with its bibliographic data.
+// Biblio is nil when the response carries no exchange-document for the member.
+type FamilyBiblioMember struct {
+	FamilyMember
+	Biblio *BiblioData `json:"biblio"`
 }
 
-// Internal structs for XML unmarshaling
-type abstractXML struct {
-	XMLName          xml.Name `xml:"world-patent-data"`
-	ExchangeDocument struct {
-		Country   string `xml:"country,attr"`
-		DocNumber string `xml:"doc-number,attr"`
-		Kind      string `xml:"kind,attr"`
-		Abstract  struct {
-			Lang string `xml:"lang,attr"`
-			P    string `xml:"p"`
-		} `xml:"abstract"`
-	} `xml:"exchange-documents>exchange-document"`
+// FamilyBiblioData represents parsed patent family data with bibliographic data per member
+type FamilyBiblioData struct {
+	PatentNumber string               `json:"patent_number"`
+	FamilyID     string               `json:"family_id"`
+	TotalCount   int                  `json:"total_count"`
+	Members      []FamilyBiblioMember `json:"members"`
 }
 
-type biblioXML struct {
-	XMLName          xml.Name `xml:"world-patent-data"`
-	ExchangeDocument struct {
-		Country    string `xml:"country,attr"`
-		DocNumber  string `xml:"doc-number,attr"`
-		Kind       string `xml:"kind,attr"`
-		FamilyID   string `xml:"family-id,attr"`
-		BiblioData struct {
-			PublicationRef struct {
-				DocumentID []struct {
-					Type      string `xml:"document-id-type,attr"`
-					Country   string `xml:"country"`
-					DocNumber string `xml:"doc-number"`
-					Kind      string `xml:"kind"`
-					Date      string `xml:"date"`
-				} `xml:"document-id"`
-			} `xml:"publication-reference"`
-			InventionTitles []struct {
-				Lang string `xml:"lang,attr"`
-				Text string `xml:",chardata"`
-			} `xml:"invention-title"`
-			Parties struct {
-				Applicants []struct {
-					Sequence      string `xml:"sequence,attr"`
-					DataFormat    string `xml:"data-format,attr"`
-					ApplicantName struct {
-						Name string `xml:"name"`
-					} `xml:"applicant-name"`
-				} `xml:"applicants>applicant"`
-				Inventors []struct {
-					Sequence     string `xml:"sequence,attr"`
-					DataFormat   string `xml:"data-format,attr"`
-					InventorName struct {
-						Name string `xml:"name"`
-					} `xml:"inventor-name"`
-				} `xml:"inventors>inventor"`
-			} `xml:"parties"`
-			ClassificationsIPCR []struct {
-				Text string `xml:"text"`
-			} `xml:"classifications-ipcr>classification-ipcr"`
-			PatentClassifications []struct {
-				Section   string `xml:"section"`
-				Class     string `xml:"class"`
-				Subclass  string `xml:"subclass"`
-				MainGroup string `xml:"main-group"`
-				Subgroup  string `xml:"subgroup"`
-			} `xml:"patent-classifications>patent-classification"`
-		} `xml:"bibliographic-data"`
-	} `xml:"exchange-documents>exchange-document"`
+// FamilyLegalMember represents a family member together with its legal events.
+type FamilyLegalMember struct {
+	FamilyMember
+	LegalEvents []LegalEvent `json:"legal_events"`
 }
 
-type claimsXML struct {
-	XMLName           xml.Name `xml:"world-patent-data"`
-	FulltextDocuments struct {
-		FulltextDocument struct {
-			BiblioData struct {
-				PublicationRef struct {
-					DocumentID struct {
-						Country   string `xml:"country"`
-						DocNumber string `xml:"doc-number"`
-						Kind      string `xml:"kind"`
-					} `xml:"document-id"`
-				} `xml:"publication-reference"`
-			} `xml:"bibliographic-data"`
-			Claims struct {
-				Lang      string `xml:"lang,attr"`
-				ClaimList struct {
-					ClaimTexts []struct {
-						Text string `xml:",chardata"`
-					} `xml:"claim-text"`
-				} `xml:"claim"`
-			} `xml:"claims"`
-		} `xml:"fulltext-document"`
-	} `xml:"fulltext-documents"`
+// FamilyLegalData represents parsed patent family data with legal events per member
+type FamilyLegalData struct {
+	PatentNumber string              `json:"patent_number"`
+	FamilyID     string              `json:"family_id"`
+	TotalCount   int                 `json:"total_count"`
+	Members      []FamilyLegalMember `json:"members"`
 }
 
-// ParseAbstract parses abstract XML into structured data
-func ParseAbstract(xmlData string) (*AbstractData, error) {
-	var raw abstractXML
-	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
-		return nil, err
-	}
+// Legal statuses derived by FamilyLegalData.StatusSummary.
+const (
+	LegalStatusGranted   = "granted"   // Granted and in force
+	LegalStatusPending   = "pending"   // Application still under examination
+	LegalStatusLapsed    = "lapsed"    // Lapsed, expired or revoked
+	LegalStatusWithdrawn = "withdrawn" // Withdrawn, deemed withdrawn or refused
+)
 
-	data := &AbstractData{
-		Country:   raw.ExchangeDocument.Country,
-		DocNumber: raw.ExchangeDocument.DocNumber,
-		Kind:      raw.ExchangeDocument.Kind,
-		Language:  raw.ExchangeDocument.Abstract.Lang,
-		Text:      strings.TrimSpace(raw.ExchangeDocument.Abstract.P),
-	}
+// legalStatusRank orders the statuses of several members of one country,
+// the most alive first.
+var legalStatusRank = map[string]int{
+	LegalStatusGranted:   0,
+	LegalStatusPending:   1,
+	LegalStatusLapsed:    2,
+	LegalStatusWithdrawn: 3,
+}
 
-	// Construct patent number
-	if data.Country != "" && data.DocNumber != "" && data.Kind != "" {
-		data.PatentNumber = fmt.Sprintf("%s%s%s", data.Country, data.DocNumber, data.Kind)
-	}
+// legalStatusRestored marks events that undo a preceding lapse or withdrawal.
+const legalStatusRestored = "restored"
 
-	return data, nil
+// legalStatusCodes maps EP legal event codes to the status they establish.
+var legalStatusCodes = map[string]string{
+	"18D": LegalStatusWithdrawn, // Application deemed to be withdrawn
+	"18W": LegalStatusWithdrawn, // Application withdrawn
+	"18R": LegalStatusWithdrawn, // Application refused
+	"26N": LegalStatusGranted,   // No opposition filed within time limit
 }
 
-// ParseBiblio parses bibliographic XML into structured data
-func ParseBiblio(xmlData string) (*BiblioData, error) {
-	var raw biblioXML
-	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
-		return nil, err
-	}
+// legalStatusKeywords maps keywords of event descriptions to statuses, for the codes
+// of other offices. They are tried in order, so "REINSTATEMENT AFTER LAPSE" restores.
+var legalStatusKeywords = []struct {
+	status   string
+	keywords []string
+}{
+	{legalStatusRestored, []string{"RESTOR", "REINSTAT", "RE-ESTABLISH", "RESTITUTIO"}},
+	{LegalStatusWithdrawn, []string{"WITHDRAWN", "WITHDRAWAL", "REFUSED", "REFUSAL", "ABANDON"}},
+	{LegalStatusLapsed, []string{"LAPSE", "EXPIRED", "CEASED", "REVOKED", "REVOCATION", "NON-PAYMENT", "NONPAYMENT"}},
+	{LegalStatusGranted, []string{"PATENT GRANTED", "GRANT OF PATENT", "GRANT OF A PATENT", "NO OPPOSITION FILED"}},
+}
 
-	data := &BiblioData{
-		Country:   raw.ExchangeDocument.Country,
-		DocNumber: raw.ExchangeDocument.DocNumber,
-		Kind:      raw.ExchangeDocument.Kind,
-		FamilyID:  raw.ExchangeDocument.FamilyID,
-		Titles:    make(map[string]string),
-	}
+// legalStatusIgnored lists description parts of events that concern only some
+// designated states, an opposition or a priority rather than the member as a whole.
+var legalStatusIgnored = []string{"CONTRACTING STATE", "DESIGNAT", "OPPOSITION", "PRIORITY"}
 
-	// Construct patent number
-	if data.Country != "" && data.DocNumber != "" && data.Kind != "" {
-		data.PatentNumber = fmt.Sprintf("%s%s%s", data.Country, data.DocNumber, data.Kind)
+// legalEventStatus returns the status an event establishes, legalStatusRestored,
+// or "" if the event does not change the status.
+func legalEventStatus(event LegalEvent) string {
+	if status, ok := legalStatusCodes[strings.ToUpper(strings.TrimSpace(event.Code))]; ok {
+		return status
 	}
 
-	// Extract publication date from first docdb document-id
-	for _, docID := range raw.ExchangeDocument.BiblioData.PublicationRef.DocumentID {
-		if docID.Type == "docdb" && docID.Date != "" {
-			data.PublicationDate = docID.Date
-			break
-		}
+	desc := strings.ToUpper(event.Description)
+	if strings.Contains(desc, "NO OPPOSITION FILED") {
+		return LegalStatusGranted
 	}
-
-	// Extract titles (multilingual)
-	for _, title := range raw.ExchangeDocument.BiblioData.InventionTitles {
-		if title.Lang != "" && title.Text != "" {
-			data.Titles[title.Lang] = strings.TrimSpace(title.Text)
+	for _, ignored := range legalStatusIgnored {
+		if strings.Contains(desc, ignored) {
+			return ""
 		}
 	}
-
-	// Extract applicants (only epodoc format to avoid duplicates)
-	for _, applicant := range raw.ExchangeDocument.BiblioData.Parties.Applicants {
-		if applicant.DataFormat == "epodoc" && applicant.ApplicantName.Name != "" {
-			name := strings.TrimSpace(applicant.ApplicantName.Name)
-			// Extract country from name if present (format: "NAME [CC]")
-			country := ""
-			if idx := strings.LastIndex(name, "["); idx > 0 {
-				if idx2 := strings.Index(name[idx:], "]"); idx2 > 0 {
-					country = name[idx+1 : idx+idx2]
-					name = strings.TrimSpace(name[:idx])
-				}
+	for _, entry := range legalStatusKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(desc, keyword) {
+				return entry.status
 			}
-			data.Applicants = append(data.Applicants, Party{
-				Name:    name,
-				Country: country,
-			})
 		}
 	}
+	return ""
+}
 
-	// Extract inventors (only epodoc format)
-	for _, inventor := range raw.ExchangeDocument.BiblioData.Parties.Inventors {
-		if inventor.DataFormat == "epodoc" && inventor.InventorName.Name != "" {
-			name := strings.TrimSpace(inventor.InventorName.Name)
-			country := ""
-			if idx := strings.LastIndex(name, "["); idx > 0 {
-				if idx2 := strings.Index(name[idx:], "]"); idx2 > 0 {
-					country = name[idx+1 : idx+idx2]
-					name = strings.TrimSpace(name[:idx])
-				}
-			}
-			data.Inventors = append(data.Inventors, Party{
-				Name:    name,
-				Country: country,
-			})
+// LegalStatus derives the status of the member (one of the LegalStatus constants)
+// from its publications and legal events; see FamilyLegalData.StatusSummary.
+func (m *FamilyLegalMember) LegalStatus() string {
+	status := LegalStatusPending
+	kinds := []string{m.Kind}
+	for _, pub := range m.Publications {
+		kinds = append(kinds, pub.Kind)
+	}
+	for _, kind := range kinds {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(kind)), "B") {
+			status = LegalStatusGranted
 		}
 	}
 
-	// Extract IPC classifications
-	for _, ipc := range raw.ExchangeDocument.BiblioData.ClassificationsIPCR {
-		if ipc.Text != "" {
-			data.IPCClasses = append(data.IPCClasses, strings.TrimSpace(ipc.Text))
+	events := slices.Clone(m.LegalEvents)
+	slices.SortStableFunc(events, func(a, b LegalEvent) int {
+		return strings.Compare(a.DateMigr, b.DateMigr)
+	})
+
+	before := "" // status before the latest lapse or withdrawal
+	for _, event := range events {
+		switch eventStatus := legalEventStatus(event); eventStatus {
+		case "":
+		case legalStatusRestored:
+			if before != "" {
+				status, before = before, ""
+			}
+		case LegalStatusLapsed, LegalStatusWithdrawn:
+			if status == LegalStatusGranted || status == LegalStatusPending {
+				before = status
+			}
+			status = eventStatus
+		default:
+			status = eventStatus
 		}
 	}
+	return status
+}
 
-	// Extract CPC classifications
-	for _, cpc := range raw.ExchangeDocument.BiblioData.PatentClassifications {
-		class := CPCClass{
-			Section:   cpc.Section,
-			Class:     cpc.Class,
-			Subclass:  cpc.Subclass,
-			MainGroup: cpc.MainGroup,
-			Subgroup:  cpc.Subgroup,
+// StatusSummary maps the country of every member to its derived legal status
+// (LegalStatusGranted, LegalStatusPending, LegalStatusLapsed or LegalStatusWithdrawn),
+// e.g. as the headline of a freedom-to-operate check.
+//
+// The status is a heuristic over the INPADOC data, not a legal opinion:
+//   - A member starts as granted if one of its publications has a B kind code
+//     (e.g. EP B1, US B2), otherwise as pending.
+//   - Its legal events are then applied in date order (DateMigr). EP codes 18D, 18W
+//     and 18R make it withdrawn, 26N (no opposition filed) granted.
+//   - Events of other codes are classified by their description: "withdrawn",
+//     "withdrawal", "refused", "refusal" or "abandon" mean withdrawn; "lapse",
+//     "expired", "ceased", "revoked", "revocation" or "non-payment" lapsed;
+//     "patent granted", "grant of (a) patent" or "no opposition filed" granted.
+//     "Restoration", "reinstatement", "re-establishment" or "restitutio" undo the
+//     preceding lapse or withdrawal.
+//   - Events about designated or contracting states (e.g. EP PG25, lapse in one
+//     contracting state), oppositions and priorities are ignored, as are all others.
+//
+// Countries with several members (e.g. a withdrawn DE application and a granted
+// DE patent of a divisional) report the most alive status: granted, pending, lapsed, withdrawn.
+// Use FamilyLegalMember.LegalStatus for the status of each member.
+func (d *FamilyLegalData) StatusSummary() map[string]string {
+	summary := make(map[string]string)
+	for i := range d.Members {
+		member := &d.Members[i]
+		status := member.LegalStatus()
+		if current, ok := summary[member.Country]; !ok || legalStatusRank[status] < legalStatusRank[current] {
+			summary[member.Country] = status
 		}
-		// Build full representation
-		class.Full = fmt.Sprintf("%s%s%s %s/%s", class.Section, class.Class, class.Subclass, class.MainGroup, class.Subgroup)
-		data.CPCClasses = append(data.CPCClasses, class)
 	}
+	return summary
+}
 
-	return data, nil
+// LegalEvent represents a single legal event
+type LegalEvent struct {
+	Code        string            `json:"code"`
+	Description string            `json:"description"`
+	Influence   string            `json:"influence"`
+	DateMigr    string            `json:"date_migr"`
+	Fields      map[string]string `json:"fields"`
 }
 
-// ParseClaims parses claims XML into structured data
-func ParseClaims(xmlData string) (*ClaimsData, error) {
-	var raw claimsXML
-	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
-		return nil, err
+// Date returns DateMigr, the date the event was recorded, as midnight UTC. Partial dates
+// default to the first month or day, as in BiblioData.PublicationDateTime.
+func (e *LegalEvent) Date() (time.Time, error) {
+	return parseEPODate("DateMigr", e.DateMigr)
+}
+
+// LegalData represents parsed legal event data
+type LegalData struct {
+	XMLName      xml.Name     `xml:"world-patent-data" json:"-"`
+	PatentNumber string       `json:"patent_number"`
+	FamilyID     string       `json:"family_id"`
+	LegalEvents  []LegalEvent `json:"legal_events"`
+}
+
+// FilterByInfluence returns the legal events whose influence indicator matches infl
+// (e.g. "+" or "-"). Surrounding whitespace is ignored on both sides, so an empty
+// infl selects events without an influence indicator.
+func (d *LegalData) FilterByInfluence(infl string) []LegalEvent {
+	infl = strings.TrimSpace(infl)
+	var events []LegalEvent
+	for _, event := range d.LegalEvents {
+		if strings.TrimSpace(event.Influence) == infl {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// FilterByCodePrefix returns the legal events whose code starts with prefix
+// (case-insensitive, e.g. "PG" for post-grant events).
+func (d *LegalData) FilterByCodePrefix(prefix string) []LegalEvent {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+	var events []LegalEvent
+	for _, event := range d.LegalEvents {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(event.Code)), prefix) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// EventsSince returns the legal events recorded on or after the calendar day of since
+// (in its location), compared by DateMigr as YYYYMMDD or YYYY-MM-DD. Events keep their
+// order; events without a valid DateMigr are left out. EPO fills DateMigr of some older
+// events with the placeholder 00010101, so they never appear after a real cutoff date.
+func (d *LegalData) EventsSince(since time.Time) []LegalEvent {
+	cutoff := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	var events []LegalEvent
+	for _, event := range d.LegalEvents {
+		date, err := parseEPODate("DateMigr", strings.ReplaceAll(event.DateMigr, "-", ""))
+		if err == nil && !date.Before(cutoff) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// LatestByCode returns the most recent event for each legal event code, keyed by
+// the code without EPO's padding (e.g. "AK" for "AK  "). Events are compared by
+// DateMigr (YYYYMMDD); on equal dates the later event in the response wins.
+func (d *LegalData) LatestByCode() map[string]LegalEvent {
+	latest := make(map[string]LegalEvent)
+	for _, event := range d.LegalEvents {
+		code := strings.TrimSpace(event.Code)
+		if current, ok := latest[code]; ok && current.DateMigr > event.DateMigr {
+			continue
+		}
+		latest[code] = event
+	}
+	return latest
+}
+
+// ProceduralStep represents a single step of the EPO Register procedural history
+type ProceduralStep struct {
+	ID          string            `json:"id"`
+	Code        string            `json:"code"`        // procedural-step-code (e.g., "EXRE", "IGRA", "RFEE")
+	Phase       string            `json:"phase"`       // procedure-step-phase (e.g., "search", "examination")
+	Date        string            `json:"date"`        // First step date (YYYYMMDD)
+	Description string            `json:"description"` // STEP_DESCRIPTION text
+	Deadlines   []string          `json:"deadlines"`   // Time limits set by the step (e.g., "04 months")
+	Dates       map[string]string `json:"dates"`       // step-date-type -> date (e.g., "DATE_OF_REPLY")
+	Texts       map[string]string `json:"texts"`       // step-text-type -> text, excluding the description (e.g., "YEAR")
+}
+
+// ProceduralStepsData represents parsed EPO Register procedural steps
+type ProceduralStepsData struct {
+	Status string           `json:"status"` // Register status of the application
+	Steps  []ProceduralStep `json:"steps"`
+}
+
+// RegisterStatus is a single entry of the EP patent status history of the EPO Register
+type RegisterStatus struct {
+	Code string `json:"code"` // status-code (e.g., "7")
+	Date string `json:"date"` // change-date (YYYYMMDD)
+	Text string `json:"text"` // Status text (e.g., "The patent has been granted")
+}
+
+// RegisterBiblioData represents parsed EPO Register bibliographic data.
+// Where the Register keeps a history of an element, the latest version is used.
+type RegisterBiblioData struct {
+	Status            string                 `json:"status"`             // Register status of the application
+	ApplicationNumber string                 `json:"application_number"` // e.g., "EP10167109"
+	FilingDate        string                 `json:"filing_date"`        // Application date (YYYYMMDD)
+	Publications      []PublicationReference `json:"publications"`
+	Titles            map[string]string      `json:"titles"` // lang -> title
+	Applicants        []string               `json:"applicants"`
+	Statuses          []RegisterStatus       `json:"statuses"`          // Status history, most recent first
+	DesignatedStates  []string               `json:"designated_states"` // e.g., "DE", "FR"
+	LapsedStates      map[string]string      `json:"lapsed_states"`     // country -> lapse date (YYYYMMDD)
+	RenewalStatus     map[string]string      `json:"renewal_status"`    // country -> paid-through date (YYYYMMDD)
+}
+
+// RegisterEvent represents a single dossier event of the EPO Register.
+// Register events describe the EPO prosecution timeline and are distinct from
+// INPADOC legal events (see ParseLegal).
+type RegisterEvent struct {
+	ID             string `json:"id"`
+	Code           string `json:"code"`             // event-code (e.g., "0009012", "EPIDOSNIGR1")
+	Description    string `json:"description"`      // event-text (e.g., "Publication in section I.1 EP Bulletin")
+	Date           string `json:"date"`             // event-date (YYYYMMDD)
+	ImpactOnStatus bool   `json:"impact_on_status"` // The application status changed on the event date
+}
+
+// RegisterEventsData represents parsed EPO Register events
+type RegisterEventsData struct {
+	Status string          `json:"status"` // Register status of the application
+	Events []RegisterEvent `json:"events"`
+}
+
+// UnitaryPatentStatus is a single entry of the unitary patent status history
+type UnitaryPatentStatus struct {
+	Code string `json:"code"` // status-code
+	Date string `json:"date"` // change-date (YYYYMMDD)
+	Text string `json:"text"` // Status text (e.g., "Unitary effect registered")
+}
+
+// UnitaryPatentData represents parsed EPO Register unitary patent package (UPP) data
+type UnitaryPatentData struct {
+	Status                  string                `json:"status"`                    // Current unitary patent status (empty if the patent has no UPP data)
+	UnitaryEffectRegistered bool                  `json:"unitary_effect_registered"` // Whether unitary effect has been registered
+	RequestDate             string                `json:"request_date"`              // Date of the request for unitary effect (YYYYMMDD)
+	ParticipatingStates     []string              `json:"participating_states"`      // Member states covered by the unitary effect (e.g., "DE", "FR")
+	UPCOptOut               bool                  `json:"upc_opt_out"`               // Whether an opt-out from the Unified Patent Court is in effect
+	OptOutDate              string                `json:"opt_out_date"`              // Date of the opt-out in effect (YYYYMMDD)
+	Statuses                []UnitaryPatentStatus `json:"statuses"`
+}
+
+// Paragraph represents a description paragraph
+type Paragraph struct {
+	ID   string         `json:"id"`
+	Num  string         `json:"num"`
+	Text string         `json:"text"` // Includes the text of inline elements such as <sub>, <b> and <figref>
+	Refs []ParagraphRef `json:"refs"` // Cross-references within the text (e.g., <figref>), in document order
+}
+
+// ParagraphRef is an inline reference element of a description paragraph, such as
+// <figref idref="f0001">Fig. 1</figref>. Its text is also part of Paragraph.Text.
+type ParagraphRef struct {
+	Element string `json:"element"` // Element name, e.g. "figref" or "crossref"
+	IDRef   string `json:"id_ref"`  // idref attribute: the referenced figure, paragraph, formula, etc.
+	Text    string `json:"text"`    // e.g. "Fig. 1"
+}
+
+// DescriptionData represents parsed description data
+type DescriptionData struct {
+	XMLName      xml.Name    `xml:"world-patent-data" json:"-"`
+	PatentNumber string      `json:"patent_number"`
+	Country      string      `json:"country"`
+	DocNumber    string      `json:"doc_number"`
+	Kind         string      `json:"kind"`
+	Language     string      `json:"language"`
+	Paragraphs   []Paragraph `json:"paragraphs"`
+
+	// UnrepresentableFields lists the paragraphs still containing content without a
+	// plain-text representation (e.g., "paragraphs[12]"); set only with WithTextNormalization
+	UnrepresentableFields []string `json:"unrepresentable_fields,omitempty"`
+}
+
+// FulltextData represents complete fulltext document data
+type FulltextData struct {
+	XMLName     xml.Name         `xml:"world-patent-data" json:"-"`
+	Country     string           `json:"country"`
+	DocNumber   string           `json:"doc_number"`
+	Kind        string           `json:"kind"`
+	Language    string           `json:"language"`
+	Status      string           `json:"status"`
+	Biblio      *BiblioData      `json:"biblio"`
+	Abstract    *AbstractData    `json:"abstract"`
+	Description *DescriptionData `json:"description"`
+	Claims      *ClaimsData      `json:"claims"`
+}
+
+// NumberConversionData represents a parsed number conversion (number service) response
+type NumberConversionData struct {
+	InputFormat  string `json:"input_format"`  // e.g., "original"
+	OutputFormat string `json:"output_format"` // e.g., "docdb"
+	Country      string `json:"country"`       // Empty for epodoc output, where the country is part of DocNumber
+	DocNumber    string `json:"doc_number"`
+	Kind         string `json:"kind"`
+	Date         string `json:"date"`
+	Number       string `json:"number"` // Converted number as accepted by the API (e.g., "EP.2400812.A1", "EP2400812A1")
+}
+
+// SearchResult represents a single search result
+type SearchResult struct {
+	System    string `json:"system"`
+	FamilyID  string `json:"family_id"`
+	Country   string `json:"country"`
+	DocNumber string `json:"doc_number"`
+	Kind      string `json:"kind"`
+	Title     string `json:"title"`
+}
+
+// SearchResultData represents search results with pagination
+type SearchResultData struct {
+	XMLName    xml.Name       `xml:"world-patent-data" json:"-"`
+	Query      string         `json:"query"`
+	TotalCount int            `json:"total_count"`
+	RangeBegin int            `json:"range_begin"`
+	RangeEnd   int            `json:"range_end"`
+	Results    []SearchResult `json:"results"`
+}
+
+// Numbers returns the numbers of the results, in result order and without duplicates.
+// See PatentNumbers.
+func (d *SearchResultData) Numbers() []PatentNumber {
+	var nums patentNumberSet
+	for _, result := range d.Results {
+		nums.add(result.Country, result.DocNumber, result.Kind)
+	}
+	return nums.list
+}
+
+// IsEmpty reports whether the search matched no documents.
+// EPO answers such searches with total-result-count="0" and no results.
+func (d *SearchResultData) IsEmpty() bool {
+	return d.TotalCount == 0 && len(d.Results) == 0
+}
+
+// HasMore reports whether results beyond RangeEnd can still be retrieved, i.e.
+// RangeEnd is below both TotalCount and MaxSearchResults.
+func (d *SearchResultData) HasMore() bool {
+	_, ok := d.NextRange()
+	return ok
+}
+
+// NextRange returns the range of up to MaxSearchRangeSize results following this
+// page, e.g. 101-200 after 1-100. It ends at TotalCount or MaxSearchResults,
+// whichever is lower, and reports false when no results are left to retrieve.
+//
+// Example:
+//
+//	r, _ := ops.NewSearchRange(1, 100)
+//	for {
+//	    page, err := client.Search(ctx, query, "", ops.WithRange(r))
+//	    // ...
+//	    next, ok := page.NextRange()
+//	    if !ok {
+//	        break
+//	    }
+//	    r = next
+//	}
+func (d *SearchResultData) NextRange() (SearchRange, bool) {
+	return nextSearchRange(d.RangeEnd, d.TotalCount, MaxSearchResults)
+}
+
+// RegisterSearchResult represents a single EPO Register search result
+type RegisterSearchResult struct {
+	Country           string `json:"country"`            // Publication country (e.g., "EP")
+	PublicationNumber string `json:"publication_number"` // Publication number without kind (e.g., "4636834")
+	PublicationDate   string `json:"publication_date"`   // Publication date (YYYYMMDD)
+	ApplicationNumber string `json:"application_number"` // Application number with country (e.g., "EP25198922")
+	Title             string `json:"title"`              // English title, or the first title available
+	Status            string `json:"status"`             // Register status of the application, if given
+}
+
+// RegisterSearchData represents EPO Register search results with pagination
+type RegisterSearchData struct {
+	Query      string                 `json:"query"`
+	TotalCount int                    `json:"total_count"`
+	RangeBegin int                    `json:"range_begin"`
+	RangeEnd   int                    `json:"range_end"`
+	Results    []RegisterSearchResult `json:"results"`
+}
+
+// IsEmpty reports whether the register search matched no applications.
+func (d *RegisterSearchData) IsEmpty() bool {
+	return d.TotalCount == 0 && len(d.Results) == 0
+}
+
+// HasMore reports whether results beyond RangeEnd can still be retrieved.
+func (d *RegisterSearchData) HasMore() bool {
+	_, ok := d.NextRange()
+	return ok
+}
+
+// NextRange returns the range of up to MaxSearchRangeSize results following this
+// page, ending at TotalCount or MaxRegisterSearchResults, whichever is lower.
+// It reports false when no results are left to retrieve.
+func (d *RegisterSearchData) NextRange() (SearchRange, bool) {
+	return nextSearchRange(d.RangeEnd, d.TotalCount, MaxRegisterSearchResults)
+}
+
+// SearchBiblioResult represents a search result together with its bibliographic data.
+// Biblio is nil when the response carries no bibliographic-data for the result.
+type SearchBiblioResult struct {
+	SearchResult
+	Biblio *BiblioData `json:"biblio"`
+}
+
+// SearchBiblioData represents search results retrieved with the biblio constituent
+type SearchBiblioData struct {
+	Query      string               `json:"query"`
+	TotalCount int                  `json:"total_count"`
+	RangeBegin int                  `json:"range_begin"`
+	RangeEnd   int                  `json:"range_end"`
+	Results    []SearchBiblioResult `json:"results"`
+}
+
+// SearchFullCycleResult represents a search result together with all its publication
+// stages. The embedded SearchResult describes the document that matched the query.
+type SearchFullCycleResult struct {
+	SearchResult
+	FullCycle *FullCycleData `json:"full_cycle"`
+}
+
+// SearchFullCycleData represents search results retrieved with the full-cycle constituent
+type SearchFullCycleData struct {
+	Query      string                  `json:"query"`
+	TotalCount int                     `json:"total_count"`
+	RangeBegin int                     `json:"range_begin"`
+	RangeEnd   int                     `json:"range_end"`
+	Results    []SearchFullCycleResult `json:"results"`
+}
+
+// EquivalentPatent represents an equivalent patent
+type EquivalentPatent struct {
+	Country   string `json:"country"`
+	DocNumber string `json:"doc_number"`
+	Kind      string `json:"kind"`
+}
+
+// EquivalentsData represents published equivalents inquiry results
+type EquivalentsData struct {
+	XMLName      xml.Name           `xml:"world-patent-data" json:"-"`
+	PatentNumber string             `json:"patent_number"`
+	Equivalents  []EquivalentPatent `json:"equivalents"`
+}
+
+// Numbers returns the numbers of the equivalents, in response order and without
+// duplicates. EPO lists equivalents without kind code, so their Kind is empty and
+// Ref gives them in EPODOC format (e.g., "US2012057518"); see PatentNumbers.
+func (d *EquivalentsData) Numbers() []PatentNumber {
+	var nums patentNumberSet
+	for _, equivalent := range d.Equivalents {
+		nums.add(equivalent.Country, equivalent.DocNumber, equivalent.Kind)
+	}
+	return nums.list
+}
+
+// PatentNumbers is implemented by parsed responses that list patents
+// (SearchResultData, FamilyData, EquivalentsData), so the numbers of one response
+// can be passed on to the next call of a pipeline, e.g. search → family → biblio.
+//
+// Numbers are returned as PatentNumber values with upper-case country and kind code.
+// Some responses list patents without a kind code, so Kind may be empty: pass the
+// numbers to the *For methods (e.g. GetBiblioFor), or use PatentNumber.Ref for the
+// format and number string of the other methods, which is DOCDB with a kind code
+// and EPODOC without.
+//
+// Example:
+//
+//	results, _ := client.Search(ctx, "ti=bluetooth", "1-10")
+//	for _, pn := range results.Numbers() {
+//	    format, number := pn.Ref()
+//	    family, err := client.GetFamily(ctx, ops.RefTypePublication, format, number)
+//	    // ...
+//	}
+type PatentNumbers interface {
+	Numbers() []PatentNumber
+}
+
+// patentNumberSet collects patent numbers for PatentNumbers in order of first
+// appearance, skipping duplicates and entries without country or number.
+type patentNumberSet struct {
+	list []PatentNumber
+	seen map[PatentNumber]bool
+}
+
+// add appends the number made of country, number and kind (see PatentNumbers).
+func (s *patentNumberSet) add(country, number, kind string) {
+	pn := PatentNumber{
+		Country: strings.ToUpper(strings.TrimSpace(country)),
+		Number:  strings.TrimSpace(number),
+		Kind:    strings.ToUpper(strings.TrimSpace(kind)),
+	}
+	if pn.Country == "" || pn.Number == "" || s.seen[pn] {
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[PatentNumber]bool)
+	}
+	s.seen[pn] = true
+	s.list = append(s.list, pn)
+}
+
+// MappedClass represents one classification symbol a mapping resolves to
+type MappedClass struct {
+	Symbol     string `json:"symbol"`     // e.g., "A01D2085/008"
+	Scheme     string `json:"scheme"`     // Output scheme, e.g., "CPC", "ECLA", "IPC"
+	Additional bool   `json:"additional"` // true for additional-only classifications, false for invention ones
+}
+
+// ClassificationMappingResult represents a parsed classification mapping (e.g., ECLA to CPC)
+type ClassificationMappingResult struct {
+	Input        string        `json:"input"`         // Input symbol as echoed by the service
+	InputScheme  string        `json:"input_scheme"`  // e.g., "ECLA"
+	OutputScheme string        `json:"output_scheme"` // e.g., "CPC"
+	Mappings     []MappedClass `json:"mappings"`
+}
+
+// ClassificationStat represents the share of one classification in a classification statistics search
+type ClassificationStat struct {
+	Symbol     string  `json:"symbol"`     // CPC symbol (e.g., "H04W72/00")
+	Title      string  `json:"title"`      // Class title; title parts are joined with "; "
+	Percentage float64 `json:"percentage"` // Share of matching documents in this classification
+	Count      int     `json:"count"`      // Number of matching documents, if the response reports it (0 otherwise)
+}
+
+// ClassificationNote is a note or warning attached to a classification item
+type ClassificationNote struct {
+	Type string `json:"type"` // note type (e.g., "note", "warning")
+	Text string `json:"text"` // Note paragraphs, one per line
+}
+
+// ClassificationNode is a classification item of a CPC scheme with its child items
+type ClassificationNode struct {
+	Symbol           string               `json:"symbol"`            // CPC symbol (e.g., "H04W84/18")
+	Level            int                  `json:"level"`             // Scheme level: 5 subclass, 7 main group, 8+ subgroups by number of dots
+	Title            string               `json:"title"`             // Class title; title parts are joined with "; "
+	Notes            []ClassificationNote `json:"notes"`             // Notes and warnings of the item
+	DefinitionExists bool                 `json:"definition_exists"` // A CPC definition is available for the item
+	NotAllocatable   bool                 `json:"not_allocatable"`   // The item cannot be assigned to documents
+	Children         []ClassificationNode `json:"children"`
+}
+
+// ClassificationSchemaData represents a parsed CPC classification schema response
+type ClassificationSchemaData struct {
+	ExportDate string               `json:"export_date"` // Date of the CPC scheme version (YYYY-MM-DD)
+	Items      []ClassificationNode `json:"items"`       // Top-level items of the response
+}
+
+// Internal structs for XML unmarshaling
+type abstractXML struct {
+	XMLName          xml.Name `xml:"world-patent-data"`
+	ExchangeDocument *struct {
+		Country   string             `xml:"country,attr"`
+		DocNumber string             `xml:"doc-number,attr"`
+		Kind      string             `xml:"kind,attr"`
+		Abstracts []abstractEntryXML `xml:"abstract"`
+	} `xml:"exchange-documents>exchange-document"`
+}
+
+// abstractEntryXML is one abstract element (one language) of a document.
+type abstractEntryXML struct {
+	Lang       string       `xml:"lang,attr"`
+	Paragraphs []markupText `xml:"p"`
+}
+
+// markupText is the character data of an element including that of nested inline
+// markup (e.g., <sub>, <sup>, <b>), which a plain string field would drop.
+type markupText string
+
+// UnmarshalXML concatenates all character data within the element, dropping the tags.
+func (m *markupText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, _, err := readMarkup(d)
+	*m = markupText(text)
+	return err
+}
+
+// readMarkup reads the rest of the current element, returning its character data
+// including that of nested elements. Nested elements with an idref attribute are
+// returned as references.
+func readMarkup(d *xml.Decoder) (string, []ParagraphRef, error) {
+	var text strings.Builder
+	var refs []ParagraphRef
+	var open []int   // per open nested element: index into refs, or -1
+	var starts []int // per open nested element: text offset where it begins
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return "", nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			index := -1
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "idref" {
+					index = len(refs)
+					refs = append(refs, ParagraphRef{Element: t.Name.Local, IDRef: attr.Value})
+				}
+			}
+			open = append(open, index)
+			starts = append(starts, text.Len())
+		case xml.EndElement:
+			last := len(open) - 1
+			if last < 0 {
+				return text.String(), refs, nil
+			}
+			if index := open[last]; index >= 0 {
+				refs[index].Text = strings.TrimSpace(text.String()[starts[last]:])
+			}
+			open, starts = open[:last], starts[:last]
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+}
+
+// paragraphXML is a description paragraph including the text of its inline elements.
+type paragraphXML struct {
+	ID   string
+	Num  string
+	Text string
+	Refs []ParagraphRef
+}
+
+// UnmarshalXML reads the paragraph attributes and its full inner text.
+func (p *paragraphXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			p.ID = attr.Value
+		case "num":
+			p.Num = attr.Value
+		}
+	}
+	var err error
+	p.Text, p.Refs, err = readMarkup(d)
+	return err
+}
+
+// paragraph converts the decoded paragraph, trimming surrounding whitespace.
+func (p paragraphXML) paragraph() Paragraph {
+	return Paragraph{ID: p.ID, Num: p.Num, Text: strings.TrimSpace(p.Text), Refs: p.Refs}
+}
+
+type biblioXML struct {
+	XMLName          xml.Name             `xml:"world-patent-data"`
+	ExchangeDocument *exchangeDocumentXML `xml:"exchange-documents>exchange-document"`
+}
+
+// exchangeDocumentXML represents a single exchange-document with bibliographic data.
+// It appears under exchange-documents in biblio responses and inside each family-member
+// of family responses retrieved with the biblio constituent.
+type exchangeDocumentXML struct {
+	System     string `xml:"system,attr"`
+	Country    string `xml:"country,attr"`
+	DocNumber  string `xml:"doc-number,attr"`
+	Kind       string `xml:"kind,attr"`
+	FamilyID   string `xml:"family-id,attr"`
+	BiblioData struct {
+		PublicationRef struct {
+			DocumentID []struct {
+				Type      string `xml:"document-id-type,attr"`
+				Country   string `xml:"country"`
+				DocNumber string `xml:"doc-number"`
+				Kind      string `xml:"kind"`
+				Date      string `xml:"date"`
+			} `xml:"document-id"`
+		} `xml:"publication-reference"`
+		InventionTitles []struct {
+			Lang string `xml:"lang,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"invention-title"`
+		Parties struct {
+			Applicants []struct {
+				Sequence      string `xml:"sequence,attr"`
+				DataFormat    string `xml:"data-format,attr"`
+				ApplicantName struct {
+					Name string `xml:"name"`
+				} `xml:"applicant-name"`
+			} `xml:"applicants>applicant"`
+			Inventors []struct {
+				Sequence     string `xml:"sequence,attr"`
+				DataFormat   string `xml:"data-format,attr"`
+				InventorName struct {
+					Name string `xml:"name"`
+				} `xml:"inventor-name"`
+			} `xml:"inventors>inventor"`
+		} `xml:"parties"`
+		ClassificationsIPCR []struct {
+			Text string `xml:"text"`
+		} `xml:"classifications-ipcr>classification-ipcr"`
+		PatentClassifications []struct {
+			Section   string `xml:"section"`
+			Class     string `xml:"class"`
+			Subclass  string `xml:"subclass"`
+			MainGroup string `xml:"main-group"`
+			Subgroup  string `xml:"subgroup"`
+		} `xml:"patent-classifications>patent-classification"`
+	} `xml:"bibliographic-data"`
+}
+
+type claimsXML struct {
+	XMLName           xml.Name `xml:"world-patent-data"`
+	FulltextDocuments struct {
+		FulltextDocument *struct {
+			BiblioData struct {
+				PublicationRef struct {
+					DocumentID struct {
+						Country   string `xml:"country"`
+						DocNumber string `xml:"doc-number"`
+						Kind      string `xml:"kind"`
+					} `xml:"document-id"`
+				} `xml:"publication-reference"`
+			} `xml:"bibliographic-data"`
+			Claims []struct {
+				Lang   string `xml:"lang,attr"`
+				Claims []struct {
+					Num   string         `xml:"num,attr"`
+					ID    string         `xml:"id,attr"`
+					Texts []claimTextXML `xml:"claim-text"`
+				} `xml:"claim"`
+			} `xml:"claims"`
+		} `xml:"fulltext-document"`
+	} `xml:"fulltext-documents"`
+}
+
+// claimTextXML is a claim-text element, which may nest further claim-text elements.
+type claimTextXML struct {
+	Text  string         `xml:",chardata"`
+	Parts []claimTextXML `xml:"claim-text"`
+}
+
+// ParseAbstract parses abstract XML into structured data.
+// Pass WithTextNormalization to clean up the text for further processing.
+func ParseAbstract(xmlData string, opts ...ParseOption) (*AbstractData, error) {
+	var raw abstractXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseAbstract",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if raw.ExchangeDocument == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseAbstract",
+			MissingField: "exchange-document",
+			Message:      "response contains no exchange-document",
+		}
+	}
+
+	data := newAbstractData(raw.ExchangeDocument.Country, raw.ExchangeDocument.DocNumber,
+		raw.ExchangeDocument.Kind, raw.ExchangeDocument.Abstracts)
+
+	if newParseOptions(opts).normalizeText {
+		data.normalizeText()
+	}
+
+	return data, nil
+}
+
+// newAbstractData converts the abstract elements of a document into AbstractData.
+func newAbstractData(country, docNumber, kind string, abstracts []abstractEntryXML) *AbstractData {
+	data := &AbstractData{
+		Country:   country,
+		DocNumber: docNumber,
+		Kind:      kind,
+		Texts:     make(map[string]string),
+	}
+
+	// Construct patent number
+	if data.Country != "" && data.DocNumber != "" && data.Kind != "" {
+		data.PatentNumber = fmt.Sprintf("%s%s%s", data.Country, data.DocNumber, data.Kind)
+	}
+
+	// Extract abstracts, preferring English (else the first one) for Language/Text
+	for i, abstract := range abstracts {
+		var paragraphs []string
+		for _, p := range abstract.Paragraphs {
+			if text := strings.Join(strings.Fields(string(p)), " "); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+		}
+		text := strings.Join(paragraphs, "\n")
+		if _, exists := data.Texts[abstract.Lang]; !exists {
+			data.Texts[abstract.Lang] = text
+		}
+		if i == 0 || (strings.EqualFold(abstract.Lang, "en") && !strings.EqualFold(data.Language, "en")) {
+			data.Language = abstract.Lang
+			data.Text = text
+		}
+	}
+
+	return data
+}
+
+// ParseBiblio parses bibliographic XML into structured data
+func ParseBiblio(xmlData string) (*BiblioData, error) {
+	var raw biblioXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseBiblio",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	// E.g. a legal or family response, which would otherwise give an empty BiblioData
+	if raw.ExchangeDocument == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseBiblio",
+			MissingField: "exchange-document",
+			Message:      "response contains no exchange-document",
+		}
+	}
+
+	return convertExchangeDocument(*raw.ExchangeDocument), nil
+}
+
+// convertExchangeDocument converts a single unmarshaled exchange-document into BiblioData.
+func convertExchangeDocument(doc exchangeDocumentXML) *BiblioData {
+	data := &BiblioData{
+		Country:   doc.Country,
+		DocNumber: doc.DocNumber,
+		Kind:      doc.Kind,
+		FamilyID:  doc.FamilyID,
+		Titles:    make(map[string]string),
+	}
+
+	// Construct patent number
+	if data.Country != "" && data.DocNumber != "" && data.Kind != "" {
+		data.PatentNumber = fmt.Sprintf("%s%s%s", data.Country, data.DocNumber, data.Kind)
+	}
+
+	// Extract publication date from first docdb document-id
+	for _, docID := range doc.BiblioData.PublicationRef.DocumentID {
+		if docID.Type == "docdb" && docID.Date != "" {
+			data.PublicationDate = docID.Date
+			break
+		}
+	}
+
+	// Extract titles (multilingual)
+	for _, title := range doc.BiblioData.InventionTitles {
+		if title.Lang != "" && title.Text != "" {
+			data.Titles[title.Lang] = strings.TrimSpace(title.Text)
+		}
+	}
+
+	// Extract applicants (only epodoc format to avoid duplicates)
+	for _, applicant := range doc.BiblioData.Parties.Applicants {
+		if applicant.DataFormat == "epodoc" && applicant.ApplicantName.Name != "" {
+			name := strings.TrimSpace(applicant.ApplicantName.Name)
+			// Extract country from name if present (format: "NAME [CC]")
+			country := ""
+			if idx := strings.LastIndex(name, "["); idx > 0 {
+				if idx2 := strings.Index(name[idx:], "]"); idx2 > 0 {
+					country = name[idx+1 : idx+idx2]
+					name = strings.TrimSpace(name[:idx])
+				}
+			}
+			data.Applicants = append(data.Applicants, Party{
+				Name:    name,
+				Country: country,
+			})
+		}
+	}
+
+	// Extract inventors (only epodoc format)
+	for _, inventor := range doc.BiblioData.Parties.Inventors {
+		if inventor.DataFormat == "epodoc" && inventor.InventorName.Name != "" {
+			name := strings.TrimSpace(inventor.InventorName.Name)
+			country := ""
+			if idx := strings.LastIndex(name, "["); idx > 0 {
+				if idx2 := strings.Index(name[idx:], "]"); idx2 > 0 {
+					country = name[idx+1 : idx+idx2]
+					name = strings.TrimSpace(name[:idx])
+				}
+			}
+			data.Inventors = append(data.Inventors, Party{
+				Name:    name,
+				Country: country,
+			})
+		}
+	}
+
+	// Extract IPC classifications
+	for _, ipc := range doc.BiblioData.ClassificationsIPCR {
+		if ipc.Text != "" {
+			data.IPCClasses = append(data.IPCClasses, strings.TrimSpace(ipc.Text))
+			if class, err := ParseIPCSymbol(ipc.Text); err == nil {
+				data.IPCClassesParsed = append(data.IPCClassesParsed, class)
+			}
+		}
+	}
+
+	// Extract CPC classifications
+	for _, cpc := range doc.BiblioData.PatentClassifications {
+		class := CPCClass{
+			Section:   cpc.Section,
+			Class:     cpc.Class,
+			Subclass:  cpc.Subclass,
+			MainGroup: cpc.MainGroup,
+			Subgroup:  cpc.Subgroup,
+		}
+		// Build full representation
+		class.Full = class.String()
+		data.CPCClasses = append(data.CPCClasses, class)
+	}
+
+	return data
+}
+
+// ParseClaims parses claims XML into structured data.
+// Pass WithTextNormalization to clean up the text for further processing.
+func ParseClaims(xmlData string, opts ...ParseOption) (*ClaimsData, error) {
+	var raw claimsXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseClaims",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
 	}
 
 	doc := raw.FulltextDocuments.FulltextDocument
+	if doc == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseClaims",
+			MissingField: "fulltext-document",
+			Message:      "response contains no fulltext-document",
+		}
+	}
 	data := &ClaimsData{
 		Country:   doc.BiblioData.PublicationRef.DocumentID.Country,
 		DocNumber: doc.BiblioData.PublicationRef.DocumentID.DocNumber,
 		Kind:      doc.BiblioData.PublicationRef.DocumentID.Kind,
-		Language:  doc.Claims.Lang,
 	}
 
 	// Construct patent number
@@ -458,19 +1587,172 @@ func ParseClaims(xmlData string) (*ClaimsData, error) {
 		data.PatentNumber = fmt.Sprintf("%s%s%s", data.Country, data.DocNumber, data.Kind)
 	}
 
-	// Extract claims
-	for i, claimText := range doc.Claims.ClaimList.ClaimTexts {
-		if claimText.Text != "" {
-			data.Claims = append(data.Claims, Claim{
-				Number: i + 1,
-				Text:   strings.TrimSpace(claimText.Text),
-			})
+	// Extract claims of every language block
+	for _, block := range doc.Claims {
+		var claims []Claim
+		for _, element := range block.Claims {
+			// Text-only responses put all claims into one claim element, one claim-text each
+			if element.Num == "" && element.ID == "" && len(element.Texts) > 1 {
+				for _, text := range element.Texts {
+					if claim, ok := newClaim("", "", []claimTextXML{text}, len(claims)+1); ok {
+						claims = append(claims, claim)
+					}
+				}
+				continue
+			}
+			if claim, ok := newClaim(element.Num, element.ID, element.Texts, len(claims)+1); ok {
+				claims = append(claims, claim)
+			}
 		}
+
+		// The first block is the default language
+		if data.ClaimsByLanguage == nil {
+			data.Language = block.Lang
+			data.Claims = claims
+			data.ClaimsByLanguage = make(map[string][]Claim)
+		}
+		if _, exists := data.ClaimsByLanguage[block.Lang]; !exists {
+			data.ClaimsByLanguage[block.Lang] = claims
+		}
+	}
+
+	if newParseOptions(opts).normalizeText {
+		data.normalizeText()
 	}
 
 	return data, nil
 }
 
+// leadingClaimNumber matches the number text-only claims start with (e.g., "2. ").
+var leadingClaimNumber = regexp.MustCompile(`^\s*(\d+)\s*\.`)
+
+// claimIDNumber matches the claim number at the end of a claim id (e.g., "c-en-0003").
+var claimIDNumber = regexp.MustCompile(`(\d+)$`)
+
+// newClaim builds a claim from the claim-text elements of a claim element. The number
+// is taken from num, else from id, else from the start of the text, else index, so
+// deleted claims leave a gap instead of shifting later numbers. ok is false for claims
+// without text.
+func newClaim(num, id string, texts []claimTextXML, index int) (claim Claim, ok bool) {
+	claim = Claim{Num: num, ID: id, Number: index}
+
+	// The claim's own text is the single top-level claim-text; its children are the parts
+	if len(texts) == 1 {
+		claim.Parts = claimTexts(texts[0].Parts)
+		claim.Text = flattenClaimText(texts[0])
+	} else {
+		claim.Parts = claimTexts(texts)
+		var lines []string
+		for _, text := range texts {
+			if line := flattenClaimText(text); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		claim.Text = strings.Join(lines, "\n")
+	}
+	if claim.Text == "" {
+		return claim, false
+	}
+
+	if n := claimNumber(num, id); n > 0 {
+		claim.Number = n
+	} else if m := leadingClaimNumber.FindStringSubmatch(claim.Text); m != nil {
+		claim.Number, _ = strconv.Atoi(m[1])
+	}
+	claim.DependsOn = ClaimReferences(claim.Text, claim.Number)
+	return claim, true
+}
+
+// claimNumber returns the claim number given by the num or id attribute, or 0 if neither has one.
+func claimNumber(num, id string) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(num)); err == nil && n > 0 {
+		return n
+	}
+	if m := claimIDNumber.FindStringSubmatch(strings.TrimSpace(id)); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// claimTexts converts nested claim-text elements.
+func claimTexts(raw []claimTextXML) []ClaimText {
+	var texts []ClaimText
+	for _, text := range raw {
+		texts = append(texts, ClaimText{
+			Text:  strings.TrimSpace(text.Text),
+			Parts: claimTexts(text.Parts),
+		})
+	}
+	return texts
+}
+
+// flattenClaimText joins the text of a claim-text element and its nested elements by newlines.
+func flattenClaimText(text claimTextXML) string {
+	var lines []string
+	if own := strings.TrimSpace(text.Text); own != "" {
+		lines = append(lines, own)
+	}
+	for _, part := range text.Parts {
+		if line := flattenClaimText(part); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// claimReferencePattern matches references to claims in English, German and French
+// claims (e.g., "claim 1", "claims 1 or 2", "Anspruch 1 bis 3", "revendications 1 à 4").
+var claimReferencePattern = regexp.MustCompile(`(?i)\b(?:claims?|anspr(?:uch|üchen?)|revendications?)\s+` +
+	`(\d+(?:(?:\s*,\s*(?:(?:or|and|oder|und|ou|et)\s+)?|\s*[-–]\s*|\s+(?:to|or|and|bis|oder|und|à|ou|et)\s+)\d+)*)`)
+
+// maxClaimRange limits how many claims a single range reference ("claims 1 to 20") may span.
+const maxClaimRange = 1000
+
+// claimReferenceToken splits a matched reference list into numbers and range connectors.
+var claimReferenceToken = regexp.MustCompile(`\d+|[-–]|(?i:\bto\b|\bbis\b|à)`)
+
+// ClaimReferences returns the earlier claims that the text of claim number refers to,
+// sorted and without duplicates, e.g. [1 2 3] for "according to any one of claims 1 to 3".
+//
+// References are recognized in English, German and French ("claim", "Anspruch",
+// "revendication"). Only claims before number count as dependencies, so mentions of
+// later claims and of the claim itself are ignored; a number of 0 or less accepts all.
+func ClaimReferences(text string, number int) []int {
+	var refs []int
+	add := func(n int) {
+		if n > 0 && (number <= 0 || n < number) {
+			refs = append(refs, n)
+		}
+	}
+
+	for _, match := range claimReferencePattern.FindAllStringSubmatch(text, -1) {
+		// Tokens alternate between numbers and range connectors ("1", "to", "3")
+		tokens := claimReferenceToken.FindAllString(match[1], -1)
+		for i := 0; i < len(tokens); i++ {
+			first, err := strconv.Atoi(tokens[i])
+			if err != nil {
+				continue
+			}
+			if i+2 < len(tokens) {
+				if _, err := strconv.Atoi(tokens[i+1]); err != nil {
+					last, _ := strconv.Atoi(tokens[i+2])
+					for n := first; n <= min(last, first+maxClaimRange); n++ {
+						add(n)
+					}
+					i += 2
+					continue
+				}
+			}
+			add(first)
+		}
+	}
+
+	slices.Sort(refs)
+	return slices.Compact(refs)
+}
+
 // imageInquiryXML is the internal structure for unmarshaling image inquiry XML.
 //
 // Note on Link field structure:
@@ -586,112 +1868,393 @@ func ParseImageInquiry(xmlData string) (*ImageInquiry, error) {
 
 // Internal structs for Family XML unmarshaling
 type familyXML struct {
-	XMLName      xml.Name `xml:"world-patent-data"`
-	PatentFamily struct {
-		Legal            string `xml:"legal,attr"`
-		TotalResultCount string `xml:"total-result-count,attr"`
-		PublicationRef   struct {
-			DocumentID struct {
+	XMLName      xml.Name        `xml:"world-patent-data"`
+	PatentFamily patentFamilyXML `xml:"patent-family"`
+}
+
+// familyMultipleXML is used for bulk responses containing one patent-family element per requested patent
+type familyMultipleXML struct {
+	XMLName        xml.Name          `xml:"world-patent-data"`
+	PatentFamilies []patentFamilyXML `xml:"patent-family"`
+}
+
+// patentFamilyXML represents a single patent-family element
+type patentFamilyXML struct {
+	Legal            string `xml:"legal,attr"`
+	TotalResultCount string `xml:"total-result-count,attr"`
+	PublicationRef   struct {
+		DocumentID struct {
+			Country   string `xml:"country"`
+			DocNumber string `xml:"doc-number"`
+			Kind      string `xml:"kind"`
+		} `xml:"document-id"`
+	} `xml:"publication-reference"`
+	FamilyMembers []struct {
+		FamilyID        string `xml:"family-id,attr"`
+		PublicationRefs []struct {
+			DocumentIDs []struct {
+				Type      string `xml:"document-id-type,attr"`
 				Country   string `xml:"country"`
 				DocNumber string `xml:"doc-number"`
 				Kind      string `xml:"kind"`
+				Date      string `xml:"date"`
 			} `xml:"document-id"`
 		} `xml:"publication-reference"`
-		FamilyMembers []struct {
-			FamilyID       string `xml:"family-id,attr"`
-			PublicationRef struct {
-				DocumentIDs []struct {
-					Type      string `xml:"document-id-type,attr"`
-					Country   string `xml:"country"`
-					DocNumber string `xml:"doc-number"`
-					Kind      string `xml:"kind"`
-					Date      string `xml:"date"`
-				} `xml:"document-id"`
-			} `xml:"publication-reference"`
-			ApplicationRef struct {
-				DocID      string `xml:"doc-id,attr"`
-				DocumentID struct {
-					Country   string `xml:"country"`
-					DocNumber string `xml:"doc-number"`
-					Kind      string `xml:"kind"`
-					Date      string `xml:"date"`
-				} `xml:"document-id"`
-			} `xml:"application-reference"`
-			PriorityClaims []struct {
-				Sequence   string `xml:"sequence,attr"`
-				Kind       string `xml:"kind,attr"`
-				DocumentID struct {
-					Country   string `xml:"country"`
-					DocNumber string `xml:"doc-number"`
-					Kind      string `xml:"kind"`
-					Date      string `xml:"date"`
-				} `xml:"document-id"`
-				ActiveIndicator string `xml:"priority-active-indicator"`
-			} `xml:"priority-claim"`
-		} `xml:"family-member"`
-	} `xml:"patent-family"`
+		ApplicationRef struct {
+			DocID      string `xml:"doc-id,attr"`
+			DocumentID struct {
+				Country   string `xml:"country"`
+				DocNumber string `xml:"doc-number"`
+				Kind      string `xml:"kind"`
+				Date      string `xml:"date"`
+			} `xml:"document-id"`
+		} `xml:"application-reference"`
+		PriorityClaims []struct {
+			Sequence   string `xml:"sequence,attr"`
+			Kind       string `xml:"kind,attr"`
+			DocumentID struct {
+				Country   string `xml:"country"`
+				DocNumber string `xml:"doc-number"`
+				Kind      string `xml:"kind"`
+				Date      string `xml:"date"`
+			} `xml:"document-id"`
+			ActiveIndicator string `xml:"priority-active-indicator"`
+		} `xml:"priority-claim"`
+		ExchangeDocuments []exchangeDocumentXML `xml:"exchange-document"`
+		LegalEvents       []legalEventXML       `xml:"legal"`
+	} `xml:"family-member"`
+}
+
+// truncateXML truncates XML for error messages
+func truncateXML(xmlData string, maxLen int) string {
+	if len(xmlData) <= maxLen {
+		return xmlData
+	}
+	return xmlData[:maxLen] + "..."
+}
+
+// fullCycleXML holds the exchange-documents of a full-cycle response, one per publication stage.
+type fullCycleXML struct {
+	XMLName   xml.Name              `xml:"world-patent-data"`
+	Documents []exchangeDocumentXML `xml:"exchange-documents>exchange-document"`
+}
+
+// ParseConstituents parses a combined published-data response (e.g., from
+// GetConstituentsRaw) into the sections named by constituents ("biblio", "abstract",
+// "full-cycle"); sections not named stay nil.
+func ParseConstituents(xmlData string, constituents ...string) (*ConstituentsData, error) {
+	data := &ConstituentsData{}
+	for _, constituent := range constituents {
+		var err error
+		switch constituent {
+		case ConstituentBiblio:
+			data.Biblio, err = ParseBiblio(xmlData)
+		case ConstituentAbstract:
+			data.Abstract, err = ParseAbstract(xmlData)
+		case ConstituentFullCycle:
+			data.FullCycle, err = ParseFullCycle(xmlData)
+		default:
+			err = invalidConstituentError(constituent)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// invalidConstituentError is returned for constituents other than biblio, abstract and full-cycle
+func invalidConstituentError(constituent string) error {
+	return &ValidationError{
+		Field:   "constituents",
+		Value:   constituent,
+		Message: "must be 'biblio', 'abstract' or 'full-cycle'",
+	}
+}
+
+// ParseFullCycle parses published-data full-cycle XML (e.g., from GetFullCycleRaw) into
+// the publication stages of a patent.
+//
+// The full-cycle response holds one exchange-document per publication of the same
+// invention (e.g., A1 application and B1 grant). Each becomes a FullCycleStage carrying
+// its publication date and bibliographic data as published at that stage. Stages are
+// ordered by publication date; stages published on the same day keep response order.
+func ParseFullCycle(xmlData string) (*FullCycleData, error) {
+	var raw fullCycleXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseFullCycle",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if len(raw.Documents) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseFullCycle",
+			MissingField: "exchange-document",
+			Message:      "response should contain at least one publication stage",
+		}
+	}
+
+	return newFullCycleData(raw.Documents), nil
+}
+
+// newFullCycleData converts the exchange-documents of one invention into its
+// publication stages, ordered by publication date.
+func newFullCycleData(docs []exchangeDocumentXML) *FullCycleData {
+	data := &FullCycleData{
+		Stages: make([]FullCycleStage, 0, len(docs)),
+	}
+	for _, doc := range docs {
+		biblio := convertExchangeDocument(doc)
+		stage := FullCycleStage{
+			Country:   biblio.Country,
+			DocNumber: biblio.DocNumber,
+			Kind:      biblio.Kind,
+			Date:      biblio.PublicationDate,
+			Biblio:    biblio,
+		}
+		if stage.Country != "" && stage.DocNumber != "" && stage.Kind != "" {
+			stage.DocumentID = stage.Country + "." + stage.DocNumber + "." + stage.Kind
+		}
+		if data.PatentNumber == "" && stage.Country != "" && stage.DocNumber != "" {
+			data.PatentNumber = stage.Country + stage.DocNumber
+		}
+		if data.FamilyID == "" {
+			data.FamilyID = biblio.FamilyID
+		}
+		data.Stages = append(data.Stages, stage)
+	}
+
+	sort.SliceStable(data.Stages, func(i, j int) bool {
+		return data.Stages[i].Date < data.Stages[j].Date
+	})
+
+	return data
+}
+
+// ParseFamily parses patent family XML into structured data
+func ParseFamily(xmlData string) (*FamilyData, error) {
+	var raw familyXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseFamily",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	data := convertPatentFamily(raw.PatentFamily)
+
+	// Validate parsed data
+	// Note: FamilyID may be empty in some responses (especially simplified test data)
+	// but should be present in real EPO API responses
+
+	if len(data.Members) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseFamily",
+			MissingField: "Members",
+			Message:      "family should have at least one member",
+		}
+	}
+
+	return data, nil
 }
 
-// truncateXML truncates XML for error messages
-func truncateXML(xmlData string, maxLen int) string {
-	if len(xmlData) <= maxLen {
-		return xmlData
+// ParseFamilyMultiple parses a bulk family response (e.g., from GetFamilyWithBiblioMultiple
+// or GetFamilyWithLegalMultiple raw XML) into one FamilyData per patent-family element.
+//
+// Requested patents without a family are absent from the EPO response, so the returned
+// slice may be shorter than the list of numbers that was requested. Families are returned
+// in response order; use FamilyData.PatentNumber to match them to the input numbers.
+func ParseFamilyMultiple(xmlData string) ([]*FamilyData, error) {
+	var raw familyMultipleXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseFamilyMultiple",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
 	}
-	return xmlData[:maxLen] + "..."
+
+	families := make([]*FamilyData, 0, len(raw.PatentFamilies))
+	for _, family := range raw.PatentFamilies {
+		data := convertPatentFamily(family)
+		// Skip empty families (patent had no family data)
+		if len(data.Members) == 0 {
+			continue
+		}
+		families = append(families, data)
+	}
+
+	if len(families) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseFamilyMultiple",
+			MissingField: "patent-family",
+			Message:      "response should contain at least one family with members",
+		}
+	}
+
+	return families, nil
 }
 
-// ParseFamily parses patent family XML into structured data
-func ParseFamily(xmlData string) (*FamilyData, error) {
+// ParseFamilyBiblio parses patent family XML retrieved with the biblio constituent
+// (e.g., from GetFamilyWithBiblioRaw) into family members with their bibliographic data.
+//
+// Each member's exchange-document is parsed the same way as ParseBiblio, giving titles,
+// applicants, inventors and classifications per member without separate biblio requests.
+func ParseFamilyBiblio(xmlData string) (*FamilyBiblioData, error) {
 	var raw familyXML
 	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
 		return nil, &XMLParseError{
-			Parser:    "ParseFamily",
+			Parser:    "ParseFamilyBiblio",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	family := convertPatentFamily(raw.PatentFamily)
+	if len(family.Members) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseFamilyBiblio",
+			MissingField: "Members",
+			Message:      "family should have at least one member",
+		}
+	}
+
+	data := &FamilyBiblioData{
+		PatentNumber: family.PatentNumber,
+		FamilyID:     family.FamilyID,
+		TotalCount:   family.TotalCount,
+		Members:      make([]FamilyBiblioMember, 0, len(family.Members)),
+	}
+
+	// convertPatentFamily keeps one FamilyMember per family-member element, in order
+	for i, member := range family.Members {
+		biblioMember := FamilyBiblioMember{FamilyMember: member}
+		if docs := raw.PatentFamily.FamilyMembers[i].ExchangeDocuments; len(docs) > 0 {
+			biblioMember.Biblio = convertExchangeDocument(docs[0])
+		}
+		data.Members = append(data.Members, biblioMember)
+	}
+
+	return data, nil
+}
+
+// ParseFamilyLegal parses patent family XML retrieved with the legal constituent
+// (e.g., from GetFamilyWithLegalRaw) into family members with their legal events.
+//
+// Legal events are decoded the same way as ParseLegal, but stay attached to the
+// member they belong to, so the legal status of every family member is available
+// from a single response. Members without events have an empty LegalEvents slice.
+func ParseFamilyLegal(xmlData string) (*FamilyLegalData, error) {
+	var raw familyXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseFamilyLegal",
 			Element:   "root",
 			XMLSample: truncateXML(xmlData, 200),
 			Cause:     err,
 		}
 	}
 
-	data := &FamilyData{}
+	family := convertPatentFamily(raw.PatentFamily)
+	if len(family.Members) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseFamilyLegal",
+			MissingField: "Members",
+			Message:      "family should have at least one member",
+		}
+	}
+
+	data := &FamilyLegalData{
+		PatentNumber: family.PatentNumber,
+		FamilyID:     family.FamilyID,
+		TotalCount:   family.TotalCount,
+		Members:      make([]FamilyLegalMember, 0, len(family.Members)),
+	}
+
+	// convertPatentFamily keeps one FamilyMember per family-member element, in order
+	for i, member := range family.Members {
+		legalMember := FamilyLegalMember{FamilyMember: member}
+		for _, legal := range raw.PatentFamily.FamilyMembers[i].LegalEvents {
+			legalMember.LegalEvents = append(legalMember.LegalEvents, convertLegalEvent(legal))
+		}
+		data.Members = append(data.Members, legalMember)
+	}
+
+	return data, nil
+}
+
+// convertPatentFamily converts a single unmarshaled patent-family element into FamilyData.
+func convertPatentFamily(family patentFamilyXML) *FamilyData {
+	data := &FamilyData{Type: FamilyExtended}
 
 	// Parse patent number from publication reference
 	// Some family responses have a top-level publication-reference, others don't
-	pubRef := raw.PatentFamily.PublicationRef.DocumentID
+	pubRef := family.PublicationRef.DocumentID
 	if pubRef.Country != "" && pubRef.DocNumber != "" {
 		data.PatentNumber = pubRef.Country + pubRef.DocNumber
-	} else if len(raw.PatentFamily.FamilyMembers) > 0 {
+	} else if len(family.FamilyMembers) > 0 {
 		// If no top-level publication-reference, use first family member
-		firstMember := raw.PatentFamily.FamilyMembers[0]
-		if len(firstMember.PublicationRef.DocumentIDs) > 0 {
-			firstDoc := firstMember.PublicationRef.DocumentIDs[0]
+		firstMember := family.FamilyMembers[0]
+		if len(firstMember.PublicationRefs) > 0 && len(firstMember.PublicationRefs[0].DocumentIDs) > 0 {
+			firstDoc := firstMember.PublicationRefs[0].DocumentIDs[0]
 			data.PatentNumber = firstDoc.Country + firstDoc.DocNumber
 		}
 	}
 
 	// Patent number is optional for some family responses
-	// We'll validate we have at least family members below
+	// Callers validate we have at least family members
 
 	// Parse attributes
-	data.Legal = raw.PatentFamily.Legal == "true"
-	if raw.PatentFamily.TotalResultCount != "" {
-		if _, err := fmt.Sscanf(raw.PatentFamily.TotalResultCount, "%d", &data.TotalCount); err != nil {
+	data.Legal = family.Legal == "true"
+	if family.TotalResultCount != "" {
+		if _, err := fmt.Sscanf(family.TotalResultCount, "%d", &data.TotalCount); err != nil {
 			// Non-critical: if parsing fails, TotalCount remains 0
 		}
 	}
 
 	// Parse family members
-	for _, member := range raw.PatentFamily.FamilyMembers {
+	for _, member := range family.FamilyMembers {
 		familyMember := FamilyMember{
 			FamilyID: member.FamilyID,
 		}
 
-		// Get first publication reference (docdb format)
-		if len(member.PublicationRef.DocumentIDs) > 0 {
-			pubDoc := member.PublicationRef.DocumentIDs[0]
-			familyMember.Country = pubDoc.Country
-			familyMember.DocNumber = pubDoc.DocNumber
-			familyMember.Kind = pubDoc.Kind
-			familyMember.Date = pubDoc.Date
+		// Collect all publication document-ids (one publication-reference per publication stage)
+		for _, pubRef := range member.PublicationRefs {
+			for _, pubDoc := range pubRef.DocumentIDs {
+				familyMember.Publications = append(familyMember.Publications, PublicationReference{
+					Country:   pubDoc.Country,
+					DocNumber: pubDoc.DocNumber,
+					Kind:      pubDoc.Kind,
+					Date:      pubDoc.Date,
+					Type:      pubDoc.Type,
+				})
+			}
+		}
+
+		// Primary publication: first docdb document-id, falling back to the first entry
+		for _, pub := range familyMember.Publications {
+			if pub.Type == "docdb" {
+				familyMember.Country = pub.Country
+				familyMember.DocNumber = pub.DocNumber
+				familyMember.Kind = pub.Kind
+				familyMember.Date = pub.Date
+				break
+			}
+		}
+		if familyMember.Country == "" && len(familyMember.Publications) > 0 {
+			pub := familyMember.Publications[0]
+			familyMember.Country = pub.Country
+			familyMember.DocNumber = pub.DocNumber
+			familyMember.Kind = pub.Kind
+			familyMember.Date = pub.Date
 		}
 
 		// Parse application reference
@@ -723,19 +2286,7 @@ func ParseFamily(xmlData string) (*FamilyData, error) {
 		data.Members = append(data.Members, familyMember)
 	}
 
-	// Validate parsed data
-	// Note: FamilyID may be empty in some responses (especially simplified test data)
-	// but should be present in real EPO API responses
-
-	if len(data.Members) == 0 {
-		return nil, &DataValidationError{
-			Parser:       "ParseFamily",
-			MissingField: "Members",
-			Message:      "family should have at least one member",
-		}
-	}
-
-	return data, nil
+	return data
 }
 
 // Internal structs for Legal XML unmarshaling
@@ -889,40 +2440,542 @@ func ParseLegal(xmlData string) (*LegalData, error) {
 	pubRef := raw.PatentFamily.PublicationRef.DocumentID
 	if pubRef.Country == "" || pubRef.DocNumber == "" {
 		return nil, &DataValidationError{
-			Parser:       "ParseLegal",
-			MissingField: "publication-reference",
-			Message:      "country or doc-number is empty",
+			Parser:       "ParseLegal",
+			MissingField: "publication-reference",
+			Message:      "country or doc-number is empty",
+		}
+	}
+	data.PatentNumber = pubRef.Country + pubRef.DocNumber
+
+	// Parse legal events from all family members
+	for _, member := range raw.PatentFamily.FamilyMembers {
+		if member.FamilyID != "" {
+			data.FamilyID = member.FamilyID
+		}
+
+		for _, legal := range member.LegalEvents {
+			data.LegalEvents = append(data.LegalEvents, convertLegalEvent(legal))
+		}
+	}
+
+	return data, nil
+}
+
+// convertLegalEvent converts a single unmarshaled legal element into a LegalEvent.
+func convertLegalEvent(legal legalEventXML) LegalEvent {
+	return LegalEvent{
+		Code:        legal.Code,
+		Description: legal.Desc,
+		Influence:   legal.Infl,
+		DateMigr:    legal.DateMigr,
+		Fields:      extractLegalFields(legal), // Dynamic extraction using reflection
+	}
+}
+
+// Internal structs for register procedural steps XML unmarshaling
+type proceduralStepsXML struct {
+	XMLName           xml.Name `xml:"world-patent-data"`
+	RegisterDocuments []struct {
+		Status          string `xml:"status,attr"`
+		ProceduralSteps []struct {
+			ID    string `xml:"id,attr"`
+			Phase string `xml:"procedure-step-phase,attr"`
+			Code  string `xml:"procedural-step-code"`
+			Texts []struct {
+				Type string `xml:"step-text-type,attr"`
+				Text string `xml:",chardata"`
+			} `xml:"procedural-step-text"`
+			Dates []struct {
+				Type string `xml:"step-date-type,attr"`
+				Date string `xml:"date"`
+			} `xml:"procedural-step-date"`
+			TimeLimits []struct {
+				Unit  string `xml:"time-limit-unit,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"time-limit"`
+		} `xml:"procedural-data>procedural-step"`
+	} `xml:"register-search>register-documents>register-document"`
+}
+
+// ParseProceduralSteps parses EPO Register procedural steps XML into structured data.
+//
+// Steps are returned in response order. Only the first register-document is parsed;
+// split bulk responses per patent before parsing.
+func ParseProceduralSteps(xmlData string) (*ProceduralStepsData, error) {
+	var raw proceduralStepsXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseProceduralSteps",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if len(raw.RegisterDocuments) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseProceduralSteps",
+			MissingField: "register-document",
+			Message:      "response should contain a register document",
+		}
+	}
+
+	doc := raw.RegisterDocuments[0]
+	data := &ProceduralStepsData{
+		Status: doc.Status,
+	}
+
+	for _, rawStep := range doc.ProceduralSteps {
+		step := ProceduralStep{
+			ID:    rawStep.ID,
+			Code:  strings.TrimSpace(rawStep.Code),
+			Phase: rawStep.Phase,
+			Dates: make(map[string]string),
+			Texts: make(map[string]string),
+		}
+
+		for _, text := range rawStep.Texts {
+			value := strings.TrimSpace(text.Text)
+			if text.Type == "STEP_DESCRIPTION" {
+				step.Description = value
+			} else if value != "" {
+				step.Texts[text.Type] = value
+			}
+		}
+
+		for _, date := range rawStep.Dates {
+			value := strings.TrimSpace(date.Date)
+			if value == "" {
+				continue
+			}
+			if step.Date == "" {
+				step.Date = value
+			}
+			step.Dates[date.Type] = value
+		}
+
+		for _, limit := range rawStep.TimeLimits {
+			value := strings.TrimSpace(limit.Value)
+			if value == "" {
+				continue
+			}
+			if limit.Unit != "" {
+				value += " " + limit.Unit
+			}
+			step.Deadlines = append(step.Deadlines, value)
+		}
+
+		data.Steps = append(data.Steps, step)
+	}
+
+	return data, nil
+}
+
+// Internal structs for register biblio XML unmarshaling
+type registerDocumentIDXML struct {
+	Country   string `xml:"country"`
+	DocNumber string `xml:"doc-number"`
+	Kind      string `xml:"kind"`
+	Date      string `xml:"date"`
+}
+
+type registerBiblioXML struct {
+	XMLName           xml.Name `xml:"world-patent-data"`
+	RegisterDocuments []struct {
+		Status   string `xml:"status,attr"`
+		Statuses []struct {
+			Code string `xml:"status-code,attr"`
+			Date string `xml:"change-date,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"ep-patent-statuses>ep-patent-status"`
+		Publications []registerDocumentIDXML `xml:"bibliographic-data>publication-reference>document-id"`
+		Applications []registerDocumentIDXML `xml:"bibliographic-data>application-reference>document-id"`
+		Applicants   []struct {
+			Date  string   `xml:"change-date,attr"`
+			Names []string `xml:"applicant>addressbook>name"`
+		} `xml:"bibliographic-data>parties>applicants"`
+		Designations []struct {
+			Date     string   `xml:"change-date,attr"`
+			Regional []string `xml:"designation-pct>regional>country"`
+			National []string `xml:"designation-pct>national>country"`
+		} `xml:"bibliographic-data>designation-of-states"`
+		Titles []struct {
+			Lang string `xml:"lang,attr"`
+			Date string `xml:"change-date,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"bibliographic-data>invention-title"`
+		TermsOfGrant []struct {
+			Date   string `xml:"change-date,attr"`
+			Lapses []struct {
+				Country string `xml:"country"`
+				Date    string `xml:"date"`
+			} `xml:"lapsed-in-country"`
+		} `xml:"bibliographic-data>term-of-grant"`
+		ProceduralSteps []struct {
+			Code  string `xml:"procedural-step-code"`
+			Texts []struct {
+				Type string `xml:"step-text-type,attr"`
+				Text string `xml:",chardata"`
+			} `xml:"procedural-step-text"`
+			Dates []struct {
+				Type string `xml:"step-date-type,attr"`
+				Date string `xml:"date"`
+			} `xml:"procedural-step-date"`
+		} `xml:"procedural-data>procedural-step"`
+	} `xml:"register-search>register-documents>register-document"`
+}
+
+// latestChange returns the index of the item with the latest change-date, or -1 for
+// no items. The first of several items with the same date wins, as the Register lists
+// the current version first.
+func latestChange(n int, date func(i int) string) int {
+	latest := -1
+	for i := 0; i < n; i++ {
+		if latest < 0 || strings.TrimSpace(date(i)) > strings.TrimSpace(date(latest)) {
+			latest = i
+		}
+	}
+	return latest
+}
+
+// ParseRegisterBiblio parses EPO Register bibliographic XML (e.g., from
+// GetRegisterBiblioRaw) into structured data.
+//
+// The Register keeps the history of designations, titles, applicants and lapses;
+// the version with the latest change-date is returned. DesignatedStates lists the
+// designated contracting states without the region itself (EP). LapsedStates holds
+// the lapses recorded in term-of-grant, usually after national renewal fees were
+// not paid.
+//
+// RenewalStatus is derived from the renewal fee payments (procedural step RFEE)
+// when the response includes procedural data: a fee for year N pays the
+// application up to its Nth filing anniversary, which is stored under "EP".
+// Renewal fees paid nationally after grant are not part of the Register. Only the
+// first register-document is parsed; split bulk responses per patent before parsing.
+func ParseRegisterBiblio(xmlData string) (*RegisterBiblioData, error) {
+	var raw registerBiblioXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseRegisterBiblio",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if len(raw.RegisterDocuments) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseRegisterBiblio",
+			MissingField: "register-document",
+			Message:      "response should contain a register document",
+		}
+	}
+
+	doc := raw.RegisterDocuments[0]
+	data := &RegisterBiblioData{
+		Status:        doc.Status,
+		Titles:        make(map[string]string),
+		LapsedStates:  make(map[string]string),
+		RenewalStatus: make(map[string]string),
+	}
+
+	for _, status := range doc.Statuses {
+		data.Statuses = append(data.Statuses, RegisterStatus{
+			Code: strings.TrimSpace(status.Code),
+			Date: strings.TrimSpace(status.Date),
+			Text: strings.TrimSpace(status.Text),
+		})
+	}
+
+	if len(doc.Applications) > 0 {
+		app := doc.Applications[0]
+		data.ApplicationNumber = strings.TrimSpace(app.Country) + strings.TrimSpace(app.DocNumber)
+		data.FilingDate = strings.TrimSpace(app.Date)
+	}
+
+	for _, pub := range doc.Publications {
+		data.Publications = append(data.Publications, PublicationReference{
+			Country:   strings.TrimSpace(pub.Country),
+			DocNumber: strings.TrimSpace(pub.DocNumber),
+			Kind:      strings.TrimSpace(pub.Kind),
+			Date:      strings.TrimSpace(pub.Date),
+		})
+	}
+
+	titleDates := make(map[string]string)
+	for _, title := range doc.Titles {
+		text := strings.TrimSpace(title.Text)
+		if text == "" {
+			continue
+		}
+		if date, ok := titleDates[title.Lang]; !ok || title.Date > date {
+			titleDates[title.Lang] = title.Date
+			data.Titles[title.Lang] = text
+		}
+	}
+
+	if i := latestChange(len(doc.Applicants), func(i int) string { return doc.Applicants[i].Date }); i >= 0 {
+		for _, name := range doc.Applicants[i].Names {
+			if name = strings.TrimSpace(name); name != "" {
+				data.Applicants = append(data.Applicants, name)
+			}
+		}
+	}
+
+	if i := latestChange(len(doc.Designations), func(i int) string { return doc.Designations[i].Date }); i >= 0 {
+		seen := make(map[string]bool)
+		designation := doc.Designations[i]
+		for _, country := range append(designation.Regional, designation.National...) {
+			country = strings.TrimSpace(country)
+			if country != "" && !seen[country] {
+				seen[country] = true
+				data.DesignatedStates = append(data.DesignatedStates, country)
+			}
+		}
+	}
+
+	if i := latestChange(len(doc.TermsOfGrant), func(i int) string { return doc.TermsOfGrant[i].Date }); i >= 0 {
+		for _, lapse := range doc.TermsOfGrant[i].Lapses {
+			if country := strings.TrimSpace(lapse.Country); country != "" {
+				data.LapsedStates[country] = strings.TrimSpace(lapse.Date)
+			}
+		}
+	}
+
+	// The highest renewal year paid determines the paid-through date
+	paidYears := 0
+	for _, step := range doc.ProceduralSteps {
+		if strings.TrimSpace(step.Code) != "RFEE" {
+			continue
+		}
+		paid := false
+		for _, date := range step.Dates {
+			if date.Type == "DATE_OF_PAYMENT" && strings.TrimSpace(date.Date) != "" {
+				paid = true
+			}
+		}
+		for _, text := range step.Texts {
+			if text.Type != "YEAR" {
+				continue
+			}
+			if year, err := strconv.Atoi(strings.TrimSpace(text.Text)); err == nil && paid && year > paidYears {
+				paidYears = year
+			}
+		}
+	}
+	if paidYears > 0 {
+		if filed, err := time.Parse("20060102", data.FilingDate); err == nil {
+			data.RenewalStatus["EP"] = filed.AddDate(paidYears, 0, 0).Format("20060102")
+		}
+	}
+
+	return data, nil
+}
+
+// Internal structs for register events XML unmarshaling
+type registerEventsXML struct {
+	Events []struct {
+		ID    string   `xml:"id,attr"`
+		Date  string   `xml:"event-date>date"`
+		Code  string   `xml:"event-code"`
+		Texts []string `xml:"event-text"`
+	} `xml:"dossier-event"`
+}
+
+type registerDocumentEventsXML struct {
+	XMLName           xml.Name `xml:"world-patent-data"`
+	RegisterDocuments []struct {
+		Status   string `xml:"status,attr"`
+		Statuses []struct {
+			Date string `xml:"change-date,attr"`
+		} `xml:"ep-patent-statuses>ep-patent-status"`
+		Events []registerEventsXML `xml:"events-data"`
+	} `xml:"register-search>register-documents>register-document"`
+}
+
+// ParseRegisterEvents parses EPO Register events XML (e.g., from GetRegisterEventsRaw)
+// into structured events.
+//
+// Events are returned in response order, most recent first. The Register does not
+// link events to status changes, so ImpactOnStatus is set for events recorded on a
+// change-date of the ep-patent-statuses history; several events of that day may be
+// flagged. Only the first register-document is parsed; split bulk responses per
+// patent before parsing.
+func ParseRegisterEvents(xmlData string) (*RegisterEventsData, error) {
+	var raw registerDocumentEventsXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseRegisterEvents",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if len(raw.RegisterDocuments) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseRegisterEvents",
+			MissingField: "register-document",
+			Message:      "response should contain a register document",
+		}
+	}
+
+	doc := raw.RegisterDocuments[0]
+	data := &RegisterEventsData{
+		Status: doc.Status,
+	}
+
+	statusDates := make(map[string]bool)
+	for _, status := range doc.Statuses {
+		if date := strings.TrimSpace(status.Date); date != "" {
+			statusDates[date] = true
+		}
+	}
+
+	for _, group := range doc.Events {
+		for _, rawEvent := range group.Events {
+			event := RegisterEvent{
+				ID:   rawEvent.ID,
+				Code: strings.TrimSpace(rawEvent.Code),
+				Date: strings.TrimSpace(rawEvent.Date),
+			}
+			for _, text := range rawEvent.Texts {
+				if text = strings.TrimSpace(text); text != "" {
+					event.Description = text
+					break
+				}
+			}
+			event.ImpactOnStatus = statusDates[event.Date]
+			data.Events = append(data.Events, event)
+		}
+	}
+
+	return data, nil
+}
+
+// Internal structs for register unitary patent XML unmarshaling
+type unitaryPatentXML struct {
+	XMLName           xml.Name `xml:"world-patent-data"`
+	RegisterDocuments []struct {
+		Events        []registerEventsXML `xml:"events-data"`
+		UnitaryPatent *struct {
+			Status      string `xml:"unitary-patent-status,attr"`
+			RequestDate string `xml:"date-of-request,attr"`
+			Statuses    []struct {
+				Code string `xml:"status-code,attr"`
+				Date string `xml:"change-date,attr"`
+				Text string `xml:",chardata"`
+			} `xml:"unitary-patent-statuses>unitary-patent-status"`
+			AffectedStates []string            `xml:"procedural-data>procedural-step>procedural-step-affected-states>country"`
+			Events         []registerEventsXML `xml:"events-data"`
+		} `xml:"unitary-patent"`
+	} `xml:"register-search>register-documents>register-document"`
+}
+
+// ParseRegisterUNIP parses EPO Register unitary patent package XML (e.g., from
+// GetRegisterUNIPRaw) into unitary effect and UPC opt-out status.
+//
+// The parser expects XML, the default response format; JSON responses (requested
+// with WithAcceptOverride) are rejected with a DataValidationError.
+//
+// Unitary effect and participating states are read from the unitary-patent element.
+// The Register has no dedicated opt-out element, so UPCOptOut is derived from the
+// dossier events mentioning an opt-out: the most recent one decides, and an event
+// withdrawing the opt-out clears it. Patents without UPP data yield an empty
+// UnitaryPatentData rather than an error.
+func ParseRegisterUNIP(data string) (*UnitaryPatentData, error) {
+	if DetectFormat([]byte(data)) == PayloadJSON {
+		return nil, &DataValidationError{
+			Parser:  "ParseRegisterUNIP",
+			Message: "expected XML response, got JSON",
+		}
+	}
+
+	var raw unitaryPatentXML
+	if err := xml.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseRegisterUNIP",
+			Element:   "root",
+			XMLSample: truncateXML(data, 200),
+			Cause:     err,
+		}
+	}
+
+	if len(raw.RegisterDocuments) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseRegisterUNIP",
+			MissingField: "register-document",
+			Message:      "response should contain a register document",
 		}
 	}
-	data.PatentNumber = pubRef.Country + pubRef.DocNumber
 
-	// Parse legal events from all family members
-	for _, member := range raw.PatentFamily.FamilyMembers {
-		if member.FamilyID != "" {
-			data.FamilyID = member.FamilyID
+	doc := raw.RegisterDocuments[0]
+	result := &UnitaryPatentData{}
+	events := doc.Events
+
+	if up := doc.UnitaryPatent; up != nil {
+		result.Status = strings.TrimSpace(up.Status)
+		result.RequestDate = strings.TrimSpace(up.RequestDate)
+		result.UnitaryEffectRegistered = isUnitaryEffectRegistered(result.Status)
+
+		for _, status := range up.Statuses {
+			entry := UnitaryPatentStatus{
+				Code: strings.TrimSpace(status.Code),
+				Date: strings.TrimSpace(status.Date),
+				Text: strings.TrimSpace(status.Text),
+			}
+			if isUnitaryEffectRegistered(entry.Text) {
+				result.UnitaryEffectRegistered = true
+			}
+			result.Statuses = append(result.Statuses, entry)
 		}
 
-		for _, legal := range member.LegalEvents {
-			event := LegalEvent{
-				Code:        legal.Code,
-				Description: legal.Desc,
-				Influence:   legal.Infl,
-				DateMigr:    legal.DateMigr,
-				Fields:      extractLegalFields(legal), // Dynamic extraction using reflection
+		seen := make(map[string]bool)
+		for _, state := range up.AffectedStates {
+			state = strings.TrimSpace(state)
+			if state != "" && !seen[state] {
+				seen[state] = true
+				result.ParticipatingStates = append(result.ParticipatingStates, state)
 			}
+		}
 
-			data.LegalEvents = append(data.LegalEvents, event)
+		events = append(events, up.Events...)
+	}
+
+	// The most recent opt-out related event determines the opt-out status
+	var latestDate string
+	for _, group := range events {
+		for _, event := range group.Events {
+			text := strings.ToLower(strings.Join(event.Texts, " "))
+			if !strings.Contains(text, "opt-out") && !strings.Contains(text, "opt out") {
+				continue
+			}
+			date := strings.TrimSpace(event.Date)
+			if date < latestDate {
+				continue
+			}
+			latestDate = date
+			result.UPCOptOut = !strings.Contains(text, "withdraw")
+			result.OptOutDate = ""
+			if result.UPCOptOut {
+				result.OptOutDate = date
+			}
 		}
 	}
 
-	return data, nil
+	return result, nil
+}
+
+// isUnitaryEffectRegistered reports whether a unitary patent status text denotes registered unitary effect.
+func isUnitaryEffectRegistered(status string) bool {
+	return strings.Contains(strings.ToLower(status), "unitary effect registered")
 }
 
 // Internal structs for Description XML unmarshaling
 type descriptionXML struct {
 	XMLName           xml.Name `xml:"world-patent-data"`
 	FulltextDocuments struct {
-		FulltextDocument struct {
+		FulltextDocument *struct {
 			Country    string `xml:"country,attr"`
 			DocNumber  string `xml:"doc-number,attr"`
 			Kind       string `xml:"kind,attr"`
@@ -936,19 +2989,16 @@ type descriptionXML struct {
 				} `xml:"publication-reference"`
 			} `xml:"bibliographic-data"`
 			Description struct {
-				Lang       string `xml:"lang,attr"`
-				Paragraphs []struct {
-					ID   string `xml:"id,attr"`
-					Num  string `xml:"num,attr"`
-					Text string `xml:",chardata"`
-				} `xml:"p"`
+				Lang       string         `xml:"lang,attr"`
+				Paragraphs []paragraphXML `xml:"p"`
 			} `xml:"description"`
 		} `xml:"fulltext-document"`
 	} `xml:"fulltext-documents"`
 }
 
-// ParseDescription parses description XML into structured data
-func ParseDescription(xmlData string) (*DescriptionData, error) {
+// ParseDescription parses description XML into structured data.
+// Pass WithTextNormalization to clean up the text for further processing.
+func ParseDescription(xmlData string, opts ...ParseOption) (*DescriptionData, error) {
 	var raw descriptionXML
 	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
 		return nil, &XMLParseError{
@@ -960,6 +3010,13 @@ func ParseDescription(xmlData string) (*DescriptionData, error) {
 	}
 
 	doc := raw.FulltextDocuments.FulltextDocument
+	if doc == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseDescription",
+			MissingField: "fulltext-document",
+			Message:      "response contains no fulltext-document",
+		}
+	}
 	data := &DescriptionData{
 		Country:   doc.Country,
 		DocNumber: doc.DocNumber,
@@ -977,21 +3034,81 @@ func ParseDescription(xmlData string) (*DescriptionData, error) {
 
 	// Parse paragraphs
 	for _, p := range doc.Description.Paragraphs {
-		data.Paragraphs = append(data.Paragraphs, Paragraph{
-			ID:   p.ID,
-			Num:  p.Num,
-			Text: strings.TrimSpace(p.Text),
-		})
+		data.Paragraphs = append(data.Paragraphs, p.paragraph())
+	}
+
+	if newParseOptions(opts).normalizeText {
+		data.normalizeText()
 	}
 
 	return data, nil
 }
 
+// StreamDescription parses description XML from r, calling fn for each paragraph as soon
+// as it has been decoded.
+//
+// Unlike ParseDescription, the document is never held in memory as a whole, which keeps
+// memory usage flat for multi-megabyte descriptions. Paragraph text is trimmed exactly as
+// in ParseDescription. If fn returns an error, parsing stops and that error is returned
+// unchanged.
+//
+// Example:
+//
+//	err := ops.StreamDescription(file, func(p ops.Paragraph) error {
+//	    _, err := fmt.Fprintf(out, "[%s] %s\n", p.Num, p.Text)
+//	    return err
+//	})
+func StreamDescription(r io.Reader, fn func(Paragraph) error) error {
+	decoder := xml.NewDecoder(r)
+	inDescription := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return &XMLParseError{
+				Parser:  "StreamDescription",
+				Element: "description",
+				Cause:   err,
+			}
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "description" {
+				inDescription = true
+				continue
+			}
+			if !inDescription || t.Name.Local != "p" {
+				continue
+			}
+
+			var p paragraphXML
+			if err := decoder.DecodeElement(&p, &t); err != nil {
+				return &XMLParseError{
+					Parser:  "StreamDescription",
+					Element: "p",
+					Cause:   err,
+				}
+			}
+			if err := fn(p.paragraph()); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if t.Name.Local == "description" {
+				inDescription = false
+			}
+		}
+	}
+}
+
 // Internal structs for Fulltext XML unmarshaling
 type fulltextXML struct {
 	XMLName           xml.Name `xml:"world-patent-data"`
 	FulltextDocuments struct {
-		FulltextDocument struct {
+		FulltextDocument *struct {
 			Country   string `xml:"country,attr"`
 			DocNumber string `xml:"doc-number,attr"`
 			Kind      string `xml:"kind,attr"`
@@ -1001,119 +3118,519 @@ type fulltextXML struct {
 	} `xml:"fulltext-documents"`
 }
 
-// ParseFulltext parses fulltext XML into structured data by reusing existing parsers
-func ParseFulltext(xmlData string) (*FulltextData, error) {
-	var raw fulltextXML
-	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal fulltext XML: %w", err)
+// fulltextFrontXML holds the bibliographic data and abstracts of a fulltext-document,
+// which use the same layout as in an exchange-document.
+type fulltextFrontXML struct {
+	XMLName  xml.Name `xml:"world-patent-data"`
+	Document *struct {
+		exchangeDocumentXML
+		Abstracts []abstractEntryXML `xml:"abstract"`
+	} `xml:"fulltext-documents>fulltext-document"`
+}
+
+// ParseFulltext parses fulltext XML into structured data by reusing existing parsers
+func ParseFulltext(xmlData string) (*FulltextData, error) {
+	return ParseFulltextSections(xmlData)
+}
+
+// fulltextSections are the sections ParseFulltextSections accepts, in parsing order.
+var fulltextSections = []string{EndpointBiblio, EndpointAbstract, EndpointDescription, EndpointClaims}
+
+// ParseFulltextSections is like ParseFulltext but only parses the given sections
+// ("biblio", "abstract", "description", "claims"; see EndpointBiblio etc.), leaving
+// the others nil. Without sections, all of them are parsed.
+//
+// Each section is parsed in a separate pass over the document, so skipping the
+// description of a large fulltext response saves most of the work when only the
+// claims are needed. Requested sections are never nil: a section the document does
+// not contain is returned empty, and a section that fails to parse fails the call with
+// the parser's error. Unknown section names are rejected with a ValidationError.
+func ParseFulltextSections(xmlData string, sections ...string) (*FulltextData, error) {
+	if len(sections) == 0 {
+		sections = fulltextSections
+	}
+	wanted := make(map[string]bool)
+	for _, section := range sections {
+		if !slices.Contains(fulltextSections, section) {
+			return nil, &ValidationError{
+				Field:   "sections",
+				Value:   section,
+				Message: "unknown fulltext section, expected one of " + strings.Join(fulltextSections, ", "),
+			}
+		}
+		wanted[section] = true
+	}
+
+	var raw fulltextXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseFulltext",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	doc := raw.FulltextDocuments.FulltextDocument
+	if doc == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseFulltext",
+			MissingField: "fulltext-document",
+			Message:      "response contains no fulltext-document",
+		}
+	}
+	data := &FulltextData{
+		Country:   doc.Country,
+		DocNumber: doc.DocNumber,
+		Kind:      doc.Kind,
+		Language:  doc.Lang,
+		Status:    doc.Status,
+	}
+
+	// Bibliographic data and abstracts are children of the fulltext-document itself,
+	// not of an exchange-document, so ParseBiblio and ParseAbstract do not apply
+	if wanted[EndpointBiblio] || wanted[EndpointAbstract] {
+		var front fulltextFrontXML
+		if err := xml.Unmarshal([]byte(xmlData), &front); err != nil {
+			return nil, &XMLParseError{
+				Parser:    "ParseFulltext",
+				Element:   "fulltext-document",
+				XMLSample: truncateXML(xmlData, 200),
+				Cause:     err,
+			}
+		}
+		if wanted[EndpointBiblio] {
+			data.Biblio = convertExchangeDocument(front.Document.exchangeDocumentXML)
+		}
+		if wanted[EndpointAbstract] {
+			data.Abstract = newAbstractData(doc.Country, doc.DocNumber, doc.Kind, front.Document.Abstracts)
+		}
+	}
+
+	// Description and claims are parsed separately using the existing parsers
+	if wanted[EndpointDescription] {
+		description, err := ParseDescription(xmlData)
+		if err != nil {
+			return nil, err
+		}
+		data.Description = description
+	}
+
+	if wanted[EndpointClaims] {
+		claims, err := ParseClaims(xmlData)
+		if err != nil {
+			return nil, err
+		}
+		data.Claims = claims
+	}
+
+	return data, nil
+}
+
+// Internal structs for number conversion XML unmarshaling
+type numberConversionXML struct {
+	XMLName         xml.Name `xml:"world-patent-data"`
+	Standardization *struct {
+		InputFormat  string `xml:"inputFormat,attr"`
+		OutputFormat string `xml:"outputFormat,attr"`
+		// The output holds a publication-reference, application-reference or
+		// priority-claim depending on the reference type
+		Output struct {
+			References []struct {
+				DocumentID struct {
+					Country   string `xml:"country"`
+					DocNumber string `xml:"doc-number"`
+					Kind      string `xml:"kind"`
+					Date      string `xml:"date"`
+				} `xml:"document-id"`
+			} `xml:",any"`
+		} `xml:"output"`
+	} `xml:"standardization"`
+}
+
+// ParseNumberConversion parses a number conversion response (e.g., from
+// ConvertPatentNumber) into the converted number.
+//
+// Number is built for the output format: "CC.number.kind" for docdb and the
+// doc-number followed by the kind for epodoc, so it can be passed to other client
+// methods as is. For original output it is the doc-number as EPO returns it.
+func ParseNumberConversion(xmlData string) (*NumberConversionData, error) {
+	var raw numberConversionXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseNumberConversion",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if raw.Standardization == nil || len(raw.Standardization.Output.References) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseNumberConversion",
+			MissingField: "output",
+			Message:      "response should contain a converted number",
+		}
+	}
+
+	id := raw.Standardization.Output.References[0].DocumentID
+	data := &NumberConversionData{
+		InputFormat:  raw.Standardization.InputFormat,
+		OutputFormat: raw.Standardization.OutputFormat,
+		Country:      strings.TrimSpace(id.Country),
+		DocNumber:    strings.TrimSpace(id.DocNumber),
+		Kind:         strings.TrimSpace(id.Kind),
+		Date:         strings.TrimSpace(id.Date),
+	}
+	if data.DocNumber == "" {
+		return nil, &DataValidationError{
+			Parser:       "ParseNumberConversion",
+			MissingField: "doc-number",
+			Message:      "converted number has no doc-number",
+		}
+	}
+
+	switch data.OutputFormat {
+	case FormatDocDB:
+		parts := []string{data.Country, data.DocNumber}
+		if data.Kind != "" {
+			parts = append(parts, data.Kind)
+		}
+		data.Number = strings.Join(parts, ".")
+	case FormatEPODOC:
+		data.Number = data.DocNumber + data.Kind
+	default:
+		data.Number = data.DocNumber
+	}
+
+	return data, nil
+}
+
+// Internal structs for Search XML unmarshaling
+type searchXML struct {
+	XMLName      xml.Name `xml:"world-patent-data"`
+	BiblioSearch *struct {
+		TotalResultCount string `xml:"total-result-count,attr"`
+		Query            string `xml:"query"`
+		Range            struct {
+			Begin string `xml:"begin,attr"`
+			End   string `xml:"end,attr"`
+		} `xml:"range"`
+		ExchangeDocuments struct {
+			Documents []struct {
+				System     string `xml:"system,attr"`
+				FamilyID   string `xml:"family-id,attr"`
+				Country    string `xml:"country,attr"`
+				DocNumber  string `xml:"doc-number,attr"`
+				Kind       string `xml:"kind,attr"`
+				BiblioData struct {
+					InventionTitle []struct {
+						Lang string `xml:"lang,attr"`
+						Text string `xml:",chardata"`
+					} `xml:"invention-title"`
+				} `xml:"bibliographic-data"`
+			} `xml:"exchange-document"`
+		} `xml:"exchange-documents"`
+	} `xml:"biblio-search"`
+}
+
+// ParseSearch parses search result XML into structured data
+func ParseSearch(xmlData string) (*SearchResultData, error) {
+	var raw searchXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseSearch",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	// A search that matches nothing still has a biblio-search element (with a zero
+	// count), so its absence means the response is not a search result at all
+	if raw.BiblioSearch == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseSearch",
+			MissingField: "biblio-search",
+			Message:      "response does not contain a biblio-search element",
+		}
+	}
+
+	data := &SearchResultData{
+		Query: raw.BiblioSearch.Query,
+	}
+
+	// Parse counts and ranges
+	if raw.BiblioSearch.TotalResultCount != "" {
+		if _, err := fmt.Sscanf(raw.BiblioSearch.TotalResultCount, "%d", &data.TotalCount); err != nil {
+			// Non-critical: if parsing fails, TotalCount remains 0
+		}
+	}
+	if raw.BiblioSearch.Range.Begin != "" {
+		if _, err := fmt.Sscanf(raw.BiblioSearch.Range.Begin, "%d", &data.RangeBegin); err != nil {
+			// Non-critical: if parsing fails, RangeBegin remains 0
+		}
+	}
+	if raw.BiblioSearch.Range.End != "" {
+		if _, err := fmt.Sscanf(raw.BiblioSearch.Range.End, "%d", &data.RangeEnd); err != nil {
+			// Non-critical: if parsing fails, RangeEnd remains 0
+		}
+	}
+
+	// Parse results
+	for _, doc := range raw.BiblioSearch.ExchangeDocuments.Documents {
+		result := SearchResult{
+			System:    doc.System,
+			FamilyID:  doc.FamilyID,
+			Country:   doc.Country,
+			DocNumber: doc.DocNumber,
+			Kind:      doc.Kind,
+		}
+
+		// Get title (prefer English, fall back to first available)
+		for _, title := range doc.BiblioData.InventionTitle {
+			if title.Lang == "en" || result.Title == "" {
+				result.Title = title.Text
+			}
+		}
+
+		data.Results = append(data.Results, result)
+	}
+
+	return data, nil
+}
+
+// Internal structs for register search XML unmarshaling
+type registerSearchXML struct {
+	XMLName        xml.Name `xml:"world-patent-data"`
+	RegisterSearch *struct {
+		TotalResultCount string `xml:"total-result-count,attr"`
+		Query            string `xml:"query"`
+		Range            struct {
+			Begin string `xml:"begin,attr"`
+			End   string `xml:"end,attr"`
+		} `xml:"range"`
+		Documents []struct {
+			Status     string `xml:"status,attr"`
+			BiblioData struct {
+				Publications []struct {
+					Country   string `xml:"country"`
+					DocNumber string `xml:"doc-number"`
+					Date      string `xml:"date"`
+				} `xml:"publication-reference>document-id"`
+				Applications []struct {
+					Country   string `xml:"country"`
+					DocNumber string `xml:"doc-number"`
+				} `xml:"application-reference>document-id"`
+				InventionTitle []struct {
+					Lang string `xml:"lang,attr"`
+					Text string `xml:",chardata"`
+				} `xml:"invention-title"`
+			} `xml:"bibliographic-data"`
+		} `xml:"register-documents>register-document"`
+	} `xml:"register-search"`
+}
+
+// ParseRegisterSearch parses EPO Register search XML (e.g., from SearchRegister)
+// into structured results.
+//
+// Each register-document yields one result with its first publication and application
+// reference. A search without matches yields empty data; a response without a
+// register-search element returns a DataValidationError.
+func ParseRegisterSearch(data string) (*RegisterSearchData, error) {
+	var raw registerSearchXML
+	if err := xml.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseRegisterSearch",
+			Element:   "root",
+			XMLSample: truncateXML(data, 200),
+			Cause:     err,
+		}
+	}
+
+	if raw.RegisterSearch == nil {
+		return nil, &DataValidationError{
+			Parser:       "ParseRegisterSearch",
+			MissingField: "register-search",
+			Message:      "response does not contain a register-search element",
+		}
+	}
+
+	search := raw.RegisterSearch
+	result := &RegisterSearchData{
+		Query: strings.TrimSpace(search.Query),
+	}
+	result.TotalCount, _ = strconv.Atoi(strings.TrimSpace(search.TotalResultCount))
+	result.RangeBegin, _ = strconv.Atoi(strings.TrimSpace(search.Range.Begin))
+	result.RangeEnd, _ = strconv.Atoi(strings.TrimSpace(search.Range.End))
+
+	for _, doc := range search.Documents {
+		entry := RegisterSearchResult{
+			Status: strings.TrimSpace(doc.Status),
+		}
+		if pubs := doc.BiblioData.Publications; len(pubs) > 0 {
+			entry.Country = strings.TrimSpace(pubs[0].Country)
+			entry.PublicationNumber = strings.TrimSpace(pubs[0].DocNumber)
+			entry.PublicationDate = strings.TrimSpace(pubs[0].Date)
+		}
+		if apps := doc.BiblioData.Applications; len(apps) > 0 {
+			entry.ApplicationNumber = strings.TrimSpace(apps[0].Country) + strings.TrimSpace(apps[0].DocNumber)
+		}
+
+		// Get title (prefer English, fall back to first available)
+		for _, title := range doc.BiblioData.InventionTitle {
+			if title.Lang == "en" || entry.Title == "" {
+				entry.Title = strings.TrimSpace(title.Text)
+			}
+		}
+
+		result.Results = append(result.Results, entry)
 	}
 
-	doc := raw.FulltextDocuments.FulltextDocument
-	data := &FulltextData{
-		Country:   doc.Country,
-		DocNumber: doc.DocNumber,
-		Kind:      doc.Kind,
-		Language:  doc.Lang,
-		Status:    doc.Status,
-	}
+	return result, nil
+}
 
-	// Try to parse each section separately using existing parsers
-	// If a section fails, we continue with the others
+// searchBiblioXML holds the exchange-documents of a search with the biblio constituent.
+// OPS wraps them in search-result; the flat layout used by ParseSearch is accepted too.
+type searchBiblioXML struct {
+	XMLName      xml.Name `xml:"world-patent-data"`
+	BiblioSearch struct {
+		SearchResult []exchangeDocumentXML `xml:"search-result>exchange-documents>exchange-document"`
+		Documents    []exchangeDocumentXML `xml:"exchange-documents>exchange-document"`
+	} `xml:"biblio-search"`
+}
 
-	if biblio, err := ParseBiblio(xmlData); err == nil {
-		data.Biblio = biblio
+// ParseSearchBiblio parses search result XML retrieved with the biblio constituent
+// (e.g., from SearchWithConstituentRaw with "biblio") into results with their
+// bibliographic data.
+//
+// Each result's exchange-document is parsed the same way as ParseBiblio, giving titles,
+// applicants, inventors and classifications per result without separate biblio requests.
+func ParseSearchBiblio(xmlData string) (*SearchBiblioData, error) {
+	var raw searchBiblioXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseSearchBiblio",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
 	}
 
-	if abstract, err := ParseAbstract(xmlData); err == nil {
-		data.Abstract = abstract
+	// Query, counts and range are parsed like a plain search
+	header, err := ParseSearch(xmlData)
+	if err != nil {
+		return nil, err
 	}
 
-	if description, err := ParseDescription(xmlData); err == nil {
-		data.Description = description
+	data := &SearchBiblioData{
+		Query:      header.Query,
+		TotalCount: header.TotalCount,
+		RangeBegin: header.RangeBegin,
+		RangeEnd:   header.RangeEnd,
 	}
 
-	if claims, err := ParseClaims(xmlData); err == nil {
-		data.Claims = claims
+	docs := append(raw.BiblioSearch.SearchResult, raw.BiblioSearch.Documents...)
+	for _, doc := range docs {
+		result := SearchBiblioResult{
+			SearchResult: SearchResult{
+				System:    doc.System,
+				FamilyID:  doc.FamilyID,
+				Country:   doc.Country,
+				DocNumber: doc.DocNumber,
+				Kind:      doc.Kind,
+			},
+		}
+
+		// Get title (prefer English, fall back to first available)
+		for _, title := range doc.BiblioData.InventionTitles {
+			if title.Lang == "en" || result.Title == "" {
+				result.Title = strings.TrimSpace(title.Text)
+			}
+		}
+
+		if len(doc.BiblioData.PublicationRef.DocumentID) > 0 || len(doc.BiblioData.InventionTitles) > 0 {
+			result.Biblio = convertExchangeDocument(doc)
+		}
+
+		data.Results = append(data.Results, result)
 	}
 
 	return data, nil
 }
 
-// Internal structs for Search XML unmarshaling
-type searchXML struct {
+// searchFullCycleXML holds the results of a search with the full-cycle constituent:
+// one exchange-documents element per result, with one exchange-document per stage.
+type searchFullCycleXML struct {
 	XMLName      xml.Name `xml:"world-patent-data"`
 	BiblioSearch struct {
-		TotalResultCount string `xml:"total-result-count,attr"`
-		Query            string `xml:"query"`
-		Range            struct {
-			Begin string `xml:"begin,attr"`
-			End   string `xml:"end,attr"`
-		} `xml:"range"`
-		ExchangeDocuments struct {
-			Documents []struct {
-				System     string `xml:"system,attr"`
-				FamilyID   string `xml:"family-id,attr"`
-				Country    string `xml:"country,attr"`
-				DocNumber  string `xml:"doc-number,attr"`
-				Kind       string `xml:"kind,attr"`
-				BiblioData struct {
-					InventionTitle []struct {
-						Lang string `xml:"lang,attr"`
-						Text string `xml:",chardata"`
-					} `xml:"invention-title"`
-				} `xml:"bibliographic-data"`
-			} `xml:"exchange-document"`
-		} `xml:"exchange-documents"`
+		Results []struct {
+			Documents []exchangeDocumentXML `xml:"exchange-document"`
+		} `xml:"search-result>exchange-documents"`
 	} `xml:"biblio-search"`
 }
 
-// ParseSearch parses search result XML into structured data
-func ParseSearch(xmlData string) (*SearchResultData, error) {
-	var raw searchXML
+// ParseSearchFullCycle parses search result XML retrieved with the full-cycle
+// constituent (e.g., from SearchWithConstituentRaw with "full-cycle") into results with
+// their complete publication history.
+//
+// Each result's exchange-documents are parsed the same way as ParseFullCycle, so every
+// hit carries its stages (e.g., A1 application and B1 grant) ordered by publication
+// date. The result itself is the first document of each group, as returned by EPO.
+//
+// Example:
+//
+//	xmlData, _ := client.SearchWithConstituentRaw(ctx, ops.ConstituentFullCycle, "ti=bluetooth", "1-10")
+//	results, err := ops.ParseSearchFullCycle(xmlData)
+//	for _, result := range results.Results {
+//	    for _, stage := range result.FullCycle.Stages {
+//	        fmt.Println(result.DocNumber, stage.Kind, stage.Date)
+//	    }
+//	}
+func ParseSearchFullCycle(xmlData string) (*SearchFullCycleData, error) {
+	var raw searchFullCycleXML
 	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
 		return nil, &XMLParseError{
-			Parser:    "ParseSearch",
+			Parser:    "ParseSearchFullCycle",
 			Element:   "root",
 			XMLSample: truncateXML(xmlData, 200),
 			Cause:     err,
 		}
 	}
 
-	data := &SearchResultData{
-		Query: raw.BiblioSearch.Query,
+	// Query, counts and range are parsed like a plain search
+	header, err := ParseSearch(xmlData)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse counts and ranges
-	if raw.BiblioSearch.TotalResultCount != "" {
-		if _, err := fmt.Sscanf(raw.BiblioSearch.TotalResultCount, "%d", &data.TotalCount); err != nil {
-			// Non-critical: if parsing fails, TotalCount remains 0
-		}
-	}
-	if raw.BiblioSearch.Range.Begin != "" {
-		if _, err := fmt.Sscanf(raw.BiblioSearch.Range.Begin, "%d", &data.RangeBegin); err != nil {
-			// Non-critical: if parsing fails, RangeBegin remains 0
-		}
-	}
-	if raw.BiblioSearch.Range.End != "" {
-		if _, err := fmt.Sscanf(raw.BiblioSearch.Range.End, "%d", &data.RangeEnd); err != nil {
-			// Non-critical: if parsing fails, RangeEnd remains 0
-		}
+	data := &SearchFullCycleData{
+		Query:      header.Query,
+		TotalCount: header.TotalCount,
+		RangeBegin: header.RangeBegin,
+		RangeEnd:   header.RangeEnd,
 	}
 
-	// Parse results
-	for _, doc := range raw.BiblioSearch.ExchangeDocuments.Documents {
-		result := SearchResult{
-			System:    doc.System,
-			FamilyID:  doc.FamilyID,
-			Country:   doc.Country,
-			DocNumber: doc.DocNumber,
-			Kind:      doc.Kind,
+	for _, group := range raw.BiblioSearch.Results {
+		if len(group.Documents) == 0 {
+			continue
+		}
+		doc := group.Documents[0]
+		result := SearchFullCycleResult{
+			SearchResult: SearchResult{
+				System:    doc.System,
+				FamilyID:  doc.FamilyID,
+				Country:   doc.Country,
+				DocNumber: doc.DocNumber,
+				Kind:      doc.Kind,
+			},
+			FullCycle: newFullCycleData(group.Documents),
 		}
 
 		// Get title (prefer English, fall back to first available)
-		for _, title := range doc.BiblioData.InventionTitle {
+		for _, title := range doc.BiblioData.InventionTitles {
 			if title.Lang == "en" || result.Title == "" {
-				result.Title = title.Text
+				result.Title = strings.TrimSpace(title.Text)
 			}
 		}
 
@@ -1196,3 +3713,447 @@ func ParseEquivalents(xmlData string) (*EquivalentsData, error) {
 
 	return data, nil
 }
+
+// Internal structs for Classification Mapping XML unmarshaling
+type classificationMappingXML struct {
+	XMLName  xml.Name `xml:"world-patent-data"`
+	Mappings struct {
+		InputSchema  string `xml:"inputSchema,attr"`
+		OutputSchema string `xml:"outputSchema,attr"`
+		Mapping      []struct {
+			AdditionalOnly string `xml:"additional-only,attr"`
+			// Symbols are elements named after their scheme (e.g., <ops:ecla>, <ops:cpc>)
+			Symbols []struct {
+				XMLName xml.Name
+				Text    string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"mapping"`
+	} `xml:"classification-scheme>mappings"`
+}
+
+// ParseClassificationMapping parses classification mapping XML (e.g., from
+// GetClassificationMappingRaw) into structured data.
+//
+// Each mapping element holds the input symbol, named after the input scheme, and
+// the mapped symbol in the output scheme. Mappings flagged additional-only are
+// returned with Additional set.
+func ParseClassificationMapping(xmlData string) (*ClassificationMappingResult, error) {
+	var raw classificationMappingXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseClassificationMapping",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if raw.Mappings.InputSchema == "" {
+		return nil, &DataValidationError{
+			Parser:       "ParseClassificationMapping",
+			MissingField: "mappings",
+			Message:      "response contains no classification mappings",
+		}
+	}
+
+	data := &ClassificationMappingResult{
+		InputScheme:  raw.Mappings.InputSchema,
+		OutputScheme: raw.Mappings.OutputSchema,
+	}
+
+	for _, mapping := range raw.Mappings.Mapping {
+		additional := mapping.AdditionalOnly == "true"
+		for _, symbol := range mapping.Symbols {
+			text := strings.TrimSpace(symbol.Text)
+			if text == "" {
+				continue
+			}
+			if strings.EqualFold(symbol.XMLName.Local, data.InputScheme) {
+				if data.Input == "" {
+					data.Input = text
+				}
+				continue
+			}
+			data.Mappings = append(data.Mappings, MappedClass{
+				Symbol:     text,
+				Scheme:     strings.ToUpper(symbol.XMLName.Local),
+				Additional: additional,
+			})
+		}
+	}
+
+	return data, nil
+}
+
+// Internal struct for classification-item elements of classification schema responses.
+// Items nest when ancestors or children are included.
+type classificationItemXML struct {
+	Level            string `xml:"level,attr"`
+	NotAllocatable   string `xml:"not-allocatable,attr"`
+	DefinitionExists string `xml:"definition-exists,attr"`
+	Symbol           string `xml:"classification-symbol"`
+	TitleParts       []struct {
+		Texts []string `xml:"text"`
+	} `xml:"class-title>title-part"`
+	Notes []struct {
+		Type       string       `xml:"type,attr"`
+		Paragraphs []markupText `xml:"note-paragraph"`
+	} `xml:"notes-and-warnings>note"`
+	Children []classificationItemXML `xml:"classification-item"`
+}
+
+// title returns the class title of the item, title parts joined with "; ".
+func (item classificationItemXML) title() string {
+	var parts []string
+	for _, part := range item.TitleParts {
+		parts = append(parts, part.Texts...)
+	}
+	return joinClassificationTitle(parts)
+}
+
+// ParseClassificationTitles parses a classification schema response (e.g., from
+// GetClassificationSchemaRaw or GetClassificationSchemaMultipleRaw) into the titles of
+// all classification items it contains, keyed by symbol as EPO writes it (e.g.,
+// "H04W84/20"). Title parts are joined with "; "; explanations are not included.
+func ParseClassificationTitles(xmlData string) (map[string]string, error) {
+	parseErr := func(err error) error {
+		return &XMLParseError{
+			Parser:    "ParseClassificationTitles",
+			Element:   "classification-item",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	titles := make(map[string]string)
+	var collect func(item classificationItemXML)
+	collect = func(item classificationItemXML) {
+		if symbol := strings.TrimSpace(item.Symbol); symbol != "" {
+			titles[symbol] = item.title()
+		}
+		for _, child := range item.Children {
+			collect(child)
+		}
+	}
+
+	// Items are wrapped differently by the single and multiple schema services
+	decoder := xml.NewDecoder(strings.NewReader(xmlData))
+	schemeFound := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, parseErr(err)
+		}
+		start, ok := token.(xml.StartElement)
+		if ok && start.Name.Local == "class-scheme" {
+			schemeFound = true
+		}
+		if !ok || start.Name.Local != "classification-item" {
+			continue
+		}
+		var item classificationItemXML
+		if err := decoder.DecodeElement(&item, &start); err != nil {
+			return nil, parseErr(err)
+		}
+		collect(item)
+	}
+
+	// A scheme without items is valid, a response without any scheme is not
+	if !schemeFound {
+		return nil, &DataValidationError{
+			Parser:       "ParseClassificationTitles",
+			MissingField: "class-scheme",
+			Message:      "response contains no class-scheme",
+		}
+	}
+
+	return titles, nil
+}
+
+// Internal structs for classification schema XML unmarshaling
+type classificationSchemaXML struct {
+	XMLName xml.Name `xml:"world-patent-data"`
+	Schemes []struct {
+		ExportDate string                  `xml:"export-date,attr"`
+		Items      []classificationItemXML `xml:"classification-item"`
+	} `xml:"classification-scheme>cpc>class-scheme"`
+}
+
+// newClassificationNode converts a classification item and its children.
+func newClassificationNode(item classificationItemXML) ClassificationNode {
+	level, _ := strconv.Atoi(strings.TrimSpace(item.Level))
+	node := ClassificationNode{
+		Symbol:           strings.TrimSpace(item.Symbol),
+		Level:            level,
+		Title:            item.title(),
+		DefinitionExists: item.DefinitionExists == "true",
+		NotAllocatable:   item.NotAllocatable == "true",
+	}
+	for _, note := range item.Notes {
+		var paragraphs []string
+		for _, p := range note.Paragraphs {
+			if text := strings.Join(strings.Fields(string(p)), " "); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+		}
+		if len(paragraphs) > 0 {
+			node.Notes = append(node.Notes, ClassificationNote{
+				Type: strings.TrimSpace(note.Type),
+				Text: strings.Join(paragraphs, "\n"),
+			})
+		}
+	}
+	for _, child := range item.Children {
+		node.Children = append(node.Children, newClassificationNode(child))
+	}
+	return node
+}
+
+// ParseClassificationSchema parses a CPC classification schema response (e.g., from
+// GetClassificationSchemaRaw or GetClassificationSchemaSubclassRaw) into a tree of
+// classification items.
+//
+// Items keep the nesting of the response: a subclass contains its main groups, and
+// main groups contain their subgroups down to the deepest level returned. Notes
+// are flattened to plain text; subnotes and class references become part of the
+// paragraph they appear in.
+func ParseClassificationSchema(xmlData string) (*ClassificationSchemaData, error) {
+	var raw classificationSchemaXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseClassificationSchema",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	if len(raw.Schemes) == 0 {
+		return nil, &DataValidationError{
+			Parser:       "ParseClassificationSchema",
+			MissingField: "class-scheme",
+			Message:      "response should contain a classification scheme",
+		}
+	}
+
+	data := &ClassificationSchemaData{ExportDate: raw.Schemes[0].ExportDate}
+	for _, scheme := range raw.Schemes {
+		for _, item := range scheme.Items {
+			data.Items = append(data.Items, newClassificationNode(item))
+		}
+	}
+	return data, nil
+}
+
+// Internal structs for Classification Statistics XML unmarshaling
+type classificationStatisticsXML struct {
+	XMLName    xml.Name `xml:"world-patent-data"`
+	Statistics []struct {
+		Symbol     string `xml:"classification-symbol,attr"`
+		Percentage string `xml:"percentage,attr"`
+		Count      string `xml:"count,attr"`
+		TitleParts []struct {
+			Texts []string `xml:"text"`
+		} `xml:"class-title>title-part"`
+	} `xml:"classification-search>search-result>classification-statistics"`
+}
+
+// Internal structs for Classification Statistics JSON unmarshaling.
+// OPS JSON maps attributes to "@name" and text to "$"; elements occurring once are
+// objects rather than one-element arrays, so repeated elements are kept raw.
+type classificationStatisticsJSON struct {
+	WorldPatentData struct {
+		Search struct {
+			SearchResult json.RawMessage `json:"ops:search-result"`
+		} `json:"ops:classification-search"`
+	} `json:"ops:world-patent-data"`
+}
+
+type classificationStatJSON struct {
+	Symbol     string `json:"@classification-symbol"`
+	Percentage string `json:"@percentage"`
+	Count      string `json:"@count"`
+	ClassTitle struct {
+		TitleParts json.RawMessage `json:"cpc:title-part"`
+	} `json:"cpc:class-title"`
+}
+
+// ParseClassificationStatistics parses a classification statistics search response
+// (e.g., from GetClassificationStatisticsRaw) in either XML or JSON format.
+//
+// EPO reports the share of matching documents per classification as a percentage;
+// Count is only set if the response also carries absolute counts. The result is
+// sorted by descending share (Percentage, then Count); equal entries keep response
+// order. A search without matches yields an empty slice and no error.
+func ParseClassificationStatistics(data string) ([]ClassificationStat, error) {
+	var stats []ClassificationStat
+	var err error
+	if DetectFormat([]byte(data)) == PayloadJSON {
+		stats, err = parseClassificationStatisticsJSON(data)
+	} else {
+		stats, err = parseClassificationStatisticsXML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Percentage != stats[j].Percentage {
+			return stats[i].Percentage > stats[j].Percentage
+		}
+		return stats[i].Count > stats[j].Count
+	})
+	return stats, nil
+}
+
+// parseClassificationStatisticsXML parses the XML form of a classification statistics response.
+func parseClassificationStatisticsXML(xmlData string) ([]ClassificationStat, error) {
+	var raw classificationStatisticsXML
+	if err := xml.Unmarshal([]byte(xmlData), &raw); err != nil {
+		return nil, &XMLParseError{
+			Parser:    "ParseClassificationStatistics",
+			Element:   "root",
+			XMLSample: truncateXML(xmlData, 200),
+			Cause:     err,
+		}
+	}
+
+	stats := make([]ClassificationStat, 0, len(raw.Statistics))
+	for _, entry := range raw.Statistics {
+		var parts []string
+		for _, part := range entry.TitleParts {
+			parts = append(parts, part.Texts...)
+		}
+		stat, err := newClassificationStat(entry.Symbol, entry.Percentage, entry.Count, parts)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// parseClassificationStatisticsJSON parses the JSON form of a classification statistics response.
+func parseClassificationStatisticsJSON(jsonData string) ([]ClassificationStat, error) {
+	invalid := func(err error) error {
+		return &DataValidationError{
+			Parser:  "ParseClassificationStatistics",
+			Message: fmt.Sprintf("invalid JSON response: %v", err),
+		}
+	}
+
+	var raw classificationStatisticsJSON
+	if err := json.Unmarshal([]byte(jsonData), &raw); err != nil {
+		return nil, invalid(err)
+	}
+
+	// An empty search-result is serialized as "" or omitted
+	var result struct {
+		Statistics json.RawMessage `json:"ops:classification-statistics"`
+	}
+	if searchResult := raw.WorldPatentData.Search.SearchResult; bytes.HasPrefix(bytes.TrimSpace(searchResult), []byte("{")) {
+		if err := json.Unmarshal(searchResult, &result); err != nil {
+			return nil, invalid(err)
+		}
+	}
+
+	var entries []classificationStatJSON
+	if err := unmarshalJSONList(result.Statistics, &entries); err != nil {
+		return nil, invalid(err)
+	}
+
+	stats := make([]ClassificationStat, 0, len(entries))
+	for _, entry := range entries {
+		var titleParts []struct {
+			Text json.RawMessage `json:"cpc:text"`
+		}
+		if err := unmarshalJSONList(entry.ClassTitle.TitleParts, &titleParts); err != nil {
+			return nil, invalid(err)
+		}
+
+		var parts []string
+		for _, part := range titleParts {
+			var texts []struct {
+				Value string `json:"$"`
+			}
+			if err := unmarshalJSONList(part.Text, &texts); err != nil {
+				return nil, invalid(err)
+			}
+			for _, text := range texts {
+				parts = append(parts, text.Value)
+			}
+		}
+
+		stat, err := newClassificationStat(entry.Symbol, entry.Percentage, entry.Count, parts)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// unmarshalJSONList decodes an OPS JSON element that is an object when it occurs once
+// and an array when it repeats into the slice pointed to by v. Missing data leaves v empty.
+func unmarshalJSONList(data json.RawMessage, v any) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		return nil
+	case trimmed[0] == '[':
+		return json.Unmarshal(trimmed, v)
+	default:
+		wrapped := make([]byte, 0, len(trimmed)+2)
+		wrapped = append(wrapped, '[')
+		wrapped = append(wrapped, trimmed...)
+		wrapped = append(wrapped, ']')
+		return json.Unmarshal(wrapped, v)
+	}
+}
+
+// joinClassificationTitle joins the title parts of a classification entry with "; ",
+// skipping empty parts.
+func joinClassificationTitle(parts []string) string {
+	var title []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			title = append(title, part)
+		}
+	}
+	return strings.Join(title, "; ")
+}
+
+// newClassificationStat builds a ClassificationStat from the attribute values of one entry.
+func newClassificationStat(symbol, percentage, count string, titleParts []string) (ClassificationStat, error) {
+	stat := ClassificationStat{
+		Symbol: strings.TrimSpace(symbol),
+		Title:  joinClassificationTitle(titleParts),
+	}
+
+	if percentage = strings.TrimSpace(percentage); percentage != "" {
+		value, err := strconv.ParseFloat(percentage, 64)
+		if err != nil {
+			return stat, &DataValidationError{
+				Parser:       "ParseClassificationStatistics",
+				MissingField: "percentage",
+				Message:      fmt.Sprintf("invalid percentage %q for %s", percentage, stat.Symbol),
+			}
+		}
+		stat.Percentage = value
+	}
+	if count = strings.TrimSpace(count); count != "" {
+		value, err := strconv.Atoi(count)
+		if err != nil {
+			return stat, &DataValidationError{
+				Parser:       "ParseClassificationStatistics",
+				MissingField: "count",
+				Message:      fmt.Sprintf("invalid count %q for %s", count, stat.Symbol),
+			}
+		}
+		stat.Count = value
+	}
+	return stat, nil
+}
@@ -2,6 +2,11 @@ package epo_ops
 
 import (
 	"embed"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -84,6 +89,12 @@ func TestParseBiblio(t *testing.T) {
 	if len(data.CPCClasses) == 0 {
 		t.Error("No CPC classes found")
 	}
+	if len(data.IPCClassesParsed) != len(data.IPCClasses) {
+		t.Fatalf("IPCClassesParsed: got %d, want %d", len(data.IPCClassesParsed), len(data.IPCClasses))
+	}
+	if ipc := data.IPCClassesParsed[0]; ipc.Full != "H04W 84/20" || ipc.Level != "A" || ipc.Value != "I" {
+		t.Errorf("IPCClassesParsed[0]: got %+v", ipc)
+	}
 
 	t.Logf("Titles: %v", data.Titles)
 	t.Logf("Applicants: %v", data.Applicants)
@@ -91,6 +102,125 @@ func TestParseBiblio(t *testing.T) {
 	t.Logf("CPC: %d classes", len(data.CPCClasses))
 }
 
+func TestBiblioDataJSON(t *testing.T) {
+	xmlData, err := xmlTestData.ReadFile("testdata/biblio.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+	data, err := ParseBiblio(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseBiblio failed: %v", err)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	var keys []string
+	for key := range decoded {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	want := []string{
+		"applicants", "country", "cpc_classes", "doc_number", "family_id", "inventors",
+		"ipc_classes", "ipc_classes_parsed", "kind", "patent_number", "publication_date", "titles",
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("JSON keys:\ngot  %v\nwant %v", keys, want)
+	}
+	if string(decoded["patent_number"]) != `"EP2400812A1"` {
+		t.Errorf("patent_number: got %s", decoded["patent_number"])
+	}
+
+	// Nested types use the same convention
+	var applicants []map[string]any
+	if err := json.Unmarshal(decoded["applicants"], &applicants); err != nil {
+		t.Fatalf("json.Unmarshal applicants failed: %v", err)
+	}
+	if _, ok := applicants[0]["name"]; !ok || len(applicants[0]) != 2 {
+		t.Errorf("Applicant keys: got %v, want name and country", applicants[0])
+	}
+	var ipc []map[string]any
+	if err := json.Unmarshal(decoded["ipc_classes_parsed"], &ipc); err != nil {
+		t.Fatalf("json.Unmarshal ipc_classes_parsed failed: %v", err)
+	}
+	if ipc[0]["main_group"] != "84" || ipc[0]["action_date"] == nil {
+		t.Errorf("IPC class: got %v", ipc[0])
+	}
+
+	// The JSON form round-trips
+	var roundTrip BiblioData
+	if err := json.Unmarshal(encoded, &roundTrip); err != nil {
+		t.Fatalf("json.Unmarshal BiblioData failed: %v", err)
+	}
+	data.XMLName = xml.Name{}
+	if !reflect.DeepEqual(&roundTrip, data) {
+		t.Errorf("Round trip:\ngot  %+v\nwant %+v", roundTrip, *data)
+	}
+}
+
+func TestParseAbstract_MultipleLanguages(t *testing.T) {
+	xmlData, err := xmlTestData.ReadFile("testdata/abstract_multilang.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	data, err := ParseAbstract(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseAbstract failed: %v", err)
+	}
+
+	if data.PatentNumber != "EP1000000A1" {
+		t.Errorf("PatentNumber: got %q, want %q", data.PatentNumber, "EP1000000A1")
+	}
+	if len(data.Texts) != 2 {
+		t.Fatalf("Texts: got %d languages, want 2", len(data.Texts))
+	}
+	if !strings.HasPrefix(data.Texts["de"], "Vorrichtung zum Herstellen") {
+		t.Errorf("German abstract: got %.40q", data.Texts["de"])
+	}
+	if !strings.HasPrefix(data.Texts["en"], "Apparatus for manufacturing") {
+		t.Errorf("English abstract: got %.40q", data.Texts["en"])
+	}
+
+	// English is preferred even though German comes first
+	if data.Language != "en" {
+		t.Errorf("Language: got %q, want %q", data.Language, "en")
+	}
+	if data.Text != data.Texts["en"] {
+		t.Errorf("Text: got %.40q, want the English abstract", data.Text)
+	}
+}
+
+func TestParseAbstract_Markup(t *testing.T) {
+	xmlData, err := xmlTestData.ReadFile("testdata/abstract_markup.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	data, err := ParseAbstract(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseAbstract failed: %v", err)
+	}
+
+	want := "A process for producing hydrogen peroxide (H2O2) comprises reacting H2 with O2 over a Pd catalyst at 105 Pa.\n" +
+		"The catalyst is supported on Al2O3."
+	if got := data.PlainText(); got != want {
+		t.Errorf("PlainText:\ngot  %q\nwant %q", got, want)
+	}
+	if !strings.Contains(data.Text, "(H2O2) comprises reacting H2 with O2") {
+		t.Errorf("Text lost subscript content: %q", data.Text)
+	}
+	if got := data.Texts["fr"]; got != "Procédé de production de H2O2." {
+		t.Errorf("French abstract: got %q", got)
+	}
+}
+
 func TestParseClaims(t *testing.T) {
 	xmlData, err := xmlTestData.ReadFile("testdata/claims.xml")
 	if err != nil {
@@ -131,6 +261,209 @@ func TestParseClaims(t *testing.T) {
 	t.Logf("First claim: %.100s...", data.Claims[0].Text)
 }
 
+func TestParseClaims_MultipleLanguages(t *testing.T) {
+	xmlData, err := xmlTestData.ReadFile("testdata/claims_multilang.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	data, err := ParseClaims(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseClaims failed: %v", err)
+	}
+
+	if data.PatentNumber != "EP1000000B1" {
+		t.Errorf("PatentNumber: got %q, want %q", data.PatentNumber, "EP1000000B1")
+	}
+
+	// The first block stays the default for compatibility
+	if data.Language != "DE" {
+		t.Errorf("Language: got %q, want %q", data.Language, "DE")
+	}
+
+	languages := data.AllLanguages()
+	if strings.Join(languages, ",") != "DE,EN,FR" {
+		t.Errorf("AllLanguages: got %v, want [DE EN FR]", languages)
+	}
+
+	for _, lang := range languages {
+		if got := len(data.ClaimsByLanguage[lang]); got != 3 {
+			t.Errorf("%s claims: got %d, want 3", lang, got)
+		}
+	}
+
+	english := data.InLanguage("en")
+	if english == nil {
+		t.Fatal("InLanguage(\"en\") returned nil")
+	}
+	if english.Language != "EN" {
+		t.Errorf("InLanguage Language: got %q, want %q", english.Language, "EN")
+	}
+	if !strings.HasPrefix(english.Claims[0].Text, "1. Apparatus for manufacturing green bodies") {
+		t.Errorf("Unexpected first English claim: %.60s", english.Claims[0].Text)
+	}
+	if english.Claims[2].Number != 3 {
+		t.Errorf("Third claim number: got %d, want 3", english.Claims[2].Number)
+	}
+
+	// The original data is not modified
+	if data.Language != "DE" {
+		t.Errorf("InLanguage modified the original data: Language %q", data.Language)
+	}
+
+	if data.InLanguage("ja") != nil {
+		t.Error("Expected nil for missing language")
+	}
+
+	// Keys differing only in case resolve deterministically
+	mixed := &ClaimsData{ClaimsByLanguage: map[string][]Claim{
+		"en": {{Number: 1}}, "EN": {{Number: 2}}, "En": {{Number: 3}},
+	}}
+	for i := 0; i < 20; i++ {
+		if got := mixed.InLanguage("en"); got == nil || got.Language != "en" {
+			t.Fatalf("InLanguage(\"en\") exact match: got %+v", got)
+		}
+		if got := mixed.InLanguage("eN"); got == nil || got.Language != "EN" {
+			t.Fatalf("InLanguage(\"eN\") fallback: got %+v", got)
+		}
+	}
+}
+
+func TestParseClaims_Structure(t *testing.T) {
+	xmlData, err := xmlTestData.ReadFile("testdata/claims_structured.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	data, err := ParseClaims(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseClaims failed: %v", err)
+	}
+
+	// Numbers come from the num attribute, so the missing claim 4 leaves a gap
+	want := []struct {
+		number    int
+		num       string
+		dependsOn []int
+	}{
+		{1, "0001", nil},
+		{2, "0002", []int{1}},
+		{3, "0003", []int{1, 2}},
+		{5, "0005", []int{1, 2, 3}}, // the later claim 7 is not a dependency
+		{6, "0006", nil},
+		{7, "0007", []int{2, 3, 5, 6}},
+	}
+	if len(data.Claims) != len(want) {
+		t.Fatalf("Claims: got %d, want %d", len(data.Claims), len(want))
+	}
+	for i, w := range want {
+		claim := data.Claims[i]
+		if claim.Number != w.number || claim.Num != w.num || !reflect.DeepEqual(claim.DependsOn, w.dependsOn) {
+			t.Errorf("Claim %d: got number %d, num %q, depends on %v; want %d, %q, %v",
+				i, claim.Number, claim.Num, claim.DependsOn, w.number, w.num, w.dependsOn)
+		}
+	}
+
+	// Nested claim-text elements
+	first := data.Claims[0]
+	wantParts := []ClaimText{
+		{Text: "a press (1) for forming the green bodies; and"},
+		{Text: "a conveyor (2) for the green bodies, the conveyor having", Parts: []ClaimText{
+			{Text: "a belt (3), and"},
+			{Text: "a drive (4) for the belt."},
+		}},
+	}
+	if !reflect.DeepEqual(first.Parts, wantParts) {
+		t.Errorf("Claim 1 parts:\ngot  %+v\nwant %+v", first.Parts, wantParts)
+	}
+	wantText := "Apparatus for manufacturing green bodies from a ceramic mass, comprising:\n" +
+		"a press (1) for forming the green bodies; and\n" +
+		"a conveyor (2) for the green bodies, the conveyor having\n" +
+		"a belt (3), and\n" +
+		"a drive (4) for the belt."
+	if first.Text != wantText {
+		t.Errorf("Claim 1 text:\ngot  %q\nwant %q", first.Text, wantText)
+	}
+	if data.Claims[1].Parts != nil {
+		t.Errorf("Expected no parts for a plain claim, got %+v", data.Claims[1].Parts)
+	}
+
+	// Text-only responses in several languages
+	multilang, err := xmlTestData.ReadFile("testdata/claims_multilang.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+	data, err = ParseClaims(string(multilang))
+	if err != nil {
+		t.Fatalf("ParseClaims failed: %v", err)
+	}
+	for lang, claims := range data.ClaimsByLanguage {
+		if got := claims[2].DependsOn; !reflect.DeepEqual(got, []int{1, 2}) {
+			t.Errorf("%s claim 3 depends on %v, want [1 2]", lang, got)
+		}
+		if claims[2].Num != "" {
+			t.Errorf("%s claim 3: expected no num attribute, got %q", lang, claims[2].Num)
+		}
+	}
+}
+
+func TestParseClaims_DeletedClaim(t *testing.T) {
+	xmlData, err := xmlTestData.ReadFile("testdata/claims_deleted.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	data, err := ParseClaims(string(xmlData))
+	if err != nil {
+		t.Fatalf("ParseClaims failed: %v", err)
+	}
+
+	// Claim 3 was deleted: EN numbers come from num, DE numbers from id
+	for _, lang := range []string{"EN", "DE"} {
+		claims := data.ClaimsByLanguage[lang]
+		var numbers []int
+		for _, claim := range claims {
+			numbers = append(numbers, claim.Number)
+		}
+		if want := []int{1, 2, 4}; !reflect.DeepEqual(numbers, want) {
+			t.Errorf("%s claim numbers: got %v, want %v", lang, numbers, want)
+		}
+		if len(claims) == 3 && !reflect.DeepEqual(claims[2].DependsOn, []int{1, 2}) {
+			t.Errorf("%s claim 4 depends on %v, want [1 2]", lang, claims[2].DependsOn)
+		}
+	}
+
+	if got := data.ClaimsByLanguage["DE"][2]; got.ID != "c-de-0004" || got.Num != "" {
+		t.Errorf("DE claim 4: got ID %q, Num %q", got.ID, got.Num)
+	}
+	if got := data.Claims[2].Num; got != "0004" {
+		t.Errorf("EN claim 4 Num: got %q, want %q", got, "0004")
+	}
+}
+
+func TestClaimReferences(t *testing.T) {
+	tests := []struct {
+		text   string
+		number int
+		want   []int
+	}{
+		{"A device comprising a press.", 1, nil},
+		{"The device of claim 1, wherein", 2, []int{1}},
+		{"according to claims 1-3", 4, []int{1, 2, 3}},
+		{"according to any one of claims 1 to 3 or claim 5", 6, []int{1, 2, 3, 5}},
+		{"according to claim 1, 2, or 4 and claim 2", 5, []int{1, 2, 4}},
+		{"Vorrichtung nach einem der Ansprüche 1 bis 3", 4, []int{1, 2, 3}},
+		{"Dispositif selon l'une des revendications 1 à 3", 4, []int{1, 2, 3}},
+		{"as in claim 3 and claim 9", 5, []int{3}},
+		{"as in claims 3 and 9", 0, []int{3, 9}},
+	}
+	for _, tt := range tests {
+		if got := ClaimReferences(tt.text, tt.number); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ClaimReferences(%q, %d) = %v, want %v", tt.text, tt.number, got, tt.want)
+		}
+	}
+}
+
 func TestParseImageInquiry(t *testing.T) {
 	xmlData, err := xmlTestData.ReadFile("testdata/image-inquiry.xml")
 	if err != nil {